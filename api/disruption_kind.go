@@ -7,6 +7,7 @@ package api
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -38,10 +39,16 @@ type DisruptionArgs struct {
 	ChaosNamespace       string
 	DryRun               bool
 	OnInit               bool
+	AllowRootDiskFill    bool
 	PulseInitialDelay    time.Duration
 	PulseActiveDuration  time.Duration
 	PulseDormantDuration time.Duration
-	NotInjectedBefore    time.Time
+	// maximum pulse durations, the pulse durations being randomized from the above minimum ones when set
+	PulseMaxActiveDuration  time.Duration
+	PulseMaxDormantDuration time.Duration
+	PulseSeed               *int64
+	PulseAnchor             time.Time // time at which the first active state of the pulse starts on all targets
+	NotInjectedBefore       time.Time
 }
 
 // CreateCmdArgs is a helper function generating common and global args and appending them to the given args array
@@ -81,6 +88,22 @@ func (d DisruptionArgs) CreateCmdArgs(args []string) []string {
 	if d.PulseActiveDuration > 0 && d.PulseDormantDuration > 0 {
 		args = append(args, "--pulse-active-duration", d.PulseActiveDuration.String())
 		args = append(args, "--pulse-dormant-duration", d.PulseDormantDuration.String())
+
+		if d.PulseMaxActiveDuration > 0 {
+			args = append(args, "--pulse-max-active-duration", d.PulseMaxActiveDuration.String())
+		}
+
+		if d.PulseMaxDormantDuration > 0 {
+			args = append(args, "--pulse-max-dormant-duration", d.PulseMaxDormantDuration.String())
+		}
+
+		if d.PulseSeed != nil {
+			args = append(args, "--pulse-seed", strconv.FormatInt(*d.PulseSeed, 10))
+		}
+
+		if !d.PulseAnchor.IsZero() {
+			args = append(args, "--pulse-anchor", d.PulseAnchor.Format(time.RFC3339))
+		}
 	}
 
 	if d.PulseInitialDelay > 0 {
@@ -104,5 +127,10 @@ func (d DisruptionArgs) CreateCmdArgs(args []string) []string {
 		}
 	}
 
+	// allow disk fill disruptions to fill the host root filesystem
+	if d.Kind == chaostypes.DisruptionKindDiskFill && d.AllowRootDiskFill {
+		args = append(args, "--allow-root-filesystem")
+	}
+
 	return args
 }
@@ -0,0 +1,211 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package v1beta1
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// AbortProbeType is the type of response returned by an abort condition probe endpoint
+type AbortProbeType string
+
+// AbortProbeOperator is the comparison applied between the probe value and its threshold
+type AbortProbeOperator string
+
+const (
+	// AbortProbeTypePrometheus queries a prometheus compatible API and reads the first sample of the query result
+	AbortProbeTypePrometheus AbortProbeType = "prometheus"
+	// AbortProbeTypeJSON reads the value located at the given JSON path of the endpoint response
+	AbortProbeTypeJSON AbortProbeType = "json"
+
+	// AbortProbeOperatorAbove aborts the disruption when the probe value is strictly greater than the threshold
+	AbortProbeOperatorAbove AbortProbeOperator = "above"
+	// AbortProbeOperatorBelow aborts the disruption when the probe value is strictly lower than the threshold
+	AbortProbeOperatorBelow AbortProbeOperator = "below"
+
+	// DefaultAbortProbeInterval is the default interval between two probe checks
+	DefaultAbortProbeInterval = 30 * time.Second
+)
+
+// DisruptionAbortConditions defines the conditions terminating a disruption before its duration is over
+// the disruption is aborted as soon as any of the conditions holds
+// +ddmark:validation:AtLeastOneOf={NotReadyTargets,Restarts,Probes}
+type DisruptionAbortConditions struct {
+	// +nullable
+	NotReadyTargets *NotReadyTargetsAbortCondition `json:"notReadyTargets,omitempty"`
+	// +nullable
+	Restarts *RestartsAbortCondition `json:"restarts,omitempty"`
+	// +nullable
+	Probes []AbortProbe `json:"probes,omitempty"`
+}
+
+// NotReadyTargetsAbortCondition aborts the disruption when at least count targets are not ready for the given duration
+type NotReadyTargetsAbortCondition struct {
+	// +kubebuilder:validation:Required
+	// +ddmark:validation:Required=true
+	Count *intstr.IntOrString `json:"count"` // number of not ready targets in either integer form or percent form of the selected targets appended with a %
+	// +kubebuilder:validation:Required
+	// +ddmark:validation:Required=true
+	Duration DisruptionDuration `json:"duration"` // minimum time a target must be not ready to be counted
+}
+
+// RestartsAbortCondition aborts the disruption when a target restarted more than the given threshold since it was selected
+type RestartsAbortCondition struct {
+	// +kubebuilder:validation:Minimum=1
+	// +ddmark:validation:Required=true
+	Threshold int32 `json:"threshold"`
+}
+
+// AbortProbe aborts the disruption when the value returned by an HTTP endpoint crosses the given threshold
+type AbortProbe struct {
+	Name string `json:"name,omitempty"`
+	// +kubebuilder:validation:Enum=prometheus;json
+	// +ddmark:validation:Enum=prometheus;json
+	// +ddmark:validation:Required=true
+	Type AbortProbeType `json:"type"`
+	// URL of the endpoint, the base URL of the API for prometheus probes (e.g. http://prometheus:9090)
+	// +ddmark:validation:Required=true
+	URL string `json:"url"`
+	// Query is the prometheus query for prometheus probes or the JSON path (e.g. data.errors.rate or items[0].value) for json probes
+	// +ddmark:validation:Required=true
+	Query string `json:"query"`
+	// +kubebuilder:validation:Enum=above;below
+	// +ddmark:validation:Enum=above;below
+	// +ddmark:validation:Required=true
+	Operator AbortProbeOperator `json:"operator"`
+	// Threshold is a float value compared to the probe value
+	// +ddmark:validation:Required=true
+	Threshold string `json:"threshold"`
+	// Interval between two probe checks, defaults to 30s
+	Interval DisruptionDuration `json:"interval,omitempty"`
+}
+
+// Validate validates the abort conditions
+func (s *DisruptionAbortConditions) Validate() (retErr error) {
+	if s.NotReadyTargets == nil && s.Restarts == nil && len(s.Probes) == 0 {
+		retErr = multierror.Append(retErr, errors.New("at least one abort condition must be set"))
+	}
+
+	if s.NotReadyTargets != nil {
+		if err := ValidateCount(s.NotReadyTargets.Count); err != nil {
+			retErr = multierror.Append(retErr, fmt.Errorf("abortConditions.notReadyTargets: %w", err))
+		}
+
+		if s.NotReadyTargets.Duration.Duration() <= 0 {
+			retErr = multierror.Append(retErr, errors.New("abortConditions.notReadyTargets.duration must be greater than 0"))
+		}
+	}
+
+	if s.Restarts != nil && s.Restarts.Threshold < 1 {
+		retErr = multierror.Append(retErr, errors.New("abortConditions.restarts.threshold must be greater than 0"))
+	}
+
+	for i, probe := range s.Probes {
+		if err := probe.Validate(); err != nil {
+			retErr = multierror.Append(retErr, fmt.Errorf("abortConditions.probes[%d]: %w", i, err))
+		}
+	}
+
+	return retErr
+}
+
+// Validate validates the probe fields
+func (p AbortProbe) Validate() error {
+	if p.Type != AbortProbeTypePrometheus && p.Type != AbortProbeTypeJSON {
+		return fmt.Errorf("unknown probe type %s, expected one of prometheus or json", p.Type)
+	}
+
+	if p.Operator != AbortProbeOperatorAbove && p.Operator != AbortProbeOperatorBelow {
+		return fmt.Errorf("unknown probe operator %s, expected one of above or below", p.Operator)
+	}
+
+	if _, err := url.ParseRequestURI(p.URL); err != nil {
+		return fmt.Errorf("invalid probe url %s: %w", p.URL, err)
+	}
+
+	if p.Query == "" {
+		return errors.New("the probe query must not be empty")
+	}
+
+	if _, err := p.GetThreshold(); err != nil {
+		return fmt.Errorf("invalid probe threshold %s: %w", p.Threshold, err)
+	}
+
+	return nil
+}
+
+// ValidateAllowedHosts returns an error if the probe url host is not part of the given allowed hosts
+// an allowed host starting with a dot allows all its subdomains (e.g. .monitoring.svc.cluster.local)
+func (p AbortProbe) ValidateAllowedHosts(allowedHosts []string) error {
+	probeURL, err := url.ParseRequestURI(p.URL)
+	if err != nil {
+		return fmt.Errorf("invalid probe url %s: %w", p.URL, err)
+	}
+
+	if len(allowedHosts) == 0 {
+		return errors.New("probes are disabled by the controller configuration, no probe host is allowed")
+	}
+
+	host := strings.ToLower(probeURL.Hostname())
+
+	for _, allowedHost := range allowedHosts {
+		allowedHost = strings.ToLower(allowedHost)
+
+		if host == allowedHost || (strings.HasPrefix(allowedHost, ".") && strings.HasSuffix(host, allowedHost)) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("the probe host %s is not allowed by the controller configuration, allowed hosts are: %s", host, strings.Join(allowedHosts, ", "))
+}
+
+// GetThreshold returns the threshold as a float
+func (p AbortProbe) GetThreshold() (float64, error) {
+	return strconv.ParseFloat(p.Threshold, 64)
+}
+
+// GetInterval returns the interval between two probe checks, falling back to the default one
+func (p AbortProbe) GetInterval() time.Duration {
+	if p.Interval.Duration() <= 0 {
+		return DefaultAbortProbeInterval
+	}
+
+	return p.Interval.Duration()
+}
+
+// GetName returns the name of the probe, falling back to its url
+func (p AbortProbe) GetName() string {
+	if p.Name == "" {
+		return p.URL
+	}
+
+	return p.Name
+}
+
+// IsCrossed returns true if the given value crosses the probe threshold
+func (p AbortProbe) IsCrossed(value float64) (bool, error) {
+	threshold, err := p.GetThreshold()
+	if err != nil {
+		return false, err
+	}
+
+	switch p.Operator {
+	case AbortProbeOperatorAbove:
+		return value > threshold, nil
+	case AbortProbeOperatorBelow:
+		return value < threshold, nil
+	}
+
+	return false, fmt.Errorf("unknown probe operator %s", p.Operator)
+}
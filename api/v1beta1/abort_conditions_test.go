@@ -0,0 +1,94 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package v1beta1_test
+
+import (
+	. "github.com/DataDog/chaos-controller/api/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+var _ = Describe("DisruptionAbortConditions", func() {
+	validProbe := AbortProbe{
+		Type:      AbortProbeTypePrometheus,
+		URL:       "http://prometheus:9090",
+		Query:     "sum(rate(http_requests_errors[1m]))",
+		Operator:  AbortProbeOperatorAbove,
+		Threshold: "0.5",
+	}
+
+	When("Call the 'Validate' method", func() {
+		It("should succeed with valid conditions", func() {
+			count := intstr.FromString("50%")
+			conditions := DisruptionAbortConditions{
+				NotReadyTargets: &NotReadyTargetsAbortCondition{Count: &count, Duration: "1m"},
+				Restarts:        &RestartsAbortCondition{Threshold: 3},
+				Probes:          []AbortProbe{validProbe},
+			}
+
+			Expect(conditions.Validate()).Should(Succeed())
+		})
+
+		It("should fail without any condition", func() {
+			Expect((&DisruptionAbortConditions{}).Validate()).ShouldNot(Succeed())
+		})
+
+		It("should fail with an invalid not ready targets condition", func() {
+			count := intstr.FromInt(0)
+			conditions := DisruptionAbortConditions{NotReadyTargets: &NotReadyTargetsAbortCondition{Count: &count}}
+
+			err := conditions.Validate()
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("abortConditions.notReadyTargets.duration must be greater than 0"))
+		})
+
+		DescribeTable("should fail with invalid probes",
+			func(mutate func(*AbortProbe), expectedErr string) {
+				probe := validProbe
+				mutate(&probe)
+
+				err := (&DisruptionAbortConditions{Probes: []AbortProbe{probe}}).Validate()
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).Should(ContainSubstring(expectedErr))
+			},
+			Entry("unknown type", func(p *AbortProbe) { p.Type = "graphite" }, "unknown probe type graphite"),
+			Entry("unknown operator", func(p *AbortProbe) { p.Operator = "equal" }, "unknown probe operator equal"),
+			Entry("invalid url", func(p *AbortProbe) { p.URL = "prometheus" }, "invalid probe url prometheus"),
+			Entry("empty query", func(p *AbortProbe) { p.Query = "" }, "the probe query must not be empty"),
+			Entry("invalid threshold", func(p *AbortProbe) { p.Threshold = "high" }, "invalid probe threshold high"),
+		)
+	})
+
+	When("Call the 'ValidateAllowedHosts' method", func() {
+		It("should only accept the allowed hosts and subdomains", func() {
+			probe := validProbe
+			Expect(probe.ValidateAllowedHosts([]string{"prometheus"})).To(Succeed())
+			Expect(probe.ValidateAllowedHosts([]string{"grafana"})).To(MatchError(ContainSubstring("the probe host prometheus is not allowed")))
+
+			probe.URL = "http://prometheus.monitoring.svc.cluster.local:9090"
+			Expect(probe.ValidateAllowedHosts([]string{".monitoring.svc.cluster.local"})).To(Succeed())
+			Expect(probe.ValidateAllowedHosts([]string{".svc.cluster.local"})).To(Succeed())
+			Expect(probe.ValidateAllowedHosts([]string{".other.svc.cluster.local"})).ToNot(Succeed())
+		})
+
+		It("should refuse any probe when no host is allowed", func() {
+			Expect(validProbe.ValidateAllowedHosts(nil)).To(MatchError(ContainSubstring("probes are disabled")))
+		})
+	})
+
+	When("Call the 'IsCrossed' method", func() {
+		It("should compare the value to the threshold depending on the operator", func() {
+			probe := validProbe
+			Expect(probe.IsCrossed(0.6)).Should(BeTrue())
+			Expect(probe.IsCrossed(0.5)).Should(BeFalse())
+
+			probe.Operator = AbortProbeOperatorBelow
+			Expect(probe.IsCrossed(0.4)).Should(BeTrue())
+			Expect(probe.IsCrossed(0.6)).Should(BeFalse())
+		})
+	})
+})
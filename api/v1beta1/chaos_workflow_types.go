@@ -0,0 +1,235 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package v1beta1
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const ChaosWorkflowKind = "ChaosWorkflow"
+
+// ChaosWorkflowWaitFor is the state the disruptions of a step must reach for the step to be completed
+type ChaosWorkflowWaitFor string
+
+const (
+	// ChaosWorkflowWaitForInjected completes the step once its disruptions are injected on all their targets
+	ChaosWorkflowWaitForInjected ChaosWorkflowWaitFor = "Injected"
+	// ChaosWorkflowWaitForFinished completes the step once the duration of its disruptions is over
+	ChaosWorkflowWaitForFinished ChaosWorkflowWaitFor = "Finished"
+)
+
+// ChaosWorkflowPhase is the phase of a workflow or of one of its steps
+type ChaosWorkflowPhase string
+
+const (
+	// ChaosWorkflowPhaseRunning is the phase of a started workflow or step
+	ChaosWorkflowPhaseRunning ChaosWorkflowPhase = "Running"
+	// ChaosWorkflowPhaseSucceeded is the phase of a workflow whose steps are all completed, or of a completed step
+	ChaosWorkflowPhaseSucceeded ChaosWorkflowPhase = "Succeeded"
+	// ChaosWorkflowPhaseFailed is the phase of a workflow stopped because one of its steps failed, or of the failed step
+	ChaosWorkflowPhaseFailed ChaosWorkflowPhase = "Failed"
+)
+
+// ChaosWorkflowSpec defines the desired state of ChaosWorkflow
+type ChaosWorkflowSpec struct {
+	// Steps are run serially, a step starting once the previous one is completed
+	// +kubebuilder:validation:MinItems=1
+	// +ddmark:validation:Required=true
+	Steps []ChaosWorkflowStep `json:"steps"`
+}
+
+// ChaosWorkflowStep creates disruptions in parallel and waits for the given conditions before completing,
+// a step without disruptions being a plain wait for its health probes or wait duration
+type ChaosWorkflowStep struct {
+	// Name of the step, unique in the workflow
+	// +kubebuilder:validation:Required
+	// +ddmark:validation:Required=true
+	Name string `json:"name"`
+	// Disruptions created in parallel when the step starts
+	// +nullable
+	Disruptions []ChaosWorkflowDisruption `json:"disruptions,omitempty"`
+	// State the disruptions of the step must reach for the step to be completed, defaults to Finished
+	// +kubebuilder:validation:Enum=Injected;Finished
+	// +ddmark:validation:Enum=Injected;Finished
+	WaitFor ChaosWorkflowWaitFor `json:"waitFor,omitempty"`
+	// Probes checked once the disruptions reached the expected state, the step being completed once none of them crosses its threshold
+	// +nullable
+	HealthProbes []AbortProbe `json:"healthProbes,omitempty"`
+	// Time to wait once the disruptions and the health probes conditions are met before completing the step
+	Wait DisruptionDuration `json:"wait,omitempty"`
+	// Maximum time for the step to be completed, the workflow failing and the step disruptions being deleted otherwise
+	Timeout DisruptionDuration `json:"timeout,omitempty"`
+}
+
+// ChaosWorkflowDisruption is a disruption created by a workflow step
+type ChaosWorkflowDisruption struct {
+	// Name of the disruption, the created disruption being named <workflow name>-<step name>-<name>
+	// +kubebuilder:validation:Required
+	// +ddmark:validation:Required=true
+	Name string `json:"name"`
+	// +kubebuilder:validation:Required
+	// +ddmark:validation:Required=true
+	Spec DisruptionSpec `json:"spec"`
+}
+
+// ChaosWorkflowStatus defines the observed state of ChaosWorkflow
+type ChaosWorkflowStatus struct {
+	Phase ChaosWorkflowPhase `json:"phase,omitempty"`
+	// +nullable
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// +nullable
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// Status of the started steps, in the order of the spec
+	// +nullable
+	Steps []ChaosWorkflowStepStatus `json:"steps,omitempty"`
+}
+
+// ChaosWorkflowStepStatus is the observed state of a workflow step
+type ChaosWorkflowStepStatus struct {
+	Name  string             `json:"name"`
+	Phase ChaosWorkflowPhase `json:"phase"`
+	// +nullable
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// Time the disruptions and the health probes conditions were met, starting the step wait
+	// +nullable
+	ConditionsMetTime *metav1.Time `json:"conditionsMetTime,omitempty"`
+	// +nullable
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// Disruptions created by the step
+	// +nullable
+	Disruptions []ChaosWorkflowDisruptionStatus `json:"disruptions,omitempty"`
+	// Message describing what the step is waiting for, or why it failed
+	Message string `json:"message,omitempty"`
+}
+
+// ChaosWorkflowDisruptionStatus is the observed state of a disruption created by a workflow step
+type ChaosWorkflowDisruptionStatus struct {
+	Name            string `json:"name"`
+	InjectionStatus string `json:"injectionStatus,omitempty"`
+	Finished        bool   `json:"finished,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ChaosWorkflow is the Schema for the chaosworkflows API
+// +kubebuilder:resource:shortName=chaoswf
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+type ChaosWorkflow struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ChaosWorkflowSpec   `json:"spec,omitempty"`
+	Status ChaosWorkflowStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ChaosWorkflowList contains a list of ChaosWorkflow
+type ChaosWorkflowList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ChaosWorkflow `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ChaosWorkflow{}, &ChaosWorkflowList{})
+}
+
+// GetWaitFor returns the state the disruptions of the step must reach, falling back to Finished
+func (s ChaosWorkflowStep) GetWaitFor() ChaosWorkflowWaitFor {
+	if s.WaitFor == "" {
+		return ChaosWorkflowWaitForFinished
+	}
+
+	return s.WaitFor
+}
+
+// GetChaosWorkflowDisruptionName returns the name of the disruption created by the given step of the given workflow
+func GetChaosWorkflowDisruptionName(workflowName, stepName, disruptionName string) string {
+	return fmt.Sprintf("%s-%s-%s", workflowName, stepName, disruptionName)
+}
+
+// Validate validates the workflow steps and their disruptions
+func (s ChaosWorkflowSpec) Validate() (retErr error) {
+	if len(s.Steps) == 0 {
+		retErr = multierror.Append(retErr, errors.New("the workflow must have at least one step"))
+	}
+
+	stepNames := map[string]struct{}{}
+
+	for i, step := range s.Steps {
+		if _, found := stepNames[step.Name]; found {
+			retErr = multierror.Append(retErr, fmt.Errorf("steps[%d]: the step name %s is used by another step", i, step.Name))
+		}
+
+		stepNames[step.Name] = struct{}{}
+
+		if err := step.Validate(); err != nil {
+			retErr = multierror.Append(retErr, multierror.Prefix(err, fmt.Sprintf("steps[%d]:", i)))
+		}
+	}
+
+	return retErr
+}
+
+// Validate validates the step, including the spec of its disruptions
+func (s ChaosWorkflowStep) Validate() (retErr error) {
+	if errs := validation.IsDNS1123Label(s.Name); len(errs) > 0 {
+		retErr = multierror.Append(retErr, fmt.Errorf("invalid step name %s: %s", s.Name, strings.Join(errs, ", ")))
+	}
+
+	if len(s.Disruptions) == 0 && len(s.HealthProbes) == 0 && s.Wait.Duration() <= 0 {
+		retErr = multierror.Append(retErr, errors.New("a step must have at least one disruption, health probe or wait duration"))
+	}
+
+	switch s.GetWaitFor() {
+	case ChaosWorkflowWaitForInjected, ChaosWorkflowWaitForFinished:
+	default:
+		retErr = multierror.Append(retErr, fmt.Errorf("unknown waitFor %s, expected one of Injected or Finished", s.WaitFor))
+	}
+
+	if s.Wait.Duration() < 0 {
+		retErr = multierror.Append(retErr, errors.New("the wait duration must be greater than or equal to 0"))
+	}
+
+	if s.Timeout.Duration() < 0 {
+		retErr = multierror.Append(retErr, errors.New("the timeout must be greater than or equal to 0"))
+	}
+
+	disruptionNames := map[string]struct{}{}
+
+	for i, disruption := range s.Disruptions {
+		if _, found := disruptionNames[disruption.Name]; found {
+			retErr = multierror.Append(retErr, fmt.Errorf("disruptions[%d]: the disruption name %s is used by another disruption of the step", i, disruption.Name))
+		}
+
+		disruptionNames[disruption.Name] = struct{}{}
+
+		if errs := validation.IsDNS1123Label(disruption.Name); len(errs) > 0 {
+			retErr = multierror.Append(retErr, fmt.Errorf("disruptions[%d]: invalid disruption name %s: %s", i, disruption.Name, strings.Join(errs, ", ")))
+		}
+
+		if err := disruption.Spec.Validate(); err != nil {
+			retErr = multierror.Append(retErr, fmt.Errorf("disruptions[%d].spec: %w", i, err))
+		}
+	}
+
+	for i, probe := range s.HealthProbes {
+		if err := probe.Validate(); err != nil {
+			retErr = multierror.Append(retErr, fmt.Errorf("healthProbes[%d]: %w", i, err))
+		}
+	}
+
+	return retErr
+}
@@ -0,0 +1,105 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package v1beta1_test
+
+import (
+	. "github.com/DataDog/chaos-controller/api/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+var _ = Describe("ChaosWorkflowSpec", func() {
+	var spec ChaosWorkflowSpec
+
+	BeforeEach(func() {
+		count := intstr.FromInt(1)
+		spec = ChaosWorkflowSpec{
+			Steps: []ChaosWorkflowStep{
+				{
+					Name: "latency",
+					Disruptions: []ChaosWorkflowDisruption{
+						{
+							Name: "curl",
+							Spec: DisruptionSpec{
+								Selector: map[string]string{"app": "demo"},
+								Count:    &count,
+								Duration: "5m",
+								Network:  &NetworkDisruptionSpec{Delay: 200},
+							},
+						},
+					},
+				},
+				{
+					Name: "recovery",
+					Wait: "2m",
+				},
+			},
+		}
+	})
+
+	Describe("Validate", func() {
+		It("should succeed with a valid spec", func() {
+			Expect(spec.Validate()).To(Succeed())
+		})
+
+		It("should fail without any step", func() {
+			spec.Steps = nil
+			Expect(spec.Validate()).ShouldNot(Succeed())
+		})
+
+		It("should fail with duplicated step names", func() {
+			spec.Steps[1].Name = "latency"
+			Expect(spec.Validate()).ShouldNot(Succeed())
+		})
+
+		It("should fail with an invalid step name", func() {
+			spec.Steps[0].Name = "Add_Latency"
+			Expect(spec.Validate()).ShouldNot(Succeed())
+		})
+
+		It("should fail with a step doing nothing", func() {
+			spec.Steps[1].Wait = ""
+			Expect(spec.Validate()).ShouldNot(Succeed())
+		})
+
+		It("should fail with an unknown waitFor", func() {
+			spec.Steps[0].WaitFor = "Created"
+			Expect(spec.Validate()).ShouldNot(Succeed())
+		})
+
+		It("should fail with duplicated disruption names in a step", func() {
+			spec.Steps[0].Disruptions = append(spec.Steps[0].Disruptions, spec.Steps[0].Disruptions[0])
+			Expect(spec.Validate()).ShouldNot(Succeed())
+		})
+
+		It("should fail with an invalid disruption spec", func() {
+			count := intstr.FromInt(-1)
+			spec.Steps[0].Disruptions[0].Spec.Count = &count
+			Expect(spec.Validate()).ShouldNot(Succeed())
+		})
+
+		It("should fail with an invalid health probe", func() {
+			spec.Steps[1].HealthProbes = []AbortProbe{{Type: AbortProbeTypeJSON, URL: "not an url", Query: "errors", Operator: AbortProbeOperatorAbove, Threshold: "1"}}
+			Expect(spec.Validate()).ShouldNot(Succeed())
+		})
+	})
+
+	Describe("GetWaitFor", func() {
+		It("should default to Finished", func() {
+			Expect(spec.Steps[0].GetWaitFor()).To(Equal(ChaosWorkflowWaitForFinished))
+
+			spec.Steps[0].WaitFor = ChaosWorkflowWaitForInjected
+			Expect(spec.Steps[0].GetWaitFor()).To(Equal(ChaosWorkflowWaitForInjected))
+		})
+	})
+
+	Describe("GetChaosWorkflowDisruptionName", func() {
+		It("should name the disruption after the workflow and the step", func() {
+			Expect(GetChaosWorkflowDisruptionName("game-day", "latency", "curl")).To(Equal("game-day-latency-curl"))
+		})
+	})
+})
@@ -0,0 +1,108 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package v1beta1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/go-multierror"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// SetupWebhookWithManager registers the chaos workflow validating webhook
+// it must be called after the disruption one which initializes the shared webhook configuration
+func (r *ChaosWorkflow) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:webhookVersions={v1},path=/validate-chaos-datadoghq-com-v1beta1-chaosworkflow,mutating=false,failurePolicy=fail,sideEffects=None,groups=chaos.datadoghq.com,resources=chaosworkflows,verbs=create;update,versions=v1beta1,name=vchaosworkflow.kb.io,admissionReviewVersions={v1,v1beta1}
+
+var _ webhook.Validator = &ChaosWorkflow{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *ChaosWorkflow) ValidateCreate() error {
+	logger.Debugw("validating created chaos workflow", "chaosWorkflowName", r.Name, "chaosWorkflowNamespace", r.Namespace, "spec", r.Spec)
+
+	// delete-only mode, reject everything trying to be created
+	if deleteOnly {
+		return errors.New("the controller is currently in delete-only mode, you can't create new chaos workflows for now")
+	}
+
+	// created disruptions are labeled with the chaos workflow name
+	if errs := validation.IsValidLabelValue(r.Name); len(errs) > 0 {
+		return fmt.Errorf("invalid chaos workflow name: %v", errs)
+	}
+
+	// the disruptions are validated as they will be created, instantiated from the template they reference if any
+	spec := r.Spec.DeepCopy()
+
+	for i := range spec.Steps {
+		for j := range spec.Steps[i].Disruptions {
+			disruption := &spec.Steps[i].Disruptions[j]
+
+			instantiated, err := InstantiateDisruptionSpec(context.Background(), k8sClient, r.Namespace, disruption.Spec)
+			if err != nil {
+				return fmt.Errorf("step %s disruption %s: %w", spec.Steps[i].Name, disruption.Name, err)
+			}
+
+			disruption.Spec = instantiated
+		}
+	}
+
+	if err := spec.Validate(); err != nil {
+		return err
+	}
+
+	var multiErr *multierror.Error
+
+	// health probes make the controller call their url, only the hosts allowed by the controller can be probed
+	for _, step := range spec.Steps {
+		if err := validateAbortProbesAllowedHosts(fmt.Sprintf("step %s healthProbes", step.Name), step.HealthProbes); err != nil {
+			multiErr = multierror.Append(multiErr, err)
+		}
+	}
+
+	for _, step := range spec.Steps {
+		for _, disruption := range step.Disruptions {
+			// created disruptions names are used as chaos pods label values
+			name := GetChaosWorkflowDisruptionName(r.Name, step.Name, disruption.Name)
+			if len(name) > validation.LabelValueMaxLength {
+				multiErr = multierror.Append(multiErr, fmt.Errorf("the name of the disruption %s created by the step %s must be no more than %d characters, please use shorter workflow, step or disruption names", name, step.Name, validation.LabelValueMaxLength))
+			}
+
+			if err := ddmarkClient.ValidateStructMultierror(disruption.Spec, "validation_webhook"); err.ErrorOrNil() != nil {
+				multiErr = multierror.Append(multiErr, multierror.Prefix(err, fmt.Sprintf("ddmark: step %s disruption %s:", step.Name, disruption.Name)))
+			}
+		}
+	}
+
+	return multiErr.ErrorOrNil()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *ChaosWorkflow) ValidateUpdate(old runtime.Object) error {
+	logger.Debugw("validating updated chaos workflow", "chaosWorkflowName", r.Name, "chaosWorkflowNamespace", r.Namespace, "spec", r.Spec)
+
+	// the steps can't be changed while the workflow is running as the status refers to them
+	if !reflect.DeepEqual(old.(*ChaosWorkflow).Spec, r.Spec) {
+		return errors.New("a chaos workflow spec can't be updated, please delete and recreate it if needed")
+	}
+
+	return nil
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *ChaosWorkflow) ValidateDelete() error {
+	return nil
+}
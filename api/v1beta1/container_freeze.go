@@ -0,0 +1,22 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package v1beta1
+
+// ContainerFreezeSpec represents a container freeze injection,
+// the processes of the targeted containers being frozen through the cgroup freezer until the disruption is cleaned
+type ContainerFreezeSpec struct{}
+
+// Validate validates args for the given disruption
+func (s *ContainerFreezeSpec) Validate() error {
+	return nil
+}
+
+// GenerateArgs generates injection or cleanup pod arguments for the given spec
+func (s *ContainerFreezeSpec) GenerateArgs() []string {
+	return []string{
+		"container-freeze",
+	}
+}
@@ -6,27 +6,148 @@
 package v1beta1
 
 import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
 	"github.com/hashicorp/go-multierror"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// CPUPressureProfileType is the shape of the stress level variation over time
+type CPUPressureProfileType string
+
+const (
+	// CPUPressureProfileRamp linearly moves the stress level from the start level to the final level over the profile duration
+	CPUPressureProfileRamp CPUPressureProfileType = "ramp"
+	// CPUPressureProfileSine oscillates the stress level between the start level and the final level, the profile duration being the period
+	CPUPressureProfileSine CPUPressureProfileType = "sine"
+	// CPUPressureProfileStep moves the stress level from the start level to the final level by steps, each step lasting the profile duration
+	CPUPressureProfileStep CPUPressureProfileType = "step"
+
+	// DefaultCPUPressureProfileSteps is the number of steps of a step profile when not specified
+	DefaultCPUPressureProfileSteps = 4
+)
+
 // CPUPressureSpec represents a cpu pressure disruption
 type CPUPressureSpec struct {
 	// Count represents the number of cores to target
 	// either an integer form or a percentage form appended with a %
 	// if empty, it will be considered to be 100%
 	Count *intstr.IntOrString `json:"count,omitempty"`
+	// TargetUtilization is the percentage of time each targeted core should be kept busy,
+	// the stress adapting to the load already generated by the targets (it can't be used with count)
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +ddmark:validation:Minimum=1
+	// +ddmark:validation:Maximum=100
+	TargetUtilization *int `json:"targetUtilization,omitempty"`
+	// Profile makes the stress level vary over time up to the level defined by count or targetUtilization
+	Profile *CPUPressureProfileSpec `json:"profile,omitempty"`
+	// Throttling lowers the CPU limit of the targets instead of stressing them (it can't be used with the stress fields)
+	Throttling *CPUPressureThrottlingSpec `json:"throttling,omitempty"`
+}
+
+// CPUPressureThrottlingSpec represents a temporary lowering of the CPU quota of the targets
+type CPUPressureThrottlingSpec struct {
+	// Percentage is the percentage of the current CPU limit to keep, or of the allocated cores when the targets are not limited
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=99
+	// +ddmark:validation:Minimum=1
+	// +ddmark:validation:Maximum=99
+	// +ddmark:validation:Required=true
+	Percentage int `json:"percentage"`
+}
+
+// CPUPressureProfileSpec represents the variation of a cpu pressure over time
+type CPUPressureProfileSpec struct {
+	// +kubebuilder:validation:Enum=ramp;sine;step
+	// +ddmark:validation:Enum=ramp;sine;step
+	// +ddmark:validation:Required=true
+	Type CPUPressureProfileType `json:"type"`
+	// Duration is the ramp duration, the sine period or the duration of each step
+	// +ddmark:validation:Required=true
+	Duration DisruptionDuration `json:"duration"`
+	// Start is the stress level percentage the profile starts from, 0 by default
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +ddmark:validation:Minimum=0
+	// +ddmark:validation:Maximum=100
+	Start int `json:"start,omitempty"`
+	// Steps is the number of steps of a step profile to reach the final level, 4 by default
+	// +kubebuilder:validation:Minimum=1
+	Steps int `json:"steps,omitempty"`
 }
 
 // Validate validates args for the given disruption
 func (s *CPUPressureSpec) Validate() (retErr error) {
-	if s.Count == nil {
-		return nil
+	// Rule: count must be valid
+	if s.Count != nil {
+		if err := ValidateCount(s.Count); err != nil {
+			retErr = multierror.Append(retErr, err)
+		}
 	}
 
-	// Rule: count must be valid
-	if err := ValidateCount(s.Count); err != nil {
-		retErr = multierror.Append(retErr, err)
+	if s.TargetUtilization != nil {
+		// Rule: count and target utilization are exclusive
+		if s.Count != nil {
+			retErr = multierror.Append(retErr, errors.New("count and targetUtilization can't be used together, the target utilization applies to every targeted core"))
+		}
+
+		// Rule: target utilization is a percentage
+		if *s.TargetUtilization < 1 || *s.TargetUtilization > 100 {
+			retErr = multierror.Append(retErr, fmt.Errorf("the target utilization must be between 1 and 100, got %d", *s.TargetUtilization))
+		}
+	}
+
+	if s.Profile != nil {
+		if err := s.Profile.Validate(); err != nil {
+			retErr = multierror.Append(retErr, err)
+		}
+	}
+
+	if s.Throttling != nil {
+		// Rule: throttling does not generate any load
+		if s.Count != nil || s.TargetUtilization != nil || s.Profile != nil {
+			retErr = multierror.Append(retErr, errors.New("throttling lowers the CPU limit without stressing the targets, it can't be used with count, targetUtilization or profile"))
+		}
+
+		// Rule: throttling percentage must keep some CPU time
+		if s.Throttling.Percentage < 1 || s.Throttling.Percentage > 99 {
+			retErr = multierror.Append(retErr, fmt.Errorf("the throttling percentage must be between 1 and 99, got %d", s.Throttling.Percentage))
+		}
+	}
+
+	return retErr
+}
+
+// Validate validates the given profile
+func (s *CPUPressureProfileSpec) Validate() (retErr error) {
+	switch s.Type {
+	case CPUPressureProfileRamp, CPUPressureProfileSine, CPUPressureProfileStep:
+	default:
+		retErr = multierror.Append(retErr, fmt.Errorf("unknown cpu pressure profile type %q, expected one of ramp, sine or step", s.Type))
+	}
+
+	duration, err := time.ParseDuration(string(s.Duration))
+	if err != nil {
+		retErr = multierror.Append(retErr, fmt.Errorf("invalid cpu pressure profile duration %q: %w", s.Duration, err))
+	} else if duration < time.Second {
+		retErr = multierror.Append(retErr, errors.New("the cpu pressure profile duration must be at least 1s"))
+	}
+
+	if s.Start < 0 || s.Start > 100 {
+		retErr = multierror.Append(retErr, fmt.Errorf("the cpu pressure profile start must be between 0 and 100, got %d", s.Start))
+	}
+
+	if s.Steps < 0 {
+		retErr = multierror.Append(retErr, fmt.Errorf("the cpu pressure profile steps must be greater than 0, got %d", s.Steps))
+	}
+
+	if s.Steps != 0 && s.Type != CPUPressureProfileStep {
+		retErr = multierror.Append(retErr, errors.New("the cpu pressure profile steps can only be used with a step profile"))
 	}
 
 	return retErr
@@ -38,7 +159,13 @@ func (s *CPUPressureSpec) GenerateArgs() []string {
 		"cpu-pressure",
 	}
 
-	if s.Count != nil {
+	if s.Throttling != nil {
+		return append(args, "--throttling-percentage", strconv.Itoa(s.Throttling.Percentage))
+	}
+
+	if s.TargetUtilization != nil {
+		args = append(args, "--target-utilization", strconv.Itoa(*s.TargetUtilization))
+	} else if s.Count != nil {
 		args = append(args, "--count", s.Count.String())
 	} else {
 		// starting from here, we expect downstream consumer to benefit from a valid disruption
@@ -47,5 +174,61 @@ func (s *CPUPressureSpec) GenerateArgs() []string {
 		args = append(args, "--count", "100%")
 	}
 
+	if s.Profile != nil {
+		args = append(args, s.Profile.GenerateArgs()...)
+	}
+
+	return args
+}
+
+// GenerateArgs generates the profile arguments, shared by the cpu pressure and the cpu stress commands
+func (s *CPUPressureProfileSpec) GenerateArgs() []string {
+	args := []string{
+		"--profile", string(s.Type),
+		"--profile-duration", string(s.Duration),
+		"--profile-start", strconv.Itoa(s.Start),
+	}
+
+	if s.Steps != 0 {
+		args = append(args, "--profile-steps", strconv.Itoa(s.Steps))
+	}
+
 	return args
 }
+
+// LevelAt returns the stress level percentage to apply once the given time elapsed since the beginning of the stress,
+// level being the final level of the profile; the final level is returned as is if no profile is defined
+func (s *CPUPressureProfileSpec) LevelAt(level int, elapsed time.Duration) int {
+	if s == nil || s.Duration.Duration() <= 0 || elapsed < 0 {
+		return level
+	}
+
+	delta := float64(level - s.Start)
+	progress := float64(elapsed) / float64(s.Duration.Duration())
+
+	switch s.Type {
+	case CPUPressureProfileRamp:
+		if progress >= 1 {
+			return level
+		}
+
+		return s.Start + int(math.Round(delta*progress))
+	case CPUPressureProfileSine:
+		// start from the start level, reach the final level at half the period and come back
+		return s.Start + int(math.Round(delta*(1-math.Cos(2*math.Pi*progress))/2))
+	case CPUPressureProfileStep:
+		steps := s.Steps
+		if steps == 0 {
+			steps = DefaultCPUPressureProfileSteps
+		}
+
+		step := int(math.Floor(progress))
+		if step >= steps {
+			return level
+		}
+
+		return s.Start + int(math.Round(delta*float64(step)/float64(steps)))
+	default:
+		return level
+	}
+}
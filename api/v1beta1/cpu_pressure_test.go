@@ -0,0 +1,111 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package v1beta1_test
+
+import (
+	"time"
+
+	. "github.com/DataDog/chaos-controller/api/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+var _ = Describe("CPUPressureSpec", func() {
+	var spec CPUPressureSpec
+
+	BeforeEach(func() {
+		spec = CPUPressureSpec{}
+	})
+
+	Describe("Validate", func() {
+		It("should succeed with a target utilization and a profile", func() {
+			targetUtilization := 70
+			spec.TargetUtilization = &targetUtilization
+			spec.Profile = &CPUPressureProfileSpec{Type: CPUPressureProfileStep, Duration: "1m", Start: 10, Steps: 3}
+			Expect(spec.Validate()).To(Succeed())
+		})
+
+		It("should fail with both a count and a target utilization", func() {
+			count := intstr.FromInt(1)
+			targetUtilization := 70
+			spec.Count = &count
+			spec.TargetUtilization = &targetUtilization
+			Expect(spec.Validate()).ToNot(Succeed())
+		})
+
+		It("should fail with a target utilization out of range", func() {
+			targetUtilization := 101
+			spec.TargetUtilization = &targetUtilization
+			Expect(spec.Validate()).ToNot(Succeed())
+		})
+
+		It("should fail with throttling and a count", func() {
+			count := intstr.FromString("50%")
+			spec.Count = &count
+			spec.Throttling = &CPUPressureThrottlingSpec{Percentage: 50}
+			Expect(spec.Validate()).ToNot(Succeed())
+		})
+
+		It("should fail with a throttling percentage out of range", func() {
+			spec.Throttling = &CPUPressureThrottlingSpec{Percentage: 100}
+			Expect(spec.Validate()).ToNot(Succeed())
+		})
+
+		DescribeTable("should fail with an invalid profile",
+			func(profile CPUPressureProfileSpec) {
+				spec.Profile = &profile
+				Expect(spec.Validate()).ToNot(Succeed())
+			},
+			Entry("unknown type", CPUPressureProfileSpec{Type: "square", Duration: "1m"}),
+			Entry("missing duration", CPUPressureProfileSpec{Type: CPUPressureProfileRamp}),
+			Entry("duration too short", CPUPressureProfileSpec{Type: CPUPressureProfileRamp, Duration: "10ms"}),
+			Entry("start out of range", CPUPressureProfileSpec{Type: CPUPressureProfileRamp, Duration: "1m", Start: 120}),
+			Entry("steps on a ramp", CPUPressureProfileSpec{Type: CPUPressureProfileRamp, Duration: "1m", Steps: 2}),
+		)
+	})
+
+	Describe("GenerateArgs", func() {
+		It("should default the count to 100%", func() {
+			Expect(spec.GenerateArgs()).To(Equal([]string{"cpu-pressure", "--count", "100%"}))
+		})
+
+		It("should only generate the throttling args with throttling", func() {
+			spec.Throttling = &CPUPressureThrottlingSpec{Percentage: 50}
+			Expect(spec.GenerateArgs()).To(Equal([]string{"cpu-pressure", "--throttling-percentage", "50"}))
+		})
+
+		It("should generate target utilization and profile args", func() {
+			targetUtilization := 70
+			spec.TargetUtilization = &targetUtilization
+			spec.Profile = &CPUPressureProfileSpec{Type: CPUPressureProfileStep, Duration: "1m0s", Steps: 3}
+			Expect(spec.GenerateArgs()).To(Equal([]string{
+				"cpu-pressure", "--target-utilization", "70",
+				"--profile", "step", "--profile-duration", "1m0s", "--profile-start", "0", "--profile-steps", "3",
+			}))
+		})
+	})
+
+	Describe("CPUPressureProfileSpec.LevelAt", func() {
+		DescribeTable("should compute the level over time",
+			func(profile *CPUPressureProfileSpec, elapsed time.Duration, expected int) {
+				Expect(profile.LevelAt(80, elapsed)).To(Equal(expected))
+			},
+			Entry("no profile", nil, time.Minute, 80),
+			Entry("ramp start", &CPUPressureProfileSpec{Type: CPUPressureProfileRamp, Duration: "5m", Start: 20}, time.Duration(0), 20),
+			Entry("ramp middle", &CPUPressureProfileSpec{Type: CPUPressureProfileRamp, Duration: "5m", Start: 20}, 150*time.Second, 50),
+			Entry("ramp end", &CPUPressureProfileSpec{Type: CPUPressureProfileRamp, Duration: "5m", Start: 20}, time.Hour, 80),
+			Entry("sine start", &CPUPressureProfileSpec{Type: CPUPressureProfileSine, Duration: "1m"}, time.Duration(0), 0),
+			Entry("sine quarter", &CPUPressureProfileSpec{Type: CPUPressureProfileSine, Duration: "1m"}, 15*time.Second, 40),
+			Entry("sine half", &CPUPressureProfileSpec{Type: CPUPressureProfileSine, Duration: "1m"}, 30*time.Second, 80),
+			Entry("sine next period", &CPUPressureProfileSpec{Type: CPUPressureProfileSine, Duration: "1m"}, 60*time.Second, 0),
+			Entry("first step", &CPUPressureProfileSpec{Type: CPUPressureProfileStep, Duration: "1m"}, 30*time.Second, 0),
+			Entry("second step", &CPUPressureProfileSpec{Type: CPUPressureProfileStep, Duration: "1m"}, 90*time.Second, 20),
+			Entry("last step", &CPUPressureProfileSpec{Type: CPUPressureProfileStep, Duration: "1m"}, 4*time.Minute, 80),
+			Entry("custom steps", &CPUPressureProfileSpec{Type: CPUPressureProfileStep, Duration: "1m", Steps: 2}, 70*time.Second, 40),
+		)
+	})
+})
@@ -6,37 +6,139 @@
 package v1beta1
 
 import (
+	"errors"
 	"fmt"
 	"strings"
+
+	"github.com/hashicorp/go-multierror"
 )
 
 // DiskFailureSpec represents a disk failure disruption
 type DiskFailureSpec struct {
-	Path string `json:"path"`
+	// Path is a prefix of the paths failing to be opened, it is a shortcut to a single prefix pattern in paths
+	Path string `json:"path,omitempty"`
+	// Paths is a list of patterns, a path matching any of them fails to be opened
+	// +kubebuilder:validation:MaxItems=16
+	Paths []DiskFailurePathSpec `json:"paths,omitempty"`
 }
 
-const MaxPathCharacters = 62
+// DiskFailurePathSpec represents a pattern matching the paths failing to be opened
+type DiskFailurePathSpec struct {
+	// +ddmark:validation:Required=true
+	Pattern string `json:"pattern"`
+	// Match is the way the pattern is compared to the opened paths: prefix (default), exact or suffix (e.g. ".sst" to match a file extension)
+	// +kubebuilder:validation:Enum=prefix;exact;suffix
+	// +ddmark:validation:Enum=prefix;exact;suffix
+	Match DiskFailurePathMatch `json:"match,omitempty"`
+}
 
-// Validate validates args for the given disruption
-func (s *DiskFailureSpec) Validate() error {
-	path := strings.TrimSpace(s.Path)
+// DiskFailurePathMatch is the way a pattern is compared to the opened paths
+type DiskFailurePathMatch string
+
+const (
+	// DiskFailurePathMatchPrefix matches the paths starting with the pattern
+	DiskFailurePathMatchPrefix DiskFailurePathMatch = "prefix"
+	// DiskFailurePathMatchExact matches the paths equal to the pattern
+	DiskFailurePathMatchExact DiskFailurePathMatch = "exact"
+	// DiskFailurePathMatchSuffix matches the paths ending with the pattern
+	DiskFailurePathMatchSuffix DiskFailurePathMatch = "suffix"
+)
 
-	if path == "" {
+// diskFailurePathWildcards are the glob characters which are not supported in patterns, the prefix, exact and suffix matches being literal
+const diskFailurePathWildcards = "*?["
+
+const (
+	// MaxPathCharacters is the maximum length of a pattern, bound to the size of the BPF program buffers
+	MaxPathCharacters = 255
+	// MaxPathPatterns is the maximum number of patterns, bound to the size of the BPF program map
+	MaxPathPatterns = 16
+)
+
+// Validate validates args for the given disruption
+func (s *DiskFailureSpec) Validate() (retErr error) {
+	if strings.TrimSpace(s.Path) == "" && len(s.Paths) == 0 {
 		return fmt.Errorf("the path of the disk failure disruption must not be empty")
 	}
 
-	if len(path) > MaxPathCharacters {
-		return fmt.Errorf("the path of the disk failure disruption must not exceed %d characters", MaxPathCharacters)
+	patterns := s.PathPatterns()
+
+	if len(patterns) > MaxPathPatterns {
+		retErr = multierror.Append(retErr, fmt.Errorf("the disk failure disruption must not have more than %d paths", MaxPathPatterns))
 	}
 
-	return nil
+	for _, pattern := range patterns {
+		if pattern.Pattern == "" {
+			retErr = multierror.Append(retErr, errors.New("the path of the disk failure disruption must not be empty"))
+		}
+
+		if strings.ContainsAny(pattern.Pattern, diskFailurePathWildcards) {
+			retErr = multierror.Append(retErr, fmt.Errorf("the path %s of the disk failure disruption must not contain wildcard characters (%s), use a prefix, exact or suffix match instead (e.g. a .sst suffix rather than *.sst)", pattern.Pattern, diskFailurePathWildcards))
+		}
+
+		if len(pattern.Pattern) > MaxPathCharacters {
+			retErr = multierror.Append(retErr, fmt.Errorf("the path of the disk failure disruption must not exceed %d characters", MaxPathCharacters))
+		}
+
+		switch pattern.Match {
+		case DiskFailurePathMatchPrefix, DiskFailurePathMatchExact, DiskFailurePathMatchSuffix:
+		default:
+			retErr = multierror.Append(retErr, fmt.Errorf("invalid match %s for the path %s of the disk failure disruption, expected prefix, exact or suffix", pattern.Match, pattern.Pattern))
+		}
+	}
+
+	// keep the single error unwrapped for readability
+	if merr, ok := retErr.(*multierror.Error); ok && len(merr.Errors) == 1 {
+		return merr.Errors[0]
+	}
+
+	return retErr
 }
 
 // GenerateArgs generates injection or cleanup pod arguments for the given spec
 func (s *DiskFailureSpec) GenerateArgs() (args []string) {
 	args = append(args, "disk-failure")
-	path := strings.TrimSpace(s.Path)
-	args = append(args, "--path", path)
+
+	if path := strings.TrimSpace(s.Path); path != "" {
+		args = append(args, "--path", path)
+	}
+
+	for _, pattern := range s.Paths {
+		args = append(args, "--path-pattern", fmt.Sprintf("%s:%s", pattern.GetMatch(), strings.TrimSpace(pattern.Pattern)))
+	}
 
 	return args
 }
+
+// PathPatterns returns all the patterns of the disruption, including the path one
+func (s *DiskFailureSpec) PathPatterns() []DiskFailurePathSpec {
+	patterns := []DiskFailurePathSpec{}
+
+	if path := strings.TrimSpace(s.Path); path != "" {
+		patterns = append(patterns, DiskFailurePathSpec{Pattern: path, Match: DiskFailurePathMatchPrefix})
+	}
+
+	for _, pattern := range s.Paths {
+		patterns = append(patterns, DiskFailurePathSpec{Pattern: strings.TrimSpace(pattern.Pattern), Match: pattern.GetMatch()})
+	}
+
+	return patterns
+}
+
+// GetMatch returns the way the pattern is compared to the opened paths, defaulting to prefix
+func (s DiskFailurePathSpec) GetMatch() DiskFailurePathMatch {
+	if s.Match == "" {
+		return DiskFailurePathMatchPrefix
+	}
+
+	return s.Match
+}
+
+// ParseDiskFailurePathPattern parses a pattern formatted as <match>:<pattern> as generated in the injector args
+func ParseDiskFailurePathPattern(raw string) (DiskFailurePathSpec, error) {
+	match, pattern, found := strings.Cut(raw, ":")
+	if !found {
+		return DiskFailurePathSpec{}, fmt.Errorf("invalid path pattern %s, expected <match>:<pattern>", raw)
+	}
+
+	return DiskFailurePathSpec{Pattern: pattern, Match: DiskFailurePathMatch(match)}, nil
+}
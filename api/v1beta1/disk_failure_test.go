@@ -24,9 +24,9 @@ var _ = Describe("DiskFailureSpec", func() {
 			err = df.Validate()
 		})
 
-		Context("with a valid path not exceeding 62 characters", func() {
+		Context("with a valid path not exceeding 255 characters", func() {
 			BeforeEach(func() {
-				path = randStringRunes(rand.IntnRange(1, 62))
+				path = randStringRunes(rand.IntnRange(1, 255))
 			})
 			It("should not return an error", func() {
 				Expect(err).ShouldNot(HaveOccurred())
@@ -42,13 +42,13 @@ var _ = Describe("DiskFailureSpec", func() {
 			})
 		})
 
-		Context("with a path more than 62 characters", func() {
+		Context("with a path more than 255 characters", func() {
 			BeforeEach(func() {
-				path = randStringRunes(rand.IntnRange(63, 10000))
+				path = randStringRunes(rand.IntnRange(256, 10000))
 			})
 			It("should return an error", func() {
 				Expect(err).Should(HaveOccurred())
-				Expect(err.Error()).Should(Equal("the path of the disk failure disruption must not exceed 62 characters"))
+				Expect(err.Error()).Should(Equal("the path of the disk failure disruption must not exceed 255 characters"))
 			})
 		})
 
@@ -75,6 +75,77 @@ var _ = Describe("DiskFailureSpec", func() {
 		})
 	})
 
+	When("Call the 'Validate' method with path patterns", func() {
+		var spec DiskFailureSpec
+
+		BeforeEach(func() {
+			spec = DiskFailureSpec{
+				Paths: []DiskFailurePathSpec{
+					{Pattern: "/var/lib/data/"},
+					{Pattern: ".sst", Match: DiskFailurePathMatchSuffix},
+					{Pattern: "/etc/hosts", Match: DiskFailurePathMatchExact},
+				},
+			}
+		})
+
+		It("should not return an error", func() {
+			Expect(spec.Validate()).To(Succeed())
+		})
+
+		Context("with an invalid match", func() {
+			BeforeEach(func() {
+				spec.Paths[0].Match = "glob"
+			})
+
+			It("should return an error", func() {
+				Expect(spec.Validate()).ToNot(Succeed())
+			})
+		})
+
+		Context("with too many patterns", func() {
+			BeforeEach(func() {
+				for i := 0; i < MaxPathPatterns; i++ {
+					spec.Paths = append(spec.Paths, DiskFailurePathSpec{Pattern: randStringRunes(10)})
+				}
+			})
+
+			It("should return an error", func() {
+				Expect(spec.Validate()).To(MatchError(ContainSubstring("must not have more than 16 paths")))
+			})
+		})
+
+		Context("with an empty pattern", func() {
+			BeforeEach(func() {
+				spec.Paths[1].Pattern = " "
+			})
+
+			It("should return an error", func() {
+				Expect(spec.Validate()).To(MatchError("the path of the disk failure disruption must not be empty"))
+			})
+		})
+
+		DescribeTable("with a glob pattern",
+			func(pattern string) {
+				spec.Paths[1].Pattern = pattern
+
+				Expect(spec.Validate()).To(MatchError(ContainSubstring("must not contain wildcard characters")))
+			},
+			Entry("star", "*.sst"),
+			Entry("question mark", "/data/file-?.log"),
+			Entry("character class", "/data/[ab].log"),
+		)
+
+		Context("with a glob pattern in the path shortcut", func() {
+			BeforeEach(func() {
+				spec.Path = "/var/lib/*/data"
+			})
+
+			It("should return an error", func() {
+				Expect(spec.Validate()).To(MatchError(ContainSubstring("must not contain wildcard characters")))
+			})
+		})
+	})
+
 	When("Call the 'GenerateArgs' method", func() {
 		var args []string
 		var path string
@@ -102,6 +173,20 @@ var _ = Describe("DiskFailureSpec", func() {
 			})
 		})
 
+		Context("with path patterns", func() {
+			It("should return args with the path patterns prefixed with their match", func() {
+				spec := DiskFailureSpec{
+					Path: "/data",
+					Paths: []DiskFailurePathSpec{
+						{Pattern: "/var/lib/data/"},
+						{Pattern: ".sst", Match: DiskFailurePathMatchSuffix},
+					},
+				}
+
+				Expect(spec.GenerateArgs()).Should(Equal([]string{"disk-failure", "--path", "/data", "--path-pattern", "prefix:/var/lib/data/", "--path-pattern", "suffix:.sst"}))
+			})
+		})
+
 		Context("with a path containing spaces", func() {
 			BeforeEach(func() {
 				path = "  /  "
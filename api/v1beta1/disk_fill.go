@@ -0,0 +1,87 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package v1beta1
+
+import (
+	"errors"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	chaostypes "github.com/DataDog/chaos-controller/types"
+	"github.com/hashicorp/go-multierror"
+)
+
+// DiskFillSpec represents a disk fill disruption
+// +ddmark:validation:ExclusiveFields={Percentage,RemainingBytes}
+// +ddmark:validation:AtLeastOneOf={Percentage,RemainingBytes}
+type DiskFillSpec struct {
+	// Path is the path inside the target (container or node) where files are allocated to fill its filesystem
+	// +ddmark:validation:Required=true
+	Path string `json:"path"`
+	// Percentage is the used space percentage of the filesystem to reach and keep
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +ddmark:validation:Minimum=1
+	// +ddmark:validation:Maximum=100
+	Percentage *int `json:"percentage,omitempty"`
+	// RemainingBytes is the number of available bytes to leave on the filesystem
+	// +kubebuilder:validation:Minimum=0
+	// +ddmark:validation:Minimum=0
+	RemainingBytes *int `json:"remainingBytes,omitempty"`
+}
+
+// Validate validates args for the given disruption
+func (s *DiskFillSpec) Validate() (retErr error) {
+	if strings.TrimSpace(s.Path) == "" {
+		retErr = multierror.Append(retErr, errors.New("the path of the disk fill disruption must not be empty"))
+	}
+
+	if s.Percentage == nil && s.RemainingBytes == nil {
+		retErr = multierror.Append(retErr, errors.New("the disk fill disruption requires either a percentage or a remaining bytes target"))
+	}
+
+	if s.Percentage != nil && s.RemainingBytes != nil {
+		retErr = multierror.Append(retErr, errors.New("the percentage and remaining bytes targets of the disk fill disruption are mutually exclusive"))
+	}
+
+	if s.Percentage != nil && (*s.Percentage < 1 || *s.Percentage > 100) {
+		retErr = multierror.Append(retErr, errors.New("the percentage of the disk fill disruption must be between 1 and 100"))
+	}
+
+	if s.RemainingBytes != nil && *s.RemainingBytes < 0 {
+		retErr = multierror.Append(retErr, errors.New("the remaining bytes of the disk fill disruption must not be negative"))
+	}
+
+	return retErr
+}
+
+// FillsNodeRootFilesystem returns true if the disruption fills the root filesystem of its target nodes,
+// which is only allowed when unsafeMode.allowRootDiskFill is set
+// the rule only depends on the spec, the injector additionally checks the device of the path to fill
+func (s *DiskFillSpec) FillsNodeRootFilesystem(level chaostypes.DisruptionLevel) bool {
+	return level == chaostypes.DisruptionLevelNode && filepath.Clean(strings.TrimSpace(s.Path)) == "/"
+}
+
+// GenerateArgs generates injection or cleanup pod arguments for the given spec
+func (s *DiskFillSpec) GenerateArgs() []string {
+	args := []string{
+		"disk-fill",
+		"--path",
+		strings.TrimSpace(s.Path),
+	}
+
+	// add the target flag
+	if s.Percentage != nil {
+		args = append(args, []string{"--percentage", strconv.Itoa(*s.Percentage)}...)
+	}
+
+	if s.RemainingBytes != nil {
+		args = append(args, []string{"--remaining-bytes", strconv.Itoa(*s.RemainingBytes)}...)
+	}
+
+	return args
+}
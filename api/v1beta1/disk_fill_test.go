@@ -0,0 +1,87 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package v1beta1_test
+
+import (
+	. "github.com/DataDog/chaos-controller/api/v1beta1"
+	chaostypes "github.com/DataDog/chaos-controller/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DiskFillSpec", func() {
+	var spec DiskFillSpec
+
+	BeforeEach(func() {
+		spec = DiskFillSpec{Path: "/mnt/data"}
+	})
+
+	Describe("Validate", func() {
+		It("should succeed with a percentage", func() {
+			percentage := 90
+			spec.Percentage = &percentage
+			Expect(spec.Validate()).To(Succeed())
+		})
+
+		It("should succeed with remaining bytes", func() {
+			remainingBytes := 0
+			spec.RemainingBytes = &remainingBytes
+			Expect(spec.Validate()).To(Succeed())
+		})
+
+		It("should fail without any target", func() {
+			Expect(spec.Validate()).ToNot(Succeed())
+		})
+
+		It("should fail with both a percentage and remaining bytes", func() {
+			percentage, remainingBytes := 90, 1024
+			spec.Percentage = &percentage
+			spec.RemainingBytes = &remainingBytes
+			Expect(spec.Validate()).ToNot(Succeed())
+		})
+
+		It("should fail with a percentage greater than 100", func() {
+			percentage := 101
+			spec.Percentage = &percentage
+			Expect(spec.Validate()).ToNot(Succeed())
+		})
+
+		It("should fail with an empty path", func() {
+			percentage := 90
+			spec.Path = " "
+			spec.Percentage = &percentage
+			Expect(spec.Validate()).ToNot(Succeed())
+		})
+	})
+
+	Describe("GenerateArgs", func() {
+		It("should generate percentage args", func() {
+			percentage := 90
+			spec.Percentage = &percentage
+			Expect(spec.GenerateArgs()).To(Equal([]string{"disk-fill", "--path", "/mnt/data", "--percentage", "90"}))
+		})
+
+		It("should generate remaining bytes args", func() {
+			remainingBytes := 1024
+			spec.RemainingBytes = &remainingBytes
+			Expect(spec.GenerateArgs()).To(Equal([]string{"disk-fill", "--path", "/mnt/data", "--remaining-bytes", "1024"}))
+		})
+	})
+
+	Describe("FillsNodeRootFilesystem", func() {
+		It("should only be true for the root path of a node", func() {
+			spec.Path = "/"
+			Expect(spec.FillsNodeRootFilesystem(chaostypes.DisruptionLevelNode)).To(BeTrue())
+			Expect(spec.FillsNodeRootFilesystem(chaostypes.DisruptionLevelPod)).To(BeFalse())
+
+			spec.Path = " /./ "
+			Expect(spec.FillsNodeRootFilesystem(chaostypes.DisruptionLevelNode)).To(BeTrue())
+
+			spec.Path = "/mnt/data"
+			Expect(spec.FillsNodeRootFilesystem(chaostypes.DisruptionLevelNode)).To(BeFalse())
+		})
+	})
+})
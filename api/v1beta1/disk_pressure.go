@@ -6,7 +6,12 @@
 package v1beta1
 
 import (
+	"errors"
+	"fmt"
 	"strconv"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
 )
 
 // DiskPressureSpec represents a disk pressure disruption
@@ -20,11 +25,37 @@ type DiskPressureSpec struct {
 type DiskPressureThrottlingSpec struct {
 	ReadBytesPerSec  *int `json:"readBytesPerSec,omitempty"`
 	WriteBytesPerSec *int `json:"writeBytesPerSec,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	// +ddmark:validation:Minimum=1
+	ReadIOPS *int `json:"readIOPS,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	// +ddmark:validation:Minimum=1
+	WriteIOPS *int `json:"writeIOPS,omitempty"`
+	// LatencyTarget is the IO latency target of the targets, throttling the IOs of their sibling cgroups
+	// having a higher latency target when missed (cgroups v2 io.latency only)
+	LatencyTarget DisruptionDuration `json:"latencyTarget,omitempty"`
 }
 
 // Validate validates args for the given disruption
-func (s *DiskPressureSpec) Validate() error {
-	return nil
+func (s *DiskPressureSpec) Validate() (retErr error) {
+	if s.Throttling.ReadIOPS != nil && *s.Throttling.ReadIOPS <= 0 {
+		retErr = multierror.Append(retErr, errors.New("the read IOPS throttling must be greater than 0"))
+	}
+
+	if s.Throttling.WriteIOPS != nil && *s.Throttling.WriteIOPS <= 0 {
+		retErr = multierror.Append(retErr, errors.New("the write IOPS throttling must be greater than 0"))
+	}
+
+	if s.Throttling.LatencyTarget != "" {
+		latencyTarget, err := time.ParseDuration(string(s.Throttling.LatencyTarget))
+		if err != nil {
+			retErr = multierror.Append(retErr, fmt.Errorf("invalid latency target %s: %w", s.Throttling.LatencyTarget, err))
+		} else if latencyTarget < time.Microsecond {
+			retErr = multierror.Append(retErr, errors.New("the latency target must be at least 1us"))
+		}
+	}
+
+	return retErr
 }
 
 // GenerateArgs generates injection or cleanup pod arguments for the given spec
@@ -45,5 +76,20 @@ func (s *DiskPressureSpec) GenerateArgs() []string {
 		args = append(args, []string{"--write-bytes-per-sec", strconv.Itoa(*s.Throttling.WriteBytesPerSec)}...)
 	}
 
+	// add read iops throttling flag if specified
+	if s.Throttling.ReadIOPS != nil {
+		args = append(args, []string{"--read-iops", strconv.Itoa(*s.Throttling.ReadIOPS)}...)
+	}
+
+	// add write iops throttling flag if specified
+	if s.Throttling.WriteIOPS != nil {
+		args = append(args, []string{"--write-iops", strconv.Itoa(*s.Throttling.WriteIOPS)}...)
+	}
+
+	// add latency target flag if specified
+	if s.Throttling.LatencyTarget != "" {
+		args = append(args, []string{"--latency-target", s.Throttling.LatencyTarget.Duration().String()}...)
+	}
+
 	return args
 }
@@ -0,0 +1,47 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package v1beta1_test
+
+import (
+	. "github.com/DataDog/chaos-controller/api/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DiskPressureSpec", func() {
+	var spec DiskPressureSpec
+
+	BeforeEach(func() {
+		spec = DiskPressureSpec{Path: "/mnt/data"}
+	})
+
+	Describe("Validate", func() {
+		It("should succeed with iops throttling and a latency target", func() {
+			readIOPS, writeIOPS := 100, 200
+			spec.Throttling = DiskPressureThrottlingSpec{ReadIOPS: &readIOPS, WriteIOPS: &writeIOPS, LatencyTarget: "10ms"}
+			Expect(spec.Validate()).To(Succeed())
+		})
+
+		It("should fail with a negative iops throttling", func() {
+			writeIOPS := -1
+			spec.Throttling.WriteIOPS = &writeIOPS
+			Expect(spec.Validate()).ToNot(Succeed())
+		})
+
+		It("should fail with a latency target lower than a microsecond", func() {
+			spec.Throttling.LatencyTarget = "10ns"
+			Expect(spec.Validate()).ToNot(Succeed())
+		})
+	})
+
+	Describe("GenerateArgs", func() {
+		It("should generate iops and latency target args", func() {
+			readIOPS := 100
+			spec.Throttling = DiskPressureThrottlingSpec{ReadIOPS: &readIOPS, LatencyTarget: "10ms"}
+			Expect(spec.GenerateArgs()).To(Equal([]string{"disk-pressure", "--path", "/mnt/data", "--read-iops", "100", "--latency-target", "10ms"}))
+		})
+	})
+})
@@ -0,0 +1,178 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package v1beta1
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/robfig/cron/v3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	DisruptionCronKind = "DisruptionCron"
+
+	// MaxDisruptionCronNameLength is the maximum length of a disruption cron name, leaving room for the created disruptions suffix
+	MaxDisruptionCronNameLength = 52
+
+	// DefaultDisruptionCronHistoryLimit is the default number of finished disruptions kept by a disruption cron
+	DefaultDisruptionCronHistoryLimit = 3
+)
+
+// DisruptionCronConcurrencyPolicy describes how a new disruption is handled when the previous one is still running
+type DisruptionCronConcurrencyPolicy string
+
+const (
+	// DisruptionCronConcurrencyPolicyAllow creates the new disruption even if the previous one is still running
+	DisruptionCronConcurrencyPolicyAllow DisruptionCronConcurrencyPolicy = "Allow"
+	// DisruptionCronConcurrencyPolicyForbid skips the new disruption if the previous one is still running
+	DisruptionCronConcurrencyPolicyForbid DisruptionCronConcurrencyPolicy = "Forbid"
+	// DisruptionCronConcurrencyPolicyReplace deletes the running disruptions before creating the new one
+	DisruptionCronConcurrencyPolicyReplace DisruptionCronConcurrencyPolicy = "Replace"
+)
+
+// DisruptionCronSpec defines the desired state of DisruptionCron
+type DisruptionCronSpec struct {
+	// Schedule in the cron format (e.g. "0 */2 * * 1-5"), see https://en.wikipedia.org/wiki/Cron
+	// +kubebuilder:validation:Required
+	// +ddmark:validation:Required=true
+	Schedule string `json:"schedule"`
+	// Time zone name of the schedule (e.g. "Europe/Paris"), defaults to the controller time zone
+	TimeZone string `json:"timeZone,omitempty"`
+	// +kubebuilder:validation:Enum=Allow;Forbid;Replace
+	// +ddmark:validation:Enum=Allow;Forbid;Replace
+	ConcurrencyPolicy DisruptionCronConcurrencyPolicy `json:"concurrencyPolicy,omitempty"` // defaults to Forbid
+	// Number of finished disruptions to keep, defaults to 3
+	// +kubebuilder:validation:Minimum=0
+	// +ddmark:validation:Minimum=0
+	// +nullable
+	HistoryLimit *int `json:"historyLimit,omitempty"`
+	// Skip the scheduled disruption if any of the targets matching its selector is not ready
+	SkipIfTargetUnhealthy bool `json:"skipIfTargetUnhealthy,omitempty"`
+	// Suspend stops the creation of new disruptions, running ones are not affected
+	Suspend bool `json:"suspend,omitempty"`
+	// Spec of the disruptions created at each schedule
+	// +kubebuilder:validation:Required
+	// +ddmark:validation:Required=true
+	DisruptionTemplate DisruptionSpec `json:"disruptionTemplate"`
+}
+
+// DisruptionCronStatus defines the observed state of DisruptionCron
+type DisruptionCronStatus struct {
+	// Last time a disruption was scheduled, whether it has been created or skipped
+	// +nullable
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+	// Reason why the last scheduled disruption has been skipped if so
+	LastSkipReason string `json:"lastSkipReason,omitempty"`
+	// Running disruptions created by the disruption cron
+	// +nullable
+	Active []corev1.ObjectReference `json:"active,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// DisruptionCron is the Schema for the disruptioncrons API
+// +kubebuilder:resource:shortName=discron
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Schedule",type=string,JSONPath=`.spec.schedule`
+// +kubebuilder:printcolumn:name="Suspend",type=boolean,JSONPath=`.spec.suspend`
+// +kubebuilder:printcolumn:name="Last Schedule",type=date,JSONPath=`.status.lastScheduleTime`
+type DisruptionCron struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DisruptionCronSpec   `json:"spec,omitempty"`
+	Status DisruptionCronStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DisruptionCronList contains a list of DisruptionCron
+type DisruptionCronList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DisruptionCron `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DisruptionCron{}, &DisruptionCronList{})
+}
+
+// GetConcurrencyPolicy returns the concurrency policy, falling back to Forbid
+func (s DisruptionCronSpec) GetConcurrencyPolicy() DisruptionCronConcurrencyPolicy {
+	if s.ConcurrencyPolicy == "" {
+		return DisruptionCronConcurrencyPolicyForbid
+	}
+
+	return s.ConcurrencyPolicy
+}
+
+// GetHistoryLimit returns the number of finished disruptions to keep, falling back to the default one
+func (s DisruptionCronSpec) GetHistoryLimit() int {
+	if s.HistoryLimit == nil {
+		return DefaultDisruptionCronHistoryLimit
+	}
+
+	return *s.HistoryLimit
+}
+
+// ParseSchedule parses the cron schedule in the configured time zone
+func (s DisruptionCronSpec) ParseSchedule() (cron.Schedule, error) {
+	schedule := s.Schedule
+	if s.TimeZone != "" {
+		schedule = fmt.Sprintf("CRON_TZ=%s %s", s.TimeZone, s.Schedule)
+	}
+
+	return cron.ParseStandard(schedule)
+}
+
+// GetNextScheduleTimes returns the count next times the disruption cron fires after the given time
+func (s DisruptionCronSpec) GetNextScheduleTimes(from time.Time, count int) ([]time.Time, error) {
+	schedule, err := s.ParseSchedule()
+	if err != nil {
+		return nil, err
+	}
+
+	times := []time.Time{}
+
+	for i := 0; i < count; i++ {
+		from = schedule.Next(from)
+		if from.IsZero() {
+			break
+		}
+
+		times = append(times, from)
+	}
+
+	return times, nil
+}
+
+// Validate validates the disruption cron schedule and its disruption template
+func (s DisruptionCronSpec) Validate() (retErr error) {
+	if _, err := s.ParseSchedule(); err != nil {
+		retErr = multierror.Append(retErr, fmt.Errorf("invalid schedule %s: %w", s.Schedule, err))
+	}
+
+	switch s.GetConcurrencyPolicy() {
+	case DisruptionCronConcurrencyPolicyAllow, DisruptionCronConcurrencyPolicyForbid, DisruptionCronConcurrencyPolicyReplace:
+	default:
+		retErr = multierror.Append(retErr, fmt.Errorf("unknown concurrency policy %s, expected one of Allow, Forbid or Replace", s.ConcurrencyPolicy))
+	}
+
+	if s.HistoryLimit != nil && *s.HistoryLimit < 0 {
+		retErr = multierror.Append(retErr, errors.New("the history limit must be greater than or equal to 0"))
+	}
+
+	if err := s.DisruptionTemplate.Validate(); err != nil {
+		retErr = multierror.Append(retErr, fmt.Errorf("disruptionTemplate: %w", err))
+	}
+
+	return retErr
+}
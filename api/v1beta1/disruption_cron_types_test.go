@@ -0,0 +1,95 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package v1beta1_test
+
+import (
+	"time"
+
+	. "github.com/DataDog/chaos-controller/api/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+var _ = Describe("DisruptionCronSpec", func() {
+	var spec DisruptionCronSpec
+
+	BeforeEach(func() {
+		count := intstr.FromInt(1)
+		spec = DisruptionCronSpec{
+			Schedule: "0 10 * * 1-5",
+			DisruptionTemplate: DisruptionSpec{
+				Selector: map[string]string{"app": "demo"},
+				Count:    &count,
+				Duration: "10m",
+				Network:  &NetworkDisruptionSpec{Drop: 10},
+			},
+		}
+	})
+
+	Describe("Validate", func() {
+		It("should succeed with a valid spec", func() {
+			Expect(spec.Validate()).To(Succeed())
+		})
+
+		It("should fail with an invalid schedule", func() {
+			spec.Schedule = "every monday"
+			Expect(spec.Validate()).ShouldNot(Succeed())
+		})
+
+		It("should fail with an unknown time zone", func() {
+			spec.TimeZone = "Mars/Olympus"
+			Expect(spec.Validate()).ShouldNot(Succeed())
+		})
+
+		It("should fail with an unknown concurrency policy", func() {
+			spec.ConcurrencyPolicy = "Sometimes"
+			Expect(spec.Validate()).ShouldNot(Succeed())
+		})
+
+		It("should fail with a negative history limit", func() {
+			limit := -1
+			spec.HistoryLimit = &limit
+			Expect(spec.Validate()).ShouldNot(Succeed())
+		})
+
+		It("should fail with an invalid disruption template", func() {
+			count := intstr.FromInt(-1)
+			spec.DisruptionTemplate.Count = &count
+			Expect(spec.Validate()).ShouldNot(Succeed())
+		})
+	})
+
+	Describe("defaults", func() {
+		It("should forbid concurrent disruptions and keep 3 finished disruptions", func() {
+			Expect(spec.GetConcurrencyPolicy()).To(Equal(DisruptionCronConcurrencyPolicyForbid))
+			Expect(spec.GetHistoryLimit()).To(Equal(DefaultDisruptionCronHistoryLimit))
+		})
+	})
+
+	Describe("GetNextScheduleTimes", func() {
+		It("should return the next schedule times", func() {
+			from := time.Date(2023, time.October, 13, 12, 0, 0, 0, time.UTC) // friday
+
+			times, err := spec.GetNextScheduleTimes(from, 2)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(times).To(Equal([]time.Time{
+				time.Date(2023, time.October, 16, 10, 0, 0, 0, time.UTC),
+				time.Date(2023, time.October, 17, 10, 0, 0, 0, time.UTC),
+			}))
+		})
+
+		It("should evaluate the schedule in the given time zone", func() {
+			spec.TimeZone = "Europe/Paris"
+			from := time.Date(2023, time.October, 13, 12, 0, 0, 0, time.UTC)
+
+			times, err := spec.GetNextScheduleTimes(from, 1)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(times).To(HaveLen(1))
+			Expect(times[0].Equal(time.Date(2023, time.October, 16, 8, 0, 0, 0, time.UTC))).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,88 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package v1beta1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// SetupWebhookWithManager registers the disruption cron validating webhook
+// it must be called after the disruption one which initializes the shared webhook configuration
+func (r *DisruptionCron) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:webhookVersions={v1},path=/validate-chaos-datadoghq-com-v1beta1-disruptioncron,mutating=false,failurePolicy=fail,sideEffects=None,groups=chaos.datadoghq.com,resources=disruptioncrons,verbs=create;update,versions=v1beta1,name=vdisruptioncron.kb.io,admissionReviewVersions={v1,v1beta1}
+
+var _ webhook.Validator = &DisruptionCron{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *DisruptionCron) ValidateCreate() error {
+	logger.Debugw("validating created disruption cron", "disruptionCronName", r.Name, "disruptionCronNamespace", r.Namespace, "spec", r.Spec)
+
+	// delete-only mode, reject everything trying to be created
+	if deleteOnly {
+		return errors.New("the controller is currently in delete-only mode, you can't create new disruption crons for now")
+	}
+
+	// created disruptions are labeled with the disruption cron name
+	if _, err := labels.Parse(fmt.Sprintf("name=%s", r.Name)); err != nil {
+		return fmt.Errorf("invalid disruption cron name: %w", err)
+	}
+
+	// created disruptions are named after the disruption cron with a schedule time suffix, and must be valid label values too
+	if len(r.Name) > MaxDisruptionCronNameLength {
+		return fmt.Errorf("invalid disruption cron name: the name must be no more than %d characters", MaxDisruptionCronNameLength)
+	}
+
+	return r.validateSpec()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *DisruptionCron) ValidateUpdate(old runtime.Object) error {
+	logger.Debugw("validating updated disruption cron", "disruptionCronName", r.Name, "disruptionCronNamespace", r.Namespace, "spec", r.Spec)
+
+	return r.validateSpec()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *DisruptionCron) ValidateDelete() error {
+	return nil
+}
+
+// validateSpec validates the disruption cron spec, including the disruption template markers,
+// the disruption template being instantiated from the template it references if any like the created disruptions are
+func (r *DisruptionCron) validateSpec() error {
+	spec := r.Spec.DeepCopy()
+
+	disruptionTemplate, err := InstantiateDisruptionSpec(context.Background(), k8sClient, r.Namespace, spec.DisruptionTemplate)
+	if err != nil {
+		return fmt.Errorf("disruptionTemplate: %w", err)
+	}
+
+	spec.DisruptionTemplate = disruptionTemplate
+
+	if err := spec.Validate(); err != nil {
+		return err
+	}
+
+	multiErr := ddmarkClient.ValidateStructMultierror(spec.DisruptionTemplate, "validation_webhook")
+	if multiErr.ErrorOrNil() != nil {
+		return multierror.Prefix(multiErr, "ddmark: ")
+	}
+
+	return nil
+}
@@ -0,0 +1,454 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	DisruptionTemplateKind        = "DisruptionTemplate"
+	ClusterDisruptionTemplateKind = "ClusterDisruptionTemplate"
+
+	// DisruptionTemplateRefKey is the disruption spec key referencing the template it has been instantiated from
+	DisruptionTemplateRefKey = "templateRef"
+)
+
+// DisruptionTemplateParameterType is the type of the value of a disruption template parameter
+type DisruptionTemplateParameterType string
+
+const (
+	// DisruptionTemplateParameterTypeString is a raw string value
+	DisruptionTemplateParameterTypeString DisruptionTemplateParameterType = "string"
+	// DisruptionTemplateParameterTypeInteger is an integer value, substituted as a number
+	DisruptionTemplateParameterTypeInteger DisruptionTemplateParameterType = "integer"
+	// DisruptionTemplateParameterTypeBoolean is a boolean value, substituted as a boolean
+	DisruptionTemplateParameterTypeBoolean DisruptionTemplateParameterType = "boolean"
+	// DisruptionTemplateParameterTypeDuration is a Go duration value (e.g. 30s, 5m), substituted as a string
+	DisruptionTemplateParameterTypeDuration DisruptionTemplateParameterType = "duration"
+)
+
+// disruptionTemplatePlaceholder matches the ${name} placeholders of a disruption template
+var disruptionTemplatePlaceholder = regexp.MustCompile(`\$\{([a-zA-Z_][a-zA-Z0-9_]*)\}`)
+
+// disruptionTemplateParameterName matches the valid names of a disruption template parameter
+var disruptionTemplateParameterName = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// DisruptionTemplateParameter describes a value given by the disruptions instantiating a template
+type DisruptionTemplateParameter struct {
+	// Name of the parameter, referenced as ${name} in the template disruption spec
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z_][a-zA-Z0-9_]*$`
+	// +ddmark:validation:Required=true
+	Name string `json:"name"`
+	// Type of the parameter value, defaults to string
+	// +kubebuilder:validation:Enum=string;integer;boolean;duration
+	// +ddmark:validation:Enum=string;integer;boolean;duration
+	Type DisruptionTemplateParameterType `json:"type,omitempty"`
+	// Description of the parameter
+	Description string `json:"description,omitempty"`
+	// Default value of the parameter, the disruptions instantiating the template must give a value otherwise
+	// +nullable
+	Default *string `json:"default,omitempty"`
+}
+
+// DisruptionTemplateSpec defines a parameterised disruption spec
+type DisruptionTemplateSpec struct {
+	// Description of the scenario of the template
+	Description string `json:"description,omitempty"`
+	// Parameters which can be referenced as ${name} in the disruption spec
+	// +nullable
+	Parameters []DisruptionTemplateParameter `json:"parameters,omitempty"`
+	// Disruption spec, a string equal to a single ${name} placeholder is replaced by the typed parameter value,
+	// other placeholders are replaced by the parameter value within the string
+	// +kubebuilder:validation:Required
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +ddmark:validation:Required=true
+	Disruption runtime.RawExtension `json:"disruption"`
+}
+
+// DisruptionTemplateRef references the template a disruption is instantiated from
+type DisruptionTemplateRef struct {
+	// Kind of the template, either a DisruptionTemplate of the disruption namespace or a ClusterDisruptionTemplate, defaults to DisruptionTemplate
+	// +kubebuilder:validation:Enum=DisruptionTemplate;ClusterDisruptionTemplate
+	// +ddmark:validation:Enum=DisruptionTemplate;ClusterDisruptionTemplate
+	Kind string `json:"kind,omitempty"`
+	// Name of the template
+	// +kubebuilder:validation:Required
+	// +ddmark:validation:Required=true
+	Name string `json:"name"`
+	// Values of the template parameters, falling back to their default values
+	// +nullable
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// DisruptionTemplate is the Schema for the disruptiontemplates API
+// +kubebuilder:resource:shortName=distemplate
+// +kubebuilder:printcolumn:name="Description",type=string,JSONPath=`.spec.description`
+type DisruptionTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec DisruptionTemplateSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DisruptionTemplateList contains a list of DisruptionTemplate
+type DisruptionTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DisruptionTemplate `json:"items"`
+}
+
+//+kubebuilder:object:root=true
+
+// ClusterDisruptionTemplate is the Schema for the clusterdisruptiontemplates API, available to the disruptions of all namespaces
+// +kubebuilder:resource:scope=Cluster,shortName=cdistemplate
+// +kubebuilder:printcolumn:name="Description",type=string,JSONPath=`.spec.description`
+type ClusterDisruptionTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec DisruptionTemplateSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterDisruptionTemplateList contains a list of ClusterDisruptionTemplate
+type ClusterDisruptionTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterDisruptionTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DisruptionTemplate{}, &DisruptionTemplateList{}, &ClusterDisruptionTemplate{}, &ClusterDisruptionTemplateList{})
+}
+
+// GetKind returns the kind of the referenced template, falling back to DisruptionTemplate
+func (r DisruptionTemplateRef) GetKind() string {
+	if r.Kind == "" {
+		return DisruptionTemplateKind
+	}
+
+	return r.Kind
+}
+
+// GetType returns the type of the parameter, falling back to string
+func (p DisruptionTemplateParameter) GetType() DisruptionTemplateParameterType {
+	if p.Type == "" {
+		return DisruptionTemplateParameterTypeString
+	}
+
+	return p.Type
+}
+
+// Parse returns the typed value of the given parameter value
+func (p DisruptionTemplateParameter) Parse(value string) (interface{}, error) {
+	switch p.GetType() {
+	case DisruptionTemplateParameterTypeString:
+		return value, nil
+	case DisruptionTemplateParameterTypeInteger:
+		return strconv.ParseInt(value, 10, 64)
+	case DisruptionTemplateParameterTypeBoolean:
+		return strconv.ParseBool(value)
+	case DisruptionTemplateParameterTypeDuration:
+		if _, err := time.ParseDuration(value); err != nil {
+			return nil, err
+		}
+
+		return value, nil
+	default:
+		return nil, fmt.Errorf("unknown parameter type %s, expected one of string, integer, boolean or duration", p.Type)
+	}
+}
+
+// sample returns a value of the parameter type used to check the template when no default value is given
+func (p DisruptionTemplateParameter) sample() string {
+	switch p.GetType() {
+	case DisruptionTemplateParameterTypeInteger:
+		return "1"
+	case DisruptionTemplateParameterTypeBoolean:
+		return "true"
+	case DisruptionTemplateParameterTypeDuration:
+		return "1m"
+	default:
+		return p.Name
+	}
+}
+
+// Validate validates the template parameters and checks the template expands to a disruption spec
+func (s DisruptionTemplateSpec) Validate() (retErr error) {
+	names := map[string]struct{}{}
+
+	for _, parameter := range s.Parameters {
+		if !disruptionTemplateParameterName.MatchString(parameter.Name) {
+			retErr = multierror.Append(retErr, fmt.Errorf("invalid parameter name %q, it must only contain letters, digits and underscores and must not start with a digit", parameter.Name))
+		}
+
+		if _, ok := names[parameter.Name]; ok {
+			retErr = multierror.Append(retErr, fmt.Errorf("parameter %s is declared more than once", parameter.Name))
+		}
+
+		names[parameter.Name] = struct{}{}
+
+		if parameter.Default != nil {
+			if _, err := parameter.Parse(*parameter.Default); err != nil {
+				retErr = multierror.Append(retErr, fmt.Errorf("invalid default value of parameter %s: %w", parameter.Name, err))
+			}
+		}
+	}
+
+	if retErr != nil {
+		return retErr
+	}
+
+	// the full disruption validation depends on the parameter values and happens on instantiation,
+	// but the template must at least expand to a disruption spec whatever the values are
+	if _, err := s.Instantiate(s.sampleValues()); err != nil {
+		retErr = multierror.Append(retErr, err)
+	}
+
+	return retErr
+}
+
+// sampleValues returns a value of their type for the parameters without default value
+func (s DisruptionTemplateSpec) sampleValues() map[string]string {
+	samples := map[string]string{}
+
+	for _, parameter := range s.Parameters {
+		if parameter.Default == nil {
+			samples[parameter.Name] = parameter.sample()
+		}
+	}
+
+	return samples
+}
+
+// InstantiateSample returns the disruption spec of the template instantiated with the parameters default values,
+// or a value of their type when they have none, on a disruption only defining a selector and a count
+// as the disruptions instantiating a template usually do
+func (s DisruptionTemplateSpec) InstantiateSample() (DisruptionSpec, error) {
+	spec := DisruptionSpec{}
+	sample := map[string]interface{}{
+		"selector": map[string]interface{}{"app": "disruption-template-sample"},
+		"count":    1,
+	}
+
+	if err := s.Apply(sample, s.sampleValues()); err != nil {
+		return spec, err
+	}
+
+	raw, err := json.Marshal(sample)
+	if err != nil {
+		return spec, fmt.Errorf("error encoding the instantiated disruption spec: %w", err)
+	}
+
+	if err := json.Unmarshal(raw, &spec); err != nil {
+		return spec, fmt.Errorf("the instantiated template is not a valid disruption spec: %w", err)
+	}
+
+	return spec, nil
+}
+
+// Expand returns the template disruption spec with its placeholders replaced by the given parameter values,
+// falling back to the parameters default values
+func (s DisruptionTemplateSpec) Expand(values map[string]string) (map[string]interface{}, error) {
+	parameters, err := s.resolveParameters(values)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(s.Disruption.Raw) == 0 {
+		return nil, errors.New("the template disruption spec is empty")
+	}
+
+	spec := map[string]interface{}{}
+	if err := json.Unmarshal(s.Disruption.Raw, &spec); err != nil {
+		return nil, fmt.Errorf("error decoding the template disruption spec: %w", err)
+	}
+
+	expanded, err := substituteDisruptionTemplateParameters(spec, parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	expandedSpec, ok := expanded.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("the template disruption spec must be an object")
+	}
+
+	if _, ok := expandedSpec[DisruptionTemplateRefKey]; ok {
+		return nil, errors.New("the template disruption spec can't reference another template")
+	}
+
+	return expandedSpec, nil
+}
+
+// Apply expands the template with the given parameter values into the given raw disruption spec,
+// the fields defined by the template replacing the ones of the disruption
+func (s DisruptionTemplateSpec) Apply(spec map[string]interface{}, values map[string]string) error {
+	expanded, err := s.Expand(values)
+	if err != nil {
+		return err
+	}
+
+	for field, value := range expanded {
+		spec[field] = value
+	}
+
+	return nil
+}
+
+// Instantiate returns the disruption spec of the template expanded with the given parameter values
+func (s DisruptionTemplateSpec) Instantiate(values map[string]string) (DisruptionSpec, error) {
+	spec := DisruptionSpec{}
+
+	expanded, err := s.Expand(values)
+	if err != nil {
+		return spec, err
+	}
+
+	raw, err := json.Marshal(expanded)
+	if err != nil {
+		return spec, fmt.Errorf("error encoding the expanded disruption spec: %w", err)
+	}
+
+	if err := json.Unmarshal(raw, &spec); err != nil {
+		return spec, fmt.Errorf("the expanded template is not a valid disruption spec: %w", err)
+	}
+
+	return spec, nil
+}
+
+// resolveParameters returns the typed value of each parameter from the given values or its default value
+func (s DisruptionTemplateSpec) resolveParameters(values map[string]string) (map[string]interface{}, error) {
+	var retErr error
+
+	parameters := map[string]interface{}{}
+	declared := map[string]struct{}{}
+
+	for _, parameter := range s.Parameters {
+		declared[parameter.Name] = struct{}{}
+
+		value, ok := values[parameter.Name]
+		if !ok {
+			if parameter.Default == nil {
+				retErr = multierror.Append(retErr, fmt.Errorf("parameter %s is required as it has no default value", parameter.Name))
+
+				continue
+			}
+
+			value = *parameter.Default
+		}
+
+		typed, err := parameter.Parse(value)
+		if err != nil {
+			retErr = multierror.Append(retErr, fmt.Errorf("invalid %s value %q of parameter %s: %w", parameter.GetType(), value, parameter.Name, err))
+
+			continue
+		}
+
+		parameters[parameter.Name] = typed
+	}
+
+	unknown := []string{}
+
+	for name := range values {
+		if _, ok := declared[name]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+
+	// sort the unknown parameters to get a stable error message
+	sort.Strings(unknown)
+
+	for _, name := range unknown {
+		retErr = multierror.Append(retErr, fmt.Errorf("unknown parameter %s", name))
+	}
+
+	return parameters, retErr
+}
+
+// substituteDisruptionTemplateParameters replaces the placeholders of the given decoded JSON value by the given parameter values
+func substituteDisruptionTemplateParameters(value interface{}, parameters map[string]interface{}) (interface{}, error) {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		substituted := make(map[string]interface{}, len(typed))
+
+		for key, item := range typed {
+			substitutedKey, err := substituteDisruptionTemplateString(key, parameters)
+			if err != nil {
+				return nil, err
+			}
+
+			if substituted[substitutedKey], err = substituteDisruptionTemplateParameters(item, parameters); err != nil {
+				return nil, err
+			}
+		}
+
+		return substituted, nil
+	case []interface{}:
+		substituted := make([]interface{}, len(typed))
+
+		for i, item := range typed {
+			var err error
+
+			if substituted[i], err = substituteDisruptionTemplateParameters(item, parameters); err != nil {
+				return nil, err
+			}
+		}
+
+		return substituted, nil
+	case string:
+		// a string made of a single placeholder takes the typed parameter value
+		if match := disruptionTemplatePlaceholder.FindStringSubmatch(typed); match != nil && match[0] == typed {
+			parameter, ok := parameters[match[1]]
+			if !ok {
+				return nil, fmt.Errorf("unknown parameter %s referenced in the template", match[1])
+			}
+
+			return parameter, nil
+		}
+
+		return substituteDisruptionTemplateString(typed, parameters)
+	default:
+		return value, nil
+	}
+}
+
+// substituteDisruptionTemplateString replaces the placeholders within the given string by the given parameter values
+func substituteDisruptionTemplateString(value string, parameters map[string]interface{}) (string, error) {
+	var retErr error
+
+	substituted := disruptionTemplatePlaceholder.ReplaceAllStringFunc(value, func(placeholder string) string {
+		name := disruptionTemplatePlaceholder.FindStringSubmatch(placeholder)[1]
+
+		parameter, ok := parameters[name]
+		if !ok {
+			retErr = fmt.Errorf("unknown parameter %s referenced in the template", name)
+
+			return placeholder
+		}
+
+		return fmt.Sprint(parameter)
+	})
+
+	return substituted, retErr
+}
@@ -0,0 +1,127 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package v1beta1_test
+
+import (
+	. "github.com/DataDog/chaos-controller/api/v1beta1"
+	chaostypes "github.com/DataDog/chaos-controller/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+var _ = Describe("DisruptionTemplateSpec", func() {
+	var spec DisruptionTemplateSpec
+
+	BeforeEach(func() {
+		drop, duration := "10", "5m"
+
+		spec = DisruptionTemplateSpec{
+			Parameters: []DisruptionTemplateParameter{
+				{Name: "app"},
+				{Name: "drop", Type: DisruptionTemplateParameterTypeInteger, Default: &drop},
+				{Name: "duration", Type: DisruptionTemplateParameterTypeDuration, Default: &duration},
+			},
+			Disruption: runtime.RawExtension{Raw: []byte(`{
+				"selector": {"app": "${app}", "team": "${app}-team"},
+				"count": 1,
+				"duration": "${duration}",
+				"network": {"drop": "${drop}"}
+			}`)},
+		}
+	})
+
+	Describe("Validate", func() {
+		It("should succeed with a valid spec", func() {
+			Expect(spec.Validate()).To(Succeed())
+		})
+
+		It("should fail with an invalid parameter name", func() {
+			spec.Parameters[0].Name = "1app"
+			Expect(spec.Validate()).ShouldNot(Succeed())
+		})
+
+		It("should fail with a parameter declared twice", func() {
+			spec.Parameters = append(spec.Parameters, DisruptionTemplateParameter{Name: "app"})
+			Expect(spec.Validate()).ShouldNot(Succeed())
+		})
+
+		It("should fail with an invalid default value", func() {
+			drop := "ten"
+			spec.Parameters[1].Default = &drop
+			Expect(spec.Validate()).ShouldNot(Succeed())
+		})
+
+		It("should fail with an undeclared parameter", func() {
+			spec.Disruption.Raw = []byte(`{"selector": {"app": "${unknown}"}}`)
+			Expect(spec.Validate()).ShouldNot(Succeed())
+		})
+
+		It("should fail with a parameter whose type does not match the disruption field", func() {
+			spec.Parameters[1].Type = DisruptionTemplateParameterTypeString
+			Expect(spec.Validate()).ShouldNot(Succeed())
+		})
+
+		It("should fail with a template referencing another template", func() {
+			spec.Disruption.Raw = []byte(`{"templateRef": {"name": "other"}}`)
+			Expect(spec.Validate()).ShouldNot(Succeed())
+		})
+	})
+
+	Describe("Instantiate", func() {
+		It("should replace the placeholders by the typed parameter values", func() {
+			disruption, err := spec.Instantiate(map[string]string{"app": "demo", "drop": "50"})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			count := intstr.FromInt(1)
+			Expect(disruption).To(Equal(DisruptionSpec{
+				Selector: map[string]string{"app": "demo", "team": "demo-team"},
+				Count:    &count,
+				Duration: "5m0s",
+				Level:    chaostypes.DisruptionLevelPod,
+				Network:  &NetworkDisruptionSpec{Drop: 50},
+			}))
+		})
+
+		It("should fail when a parameter without default value is missing", func() {
+			_, err := spec.Instantiate(map[string]string{"drop": "50"})
+			Expect(err).To(MatchError(ContainSubstring("parameter app is required")))
+		})
+
+		It("should fail with a value which does not match the parameter type", func() {
+			_, err := spec.Instantiate(map[string]string{"app": "demo", "drop": "a lot"})
+			Expect(err).To(MatchError(ContainSubstring("invalid integer value")))
+		})
+
+		It("should fail with an unknown parameter", func() {
+			_, err := spec.Instantiate(map[string]string{"app": "demo", "delay": "50"})
+			Expect(err).To(MatchError(ContainSubstring("unknown parameter delay")))
+		})
+	})
+
+	Describe("Apply", func() {
+		It("should replace the disruption fields defined by the template only", func() {
+			disruption := map[string]interface{}{
+				"count":       "50%",
+				"annotations": map[string]interface{}{"team": "chaos"},
+				"templateRef": map[string]interface{}{"name": "template"},
+			}
+
+			Expect(spec.Apply(disruption, map[string]string{"app": "demo"})).To(Succeed())
+			Expect(disruption).To(HaveKeyWithValue("count", float64(1)))
+			Expect(disruption).To(HaveKeyWithValue("network", map[string]interface{}{"drop": int64(10)}))
+			Expect(disruption).To(HaveKeyWithValue("annotations", map[string]interface{}{"team": "chaos"}))
+			Expect(disruption).To(HaveKey("templateRef"))
+		})
+	})
+})
+
+var _ = Describe("DisruptionTemplateRef", func() {
+	It("should default to a namespaced disruption template", func() {
+		Expect(DisruptionTemplateRef{Name: "template"}.GetKind()).To(Equal(DisruptionTemplateKind))
+	})
+})
@@ -0,0 +1,178 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package v1beta1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// SetupWebhookWithManager registers the disruption template validating webhook
+// it must be called after the disruption one which initializes the shared webhook configuration
+func (r *DisruptionTemplate) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+// SetupWebhookWithManager registers the cluster disruption template validating webhook
+// it must be called after the disruption one which initializes the shared webhook configuration
+func (r *ClusterDisruptionTemplate) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:webhookVersions={v1},path=/validate-chaos-datadoghq-com-v1beta1-disruptiontemplate,mutating=false,failurePolicy=fail,sideEffects=None,groups=chaos.datadoghq.com,resources=disruptiontemplates,verbs=create;update,versions=v1beta1,name=vdisruptiontemplate.kb.io,admissionReviewVersions={v1,v1beta1}
+
+var _ webhook.Validator = &DisruptionTemplate{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *DisruptionTemplate) ValidateCreate() error {
+	logger.Debugw("validating created disruption template", "disruptionTemplateName", r.Name, "disruptionTemplateNamespace", r.Namespace, "spec", r.Spec)
+
+	return validateDisruptionTemplateSpec(r.Spec)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *DisruptionTemplate) ValidateUpdate(old runtime.Object) error {
+	logger.Debugw("validating updated disruption template", "disruptionTemplateName", r.Name, "disruptionTemplateNamespace", r.Namespace, "spec", r.Spec)
+
+	return validateDisruptionTemplateSpec(r.Spec)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *DisruptionTemplate) ValidateDelete() error {
+	return nil
+}
+
+//+kubebuilder:webhook:webhookVersions={v1},path=/validate-chaos-datadoghq-com-v1beta1-clusterdisruptiontemplate,mutating=false,failurePolicy=fail,sideEffects=None,groups=chaos.datadoghq.com,resources=clusterdisruptiontemplates,verbs=create;update,versions=v1beta1,name=vclusterdisruptiontemplate.kb.io,admissionReviewVersions={v1,v1beta1}
+
+var _ webhook.Validator = &ClusterDisruptionTemplate{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *ClusterDisruptionTemplate) ValidateCreate() error {
+	logger.Debugw("validating created cluster disruption template", "clusterDisruptionTemplateName", r.Name, "spec", r.Spec)
+
+	return validateDisruptionTemplateSpec(r.Spec)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *ClusterDisruptionTemplate) ValidateUpdate(old runtime.Object) error {
+	logger.Debugw("validating updated cluster disruption template", "clusterDisruptionTemplateName", r.Name, "spec", r.Spec)
+
+	return validateDisruptionTemplateSpec(r.Spec)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *ClusterDisruptionTemplate) ValidateDelete() error {
+	return nil
+}
+
+// validateDisruptionTemplateSpec validates the disruption template spec, including the parameters markers,
+// and the disruption it instantiates with the parameters default or sample values
+func validateDisruptionTemplateSpec(spec DisruptionTemplateSpec) error {
+	if err := spec.Validate(); err != nil {
+		return err
+	}
+
+	multiErr := ddmarkClient.ValidateStructMultierror(spec, "validation_webhook")
+	if multiErr.ErrorOrNil() != nil {
+		return multierror.Prefix(multiErr, "ddmark: ")
+	}
+
+	sample, err := spec.InstantiateSample()
+	if err != nil {
+		return err
+	}
+
+	// the disruptions instantiating the template get the default duration if it sets none
+	if sample.Duration.Duration() == 0 {
+		sample.Duration = DisruptionDuration(defaultDuration.String())
+	}
+
+	multiErr = ddmarkClient.ValidateStructMultierror(sample, "validation_webhook")
+	if multiErr.ErrorOrNil() != nil {
+		return multierror.Prefix(multiErr, "ddmark: instantiated disruption: ")
+	}
+
+	if err := sample.Validate(); err != nil {
+		return fmt.Errorf("the disruption instantiated with the parameters default or sample values is invalid: %w", err)
+	}
+
+	return nil
+}
+
+// InstantiateDisruptionSpec returns the given disruption spec of the given namespace with the template it references applied
+// as it is on the creation of the disruption, the spec itself if it does not reference any template
+func InstantiateDisruptionSpec(ctx context.Context, c client.Client, namespace string, spec DisruptionSpec) (DisruptionSpec, error) {
+	if spec.TemplateRef == nil {
+		return spec, nil
+	}
+
+	ref := *spec.TemplateRef
+
+	template, err := GetDisruptionTemplateSpec(ctx, c, namespace, ref)
+	if err != nil {
+		return spec, err
+	}
+
+	rawSpec, err := json.Marshal(spec)
+	if err != nil {
+		return spec, fmt.Errorf("error encoding the disruption spec: %w", err)
+	}
+
+	// work on the raw spec so only the fields set by the template replace the ones of the disruption
+	raw := map[string]interface{}{}
+	if err := json.Unmarshal(rawSpec, &raw); err != nil {
+		return spec, fmt.Errorf("error decoding the disruption spec: %w", err)
+	}
+
+	if err := template.Apply(raw, ref.Parameters); err != nil {
+		return spec, fmt.Errorf("error instantiating %s %s: %w", ref.GetKind(), ref.Name, err)
+	}
+
+	rawSpec, err = json.Marshal(raw)
+	if err != nil {
+		return spec, fmt.Errorf("error encoding the instantiated disruption spec: %w", err)
+	}
+
+	instantiated := DisruptionSpec{}
+	if err := json.Unmarshal(rawSpec, &instantiated); err != nil {
+		return spec, fmt.Errorf("the instantiated template is not a valid disruption spec: %w", err)
+	}
+
+	return instantiated, nil
+}
+
+// GetDisruptionTemplateSpec returns the spec of the template referenced by a disruption of the given namespace
+func GetDisruptionTemplateSpec(ctx context.Context, c client.Client, namespace string, ref DisruptionTemplateRef) (DisruptionTemplateSpec, error) {
+	switch ref.GetKind() {
+	case DisruptionTemplateKind:
+		template := DisruptionTemplate{}
+		if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ref.Name}, &template); err != nil {
+			return DisruptionTemplateSpec{}, fmt.Errorf("error getting disruption template %s/%s: %w", namespace, ref.Name, err)
+		}
+
+		return template.Spec, nil
+	case ClusterDisruptionTemplateKind:
+		template := ClusterDisruptionTemplate{}
+		if err := c.Get(ctx, client.ObjectKey{Name: ref.Name}, &template); err != nil {
+			return DisruptionTemplateSpec{}, fmt.Errorf("error getting cluster disruption template %s: %w", ref.Name, err)
+		}
+
+		return template.Spec, nil
+	default:
+		return DisruptionTemplateSpec{}, fmt.Errorf("unknown template kind %s, expected one of %s or %s", ref.Kind, DisruptionTemplateKind, ClusterDisruptionTemplateKind)
+	}
+}
@@ -0,0 +1,146 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package v1beta1
+
+import (
+	"context"
+
+	"github.com/DataDog/chaos-controller/ddmark"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("DisruptionTemplate webhook", func() {
+	var template *DisruptionTemplate
+
+	BeforeEach(func() {
+		ddmarkMock := ddmark.NewClientMock(GinkgoT())
+		ddmarkMock.EXPECT().ValidateStructMultierror(mock.Anything, mock.Anything).Return(nil).Maybe()
+		ddmarkClient = ddmarkMock
+
+		template = &DisruptionTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "network-drop", Namespace: "demo"},
+			Spec: DisruptionTemplateSpec{
+				Parameters: []DisruptionTemplateParameter{{Name: "drop", Type: DisruptionTemplateParameterTypeInteger}},
+				Disruption: runtime.RawExtension{Raw: []byte(`{"network": {"drop": "${drop}"}}`)},
+			},
+		}
+
+		scheme := runtime.NewScheme()
+		Expect(AddToScheme(scheme)).To(Succeed())
+		k8sClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(template).Build()
+	})
+
+	AfterEach(func() {
+		k8sClient = nil
+	})
+
+	Describe("ValidateCreate", func() {
+		It("should accept a template leaving the selector and the count to the disruptions", func() {
+			Expect(template.ValidateCreate()).To(Succeed())
+		})
+
+		It("should reject a template instantiating an invalid disruption", func() {
+			template.Spec.Disruption.Raw = []byte(`{"level": "node", "containerFailure": {}}`)
+
+			err := template.ValidateCreate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("cannot execute a container failure because the level configuration is set to node"))
+		})
+
+		It("should run the ddmark markers on the instantiated disruption", func() {
+			ddmarkMock := ddmark.NewClientMock(GinkgoT())
+			ddmarkMock.EXPECT().ValidateStructMultierror(mock.AnythingOfType("v1beta1.DisruptionTemplateSpec"), mock.Anything).Return(nil).Once()
+			ddmarkMock.EXPECT().ValidateStructMultierror(mock.MatchedBy(func(spec DisruptionSpec) bool {
+				return spec.Network != nil && spec.Network.Drop == 1 && spec.Duration != ""
+			}), mock.Anything).Return(nil).Once()
+			ddmarkClient = ddmarkMock
+
+			Expect(template.ValidateCreate()).To(Succeed())
+		})
+	})
+
+	Describe("InstantiateDisruptionSpec", func() {
+		var spec DisruptionSpec
+
+		BeforeEach(func() {
+			count := intstr.FromInt(1)
+			spec = DisruptionSpec{
+				Selector:    map[string]string{"app": "demo"},
+				Count:       &count,
+				TemplateRef: &DisruptionTemplateRef{Name: "network-drop", Parameters: map[string]string{"drop": "30"}},
+			}
+		})
+
+		It("should apply the referenced template", func() {
+			instantiated, err := InstantiateDisruptionSpec(context.Background(), k8sClient, "demo", spec)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(instantiated.Network).ToNot(BeNil())
+			Expect(instantiated.Network.Drop).To(Equal(30))
+			Expect(instantiated.Selector).To(Equal(spec.Selector))
+			Expect(instantiated.TemplateRef).To(Equal(spec.TemplateRef))
+		})
+
+		It("should return the spec as is without template", func() {
+			spec.TemplateRef = nil
+
+			Expect(InstantiateDisruptionSpec(context.Background(), nil, "demo", spec)).To(Equal(spec))
+		})
+
+		It("should fail with a missing template", func() {
+			_, err := InstantiateDisruptionSpec(context.Background(), k8sClient, "other", spec)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("DisruptionCron and ChaosWorkflow validation", func() {
+		var disruption DisruptionSpec
+
+		BeforeEach(func() {
+			count := intstr.FromInt(1)
+			disruption = DisruptionSpec{
+				Selector:    map[string]string{"app": "demo"},
+				Count:       &count,
+				TemplateRef: &DisruptionTemplateRef{Name: "network-drop", Parameters: map[string]string{"drop": "30"}},
+			}
+		})
+
+		It("should validate the disruption cron template instantiated from its template", func() {
+			cron := &DisruptionCron{
+				ObjectMeta: metav1.ObjectMeta{Name: "cron", Namespace: "demo"},
+				Spec:       DisruptionCronSpec{Schedule: "0 10 * * 1-5", DisruptionTemplate: disruption},
+			}
+
+			Expect(cron.ValidateCreate()).To(Succeed())
+
+			By("rejecting invalid parameter values")
+			cron.Spec.DisruptionTemplate.TemplateRef.Parameters["drop"] = "a lot"
+			Expect(cron.ValidateCreate()).ToNot(Succeed())
+		})
+
+		It("should validate the chaos workflow disruptions instantiated from their template", func() {
+			workflow := &ChaosWorkflow{
+				ObjectMeta: metav1.ObjectMeta{Name: "workflow", Namespace: "demo"},
+				Spec: ChaosWorkflowSpec{
+					Steps: []ChaosWorkflowStep{
+						{Name: "drop", Disruptions: []ChaosWorkflowDisruption{{Name: "curl", Spec: disruption}}},
+					},
+				},
+			}
+
+			Expect(workflow.ValidateCreate()).To(Succeed())
+
+			By("rejecting a missing template")
+			workflow.Spec.Steps[0].Disruptions[0].Spec.TemplateRef.Name = "unknown"
+			Expect(workflow.ValidateCreate()).ToNot(Succeed())
+		})
+	})
+})
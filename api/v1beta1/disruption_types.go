@@ -31,10 +31,10 @@ import (
 )
 
 // DisruptionSpec defines the desired state of Disruption
-// +ddmark:validation:ExclusiveFields={ContainerFailure,CPUPressure,DiskPressure,NodeFailure,Network,DNS,DiskFailure}
-// +ddmark:validation:ExclusiveFields={NodeFailure,CPUPressure,DiskPressure,ContainerFailure,Network,DNS,DiskFailure}
+// +ddmark:validation:ExclusiveFields={ContainerFailure,CPUPressure,DiskPressure,NodeFailure,Network,DNS,DiskFailure,DiskFill,ProcessFailure,ContainerFreeze}
+// +ddmark:validation:ExclusiveFields={NodeFailure,CPUPressure,DiskPressure,ContainerFailure,Network,DNS,DiskFailure,DiskFill,ProcessFailure,ContainerFreeze}
 // +ddmark:validation:LinkedFieldsValueWithTrigger={NodeFailure,Level}
-// +ddmark:validation:AtLeastOneOf={DNS,CPUPressure,Network,NodeFailure,ContainerFailure,DiskPressure,GRPC,DiskFailure}
+// +ddmark:validation:AtLeastOneOf={DNS,CPUPressure,Network,NodeFailure,ContainerFailure,DiskPressure,GRPC,DiskFailure,DiskFill,ProcessFailure,ContainerFreeze}
 // +ddmark:validation:AtLeastOneOf={Selector,AdvancedSelector}
 type DisruptionSpec struct {
 	// +kubebuilder:validation:Required
@@ -56,10 +56,21 @@ type DisruptionSpec struct {
 	Unsafemode      *UnsafemodeSpec   `json:"unsafeMode,omitempty"`      // unsafemode spec used to turn off safemode safety nets
 	StaticTargeting bool              `json:"staticTargeting,omitempty"` // enable dynamic targeting and cluster observation
 	// +nullable
+	Targeting *TargetingSpec `json:"targeting,omitempty"` // strategy used to pick targets among the eligible ones, targets are randomly picked if not set
+	// +nullable
 	Triggers DisruptionTriggers `json:"triggers,omitempty"` // alter the pre-injection lifecycle
 	// +nullable
 	Pulse    *DisruptionPulse   `json:"pulse,omitempty"`    // enable pulsing diruptions and specify the duration of the active state and the dormant state of the pulsing duration
 	Duration DisruptionDuration `json:"duration,omitempty"` // time from disruption creation until chaos pods are deleted and no more are created
+	// +nullable
+	AbortConditions *DisruptionAbortConditions `json:"abortConditions,omitempty"` // conditions terminating the disruption before its duration is over
+	// +nullable
+	Rollout *DisruptionRollout `json:"rollout,omitempty"` // widen the targets progressively through stages before reaching the disruption count
+	// Paused cleans the disruption chaos pods until set back to false, the disruption being re-injected on resume
+	// it is the only field which can be updated on an existing disruption
+	Paused bool `json:"paused,omitempty"`
+	// ExcludePausedTime extends the disruption duration by the time spent paused
+	ExcludePausedTime bool `json:"excludePausedTime,omitempty"`
 	// Level defines what the disruption will target, either a pod or a node
 	// +kubebuilder:default=pod
 	// +kubebuilder:validation:Enum=pod;node
@@ -73,17 +84,27 @@ type DisruptionSpec struct {
 	// +nullable
 	ContainerFailure *ContainerFailureSpec `json:"containerFailure,omitempty"`
 	// +nullable
+	ProcessFailure *ProcessFailureSpec `json:"processFailure,omitempty"`
+	// +nullable
+	ContainerFreeze *ContainerFreezeSpec `json:"containerFreeze,omitempty"`
+	// +nullable
 	CPUPressure *CPUPressureSpec `json:"cpuPressure,omitempty"`
 	// +nullable
 	DiskPressure *DiskPressureSpec `json:"diskPressure,omitempty"`
 	// +nullable
 	DiskFailure *DiskFailureSpec `json:"diskFailure,omitempty"`
 	// +nullable
+	DiskFill *DiskFillSpec `json:"diskFill,omitempty"`
+	// +nullable
 	DNS DNSDisruptionSpec `json:"dns,omitempty"`
 	// +nullable
 	GRPC *GRPCDisruptionSpec `json:"grpc,omitempty"`
 	// +nullable
 	Reporting *Reporting `json:"reporting,omitempty"`
+	// TemplateRef instantiates the disruption from a template on creation, the fields defined by the template
+	// replacing the ones of the disruption
+	// +nullable
+	TemplateRef *DisruptionTemplateRef `json:"templateRef,omitempty"`
 }
 
 // DisruptionTriggers holds the options for changing when injector pods are created, and the timing of when the injection occurs
@@ -189,11 +210,18 @@ func (dd DisruptionDuration) Duration() time.Duration {
 
 type TargetInjection struct {
 	InjectorPodName string `json:"injectorPodName,omitempty"`
-	// +kubebuilder:validation:Enum=NotInjected;Injected;IsStuckOnRemoval
-	// +ddmark:validation:Enum=NotInjected;Injected;IsStuckOnRemoval
+	// +kubebuilder:validation:Enum=NotInjected;Injected;IsStuckOnRemoval;Drifted
+	// +ddmark:validation:Enum=NotInjected;Injected;IsStuckOnRemoval;Drifted
 	InjectionStatus chaostypes.DisruptionTargetInjectionStatus `json:"injectionStatus,omitempty"`
 	// since when this status is in place
 	Since metav1.Time `json:"since,omitempty"`
+	// group of the target according to the targeting strategy (topology domain or owner)
+	Group string `json:"group,omitempty"`
+	// restart count of the target when it was first checked against the restarts abort condition
+	InitialRestartCount *int32 `json:"initialRestartCount,omitempty"`
+	// injection results reported by the chaos pods of the target, by disruption kind
+	// +nullable
+	Results map[chaostypes.DisruptionKindName]InjectionResult `json:"results,omitempty"`
 }
 
 // TargetInjections map of target injection
@@ -214,8 +242,8 @@ func (in TargetInjections) GetTargetNames() []string {
 type DisruptionStatus struct {
 	IsStuckOnRemoval bool `json:"isStuckOnRemoval,omitempty"`
 	IsInjected       bool `json:"isInjected,omitempty"`
-	// +kubebuilder:validation:Enum=NotInjected;PartiallyInjected;PausedPartiallyInjected;Injected;PausedInjected;PreviouslyNotInjected;PreviouslyPartiallyInjected;PreviouslyInjected
-	// +ddmark:validation:Enum=NotInjected;PartiallyInjected;PausedPartiallyInjected;Injected;PausedInjected;PreviouslyNotInjected;PreviouslyPartiallyInjected;PreviouslyInjected
+	// +kubebuilder:validation:Enum=NotInjected;PartiallyInjected;PausedPartiallyInjected;Injected;PausedInjected;Paused;PreviouslyNotInjected;PreviouslyPartiallyInjected;PreviouslyInjected
+	// +ddmark:validation:Enum=NotInjected;PartiallyInjected;PausedPartiallyInjected;Injected;PausedInjected;Paused;PreviouslyNotInjected;PreviouslyPartiallyInjected;PreviouslyInjected
 	InjectionStatus chaostypes.DisruptionInjectionStatus `json:"injectionStatus,omitempty"`
 	// +nullable
 	TargetInjections TargetInjections `json:"targetInjections,omitempty"`
@@ -227,6 +255,27 @@ type DisruptionStatus struct {
 	InjectedTargetsCount int `json:"injectedTargetsCount"`
 	// Number of targets we want to target (count)
 	DesiredTargetsCount int `json:"desiredTargetsCount"`
+	// Number of targets with at least one chaos pod reporting a failed injection or cleanup
+	FailedTargetsCount int `json:"failedTargetsCount,omitempty"`
+	// Number of selected targets per group (topology domain or owner) when a targeting strategy is set
+	// +nullable
+	TargetGroups map[string]int `json:"targetGroups,omitempty"`
+	// Reason why the disruption has been aborted if any of its abort conditions held
+	AbortReason string `json:"abortReason,omitempty"`
+	// Since when the disruption is paused
+	// +nullable
+	PausedSince *metav1.Time `json:"pausedSince,omitempty"`
+	// Total time the disruption spent paused, excluding the ongoing pause
+	PausedDuration DisruptionDuration `json:"pausedDuration,omitempty"`
+	// Index of the current rollout stage, equal to the number of stages once the disruption count is reached
+	RolloutStage int `json:"rolloutStage,omitempty"`
+	// Since when the current rollout stage started
+	// +nullable
+	RolloutStageSince *metav1.Time `json:"rolloutStageSince,omitempty"`
+	// Total time the disruption spent paused when the current rollout stage started, the time paused during the stage not counting in its dwell
+	RolloutStagePausedDuration DisruptionDuration `json:"rolloutStagePausedDuration,omitempty"`
+	// Reason why the rollout has been halted if a target warning has been observed, the disruption keeping its current targets
+	RolloutHaltReason string `json:"rolloutHaltReason,omitempty"`
 }
 
 type DisruptionFilter struct {
@@ -238,6 +287,11 @@ type DisruptionFilter struct {
 // Disruption is the Schema for the disruptions API
 // +kubebuilder:resource:shortName=dis
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Injection Status",type=string,JSONPath=`.status.injectionStatus`
+// +kubebuilder:printcolumn:name="Desired",type=integer,JSONPath=`.status.desiredTargetsCount`
+// +kubebuilder:printcolumn:name="Injected",type=integer,JSONPath=`.status.injectedTargetsCount`
+// +kubebuilder:printcolumn:name="Failed",type=integer,JSONPath=`.status.failedTargetsCount`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 type Disruption struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -261,6 +315,52 @@ type DisruptionPulse struct {
 	ActiveDuration  DisruptionDuration `json:"activeDuration,omitempty"`
 	DormantDuration DisruptionDuration `json:"dormantDuration,omitempty"`
 	InitialDelay    DisruptionDuration `json:"initialDelay,omitempty"`
+	// MaxActiveDuration randomizes the duration of each active state between activeDuration and this duration when set
+	MaxActiveDuration DisruptionDuration `json:"maxActiveDuration,omitempty"`
+	// MaxDormantDuration randomizes the duration of each dormant state between dormantDuration and this duration when set
+	MaxDormantDuration DisruptionDuration `json:"maxDormantDuration,omitempty"`
+	// Seed of the random durations, making the pulse windows reproducible (derived from the disruption UID if not set, so all its chaos pods share the same durations)
+	// +nullable
+	Seed *int64 `json:"seed,omitempty"`
+}
+
+// IsRandom returns true if the active or dormant durations of the pulse are randomized
+func (p *DisruptionPulse) IsRandom() bool {
+	return p.MaxActiveDuration.Duration() > 0 || p.MaxDormantDuration.Duration() > 0
+}
+
+// Validate validates the pulse durations
+func (p *DisruptionPulse) Validate() (retErr error) {
+	if p.ActiveDuration.Duration() != 0 && p.ActiveDuration.Duration() < chaostypes.PulsingDisruptionMinimumDuration {
+		retErr = multierror.Append(retErr, fmt.Errorf("pulse activeDuration of %s should be greater than %s", p.ActiveDuration.Duration(), chaostypes.PulsingDisruptionMinimumDuration))
+	}
+
+	if p.DormantDuration.Duration() != 0 && p.DormantDuration.Duration() < chaostypes.PulsingDisruptionMinimumDuration {
+		retErr = multierror.Append(retErr, fmt.Errorf("pulse dormantDuration of %s should be greater than %s", p.DormantDuration.Duration(), chaostypes.PulsingDisruptionMinimumDuration))
+	}
+
+	// random durations are picked between the minimum durations and the maximum ones
+	if p.MaxActiveDuration.Duration() != 0 && p.MaxActiveDuration.Duration() < p.ActiveDuration.Duration() {
+		retErr = multierror.Append(retErr, fmt.Errorf("pulse maxActiveDuration of %s should be greater than the activeDuration of %s", p.MaxActiveDuration.Duration(), p.ActiveDuration.Duration()))
+	}
+
+	if p.MaxActiveDuration.Duration() != 0 && p.ActiveDuration.Duration() == 0 {
+		retErr = multierror.Append(retErr, errors.New("pulse maxActiveDuration requires an activeDuration, used as the minimum active duration"))
+	}
+
+	if p.MaxDormantDuration.Duration() != 0 && p.MaxDormantDuration.Duration() < p.DormantDuration.Duration() {
+		retErr = multierror.Append(retErr, fmt.Errorf("pulse maxDormantDuration of %s should be greater than the dormantDuration of %s", p.MaxDormantDuration.Duration(), p.DormantDuration.Duration()))
+	}
+
+	if p.MaxDormantDuration.Duration() != 0 && p.DormantDuration.Duration() == 0 {
+		retErr = multierror.Append(retErr, errors.New("pulse maxDormantDuration requires a dormantDuration, used as the minimum dormant duration"))
+	}
+
+	if p.Seed != nil && !p.IsRandom() {
+		retErr = multierror.Append(retErr, errors.New("pulse seed can only be set along with a maxActiveDuration or a maxDormantDuration"))
+	}
+
+	return retErr
 }
 
 func init() {
@@ -337,14 +437,32 @@ func (s DisruptionSpec) validateGlobalDisruptionScope() (retErr error) {
 		retErr = multierror.Append(retErr, errors.New("cannot execute a container failure because the level configuration is set to node"))
 	}
 
+	// Rule: cpu throttling not possible if disruption is node-level
+	if s.CPUPressure != nil && s.CPUPressure.Throttling != nil && s.Level == chaostypes.DisruptionLevelNode {
+		retErr = multierror.Append(retErr, errors.New("cannot throttle the CPU because the level configuration is set to node"))
+	}
+
+	// Rule: process failure not possible if disruption is node-level
+	if s.ProcessFailure != nil && s.Level == chaostypes.DisruptionLevelNode {
+		retErr = multierror.Append(retErr, errors.New("cannot execute a process failure because the level configuration is set to node"))
+	}
+
+	// Rule: container freeze not possible if disruption is node-level
+	if s.ContainerFreeze != nil && s.Level == chaostypes.DisruptionLevelNode {
+		retErr = multierror.Append(retErr, errors.New("cannot execute a container freeze because the level configuration is set to node"))
+	}
+
 	// Rule: on init compatibility
 	if s.OnInit {
 		if s.CPUPressure != nil ||
 			s.NodeFailure != nil ||
 			s.ContainerFailure != nil ||
+			s.ProcessFailure != nil ||
+			s.ContainerFreeze != nil ||
 			s.DiskPressure != nil ||
 			s.GRPC != nil ||
-			s.DiskFailure != nil {
+			s.DiskFailure != nil ||
+			s.DiskFill != nil {
 			retErr = multierror.Append(retErr, errors.New("OnInit is only compatible with network and dns disruptions"))
 		}
 
@@ -366,6 +484,11 @@ func (s DisruptionSpec) validateGlobalDisruptionScope() (retErr error) {
 		retErr = multierror.Append(retErr, errors.New("disk pressure disruptions apply to all containers, specifying certain containers does not isolate the disruption"))
 	}
 
+	// Rule: No specificity of containers on a disk fill disruption
+	if len(s.Containers) != 0 && s.DiskFill != nil {
+		retErr = multierror.Append(retErr, errors.New("disk fill disruptions fill the whole filesystem shared by all containers, specifying certain containers does not isolate the disruption"))
+	}
+
 	// Rule: DisruptionTrigger
 	if !s.Triggers.IsZero() {
 		if !s.Triggers.Inject.IsZero() && !s.Triggers.CreatePods.IsZero() {
@@ -379,16 +502,12 @@ func (s DisruptionSpec) validateGlobalDisruptionScope() (retErr error) {
 	if s.Pulse != nil {
 		if s.Pulse.ActiveDuration.Duration() > 0 || s.Pulse.DormantDuration.Duration() > 0 {
 			if s.NodeFailure != nil || s.ContainerFailure != nil {
-				retErr = multierror.Append(retErr, errors.New("pulse is only compatible with network, cpu pressure, disk pressure, dns and grpc disruptions"))
+				retErr = multierror.Append(retErr, errors.New("pulse is not compatible with node failure and container failure disruptions, which can't be cleaned before the end of the disruption"))
 			}
 		}
 
-		if s.Pulse.ActiveDuration.Duration() != 0 && s.Pulse.ActiveDuration.Duration() < chaostypes.PulsingDisruptionMinimumDuration {
-			retErr = multierror.Append(retErr, fmt.Errorf("pulse activeDuration of %s should be greater than %s", s.Pulse.ActiveDuration.Duration(), chaostypes.PulsingDisruptionMinimumDuration))
-		}
-
-		if s.Pulse.DormantDuration.Duration() != 0 && s.Pulse.DormantDuration.Duration() < chaostypes.PulsingDisruptionMinimumDuration {
-			retErr = multierror.Append(retErr, fmt.Errorf("pulse dormantDuration of %s should be greater than %s", s.Pulse.DormantDuration.Duration(), chaostypes.PulsingDisruptionMinimumDuration))
+		if err := s.Pulse.Validate(); err != nil {
+			retErr = multierror.Append(retErr, err)
 		}
 	}
 
@@ -401,6 +520,35 @@ func (s DisruptionSpec) validateGlobalDisruptionScope() (retErr error) {
 		retErr = multierror.Append(retErr, err)
 	}
 
+	// Rule: abort conditions must be valid
+	if s.AbortConditions != nil {
+		if err := s.AbortConditions.Validate(); err != nil {
+			retErr = multierror.Append(retErr, err)
+		}
+
+		if s.AbortConditions.Restarts != nil && s.Level == chaostypes.DisruptionLevelNode {
+			retErr = multierror.Append(retErr, errors.New("the restarts abort condition can only be used at the pod level"))
+		}
+	}
+
+	// Rule: rollout must be valid and relies on dynamic targeting to widen the targets
+	if s.Rollout != nil {
+		if err := s.Rollout.Validate(s.Count); err != nil {
+			retErr = multierror.Append(retErr, err)
+		}
+
+		if s.StaticTargeting {
+			retErr = multierror.Append(retErr, errors.New("rollout relies on dynamic targeting to widen the targets, it can't be used with static targeting"))
+		}
+	}
+
+	// Rule: targeting strategy must be valid
+	if s.Targeting != nil {
+		if err := s.Targeting.Validate(s.Level); err != nil {
+			retErr = multierror.Append(retErr, err)
+		}
+	}
+
 	return retErr
 }
 
@@ -413,6 +561,10 @@ func (s DisruptionSpec) DisruptionKindPicker(kind chaostypes.DisruptionKindName)
 		disruptionKind = s.NodeFailure
 	case chaostypes.DisruptionKindContainerFailure:
 		disruptionKind = s.ContainerFailure
+	case chaostypes.DisruptionKindProcessFailure:
+		disruptionKind = s.ProcessFailure
+	case chaostypes.DisruptionKindContainerFreeze:
+		disruptionKind = s.ContainerFreeze
 	case chaostypes.DisruptionKindNetworkDisruption:
 		disruptionKind = s.Network
 	case chaostypes.DisruptionKindCPUPressure:
@@ -425,6 +577,8 @@ func (s DisruptionSpec) DisruptionKindPicker(kind chaostypes.DisruptionKindName)
 		disruptionKind = s.GRPC
 	case chaostypes.DisruptionKindDiskFailure:
 		disruptionKind = s.DiskFailure
+	case chaostypes.DisruptionKindDiskFill:
+		disruptionKind = s.DiskFill
 	}
 
 	return disruptionKind
@@ -483,6 +637,14 @@ func (s DisruptionSpec) DisruptionCount() int {
 		count++
 	}
 
+	if s.ProcessFailure != nil {
+		count++
+	}
+
+	if s.ContainerFreeze != nil {
+		count++
+	}
+
 	if s.DNS != nil {
 		count++
 	}
@@ -507,6 +669,10 @@ func (s DisruptionSpec) DisruptionCount() int {
 		count++
 	}
 
+	if s.DiskFill != nil {
+		count++
+	}
+
 	return count
 }
 
@@ -558,6 +724,17 @@ func parseRandomTargets(targetLimit int, targetInjections TargetInjections, call
 	}
 }
 
+// GetPausedDuration returns the total time the disruption spent paused, including the ongoing pause if any
+func (status *DisruptionStatus) GetPausedDuration() time.Duration {
+	pausedDuration := status.PausedDuration.Duration()
+
+	if status.PausedSince != nil {
+		pausedDuration += time.Since(status.PausedSince.Time)
+	}
+
+	return pausedDuration
+}
+
 // HasTarget returns true when a target exists in the Target List or returns false.
 func (status *DisruptionStatus) HasTarget(searchTarget string) bool {
 	_, exists := status.TargetInjections[searchTarget]
@@ -588,6 +765,16 @@ func DisruptionHasNoSideEffects(kind string) bool {
 	return found
 }
 
+// HasNoSideEffects returns true if the given disruption kind leaves nothing to clean once its chaos pod is gone,
+// recoverable node failures having to be cleaned by their chaos pod unlike node crashes
+func (s DisruptionSpec) HasNoSideEffects(kind string) bool {
+	if chaostypes.DisruptionKindName(kind) == chaostypes.DisruptionKindNodeFailure && s.NodeFailure.IsRecoverable() {
+		return false
+	}
+
+	return DisruptionHasNoSideEffects(kind)
+}
+
 // ShouldSkipNodeFailureInjection returns true if we are attempting to inject a node failure that has already been injected for this given target
 // If we're using staticTargeting, we should never re-select a target whose InjectionStatus is anything other than NotInjected, as we may be
 // injecting into a pod that has been rescheduled onto a new node
@@ -7,10 +7,12 @@ package v1beta1_test
 
 import (
 	"sort"
+	"time"
 
 	. "github.com/DataDog/chaos-controller/api/v1beta1"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var _ = Describe("TargetInjections", func() {
@@ -68,3 +70,93 @@ var _ = Describe("Check if a target exist into DisruptionStatus targets list", f
 		})
 	})
 })
+
+var _ = Describe("DisruptionSpec Hash", func() {
+	It("should take every field into account", func() {
+		spec := DisruptionSpec{Duration: "1h"}
+		hash, err := spec.Hash()
+		Expect(err).ShouldNot(HaveOccurred())
+
+		spec.Paused = true
+		pausedHash, err := spec.Hash()
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(pausedHash).ToNot(Equal(hash))
+	})
+})
+
+var _ = Describe("DisruptionStatus GetPausedDuration", func() {
+	Context("with a disruption which has never been paused", func() {
+		It("should return 0", func() {
+			Expect((&DisruptionStatus{}).GetPausedDuration()).To(BeZero())
+		})
+	})
+
+	Context("with a disruption which has been paused before", func() {
+		It("should return the accumulated paused duration", func() {
+			status := DisruptionStatus{PausedDuration: "5m"}
+			Expect(status.GetPausedDuration()).To(Equal(5 * time.Minute))
+		})
+	})
+
+	Context("with a disruption currently paused", func() {
+		It("should include the ongoing pause", func() {
+			status := DisruptionStatus{
+				PausedDuration: "5m",
+				PausedSince:    &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+			}
+			Expect(status.GetPausedDuration()).To(BeNumerically("~", 15*time.Minute, time.Second))
+		})
+	})
+})
+
+var _ = Describe("DisruptionPulse Validate", func() {
+	var pulse DisruptionPulse
+
+	BeforeEach(func() {
+		pulse = DisruptionPulse{
+			ActiveDuration:  "1m",
+			DormantDuration: "2m",
+		}
+	})
+
+	It("should accept fixed durations", func() {
+		Expect(pulse.Validate()).To(Succeed())
+		Expect(pulse.IsRandom()).To(BeFalse())
+	})
+
+	It("should accept seeded random durations", func() {
+		seed := int64(42)
+		pulse.MaxActiveDuration = "5m"
+		pulse.MaxDormantDuration = "2m"
+		pulse.Seed = &seed
+
+		Expect(pulse.Validate()).To(Succeed())
+		Expect(pulse.IsRandom()).To(BeTrue())
+	})
+
+	It("should reject a maximum duration lower than its minimum duration", func() {
+		pulse.MaxActiveDuration = "30s"
+
+		Expect(pulse.Validate()).To(MatchError(ContainSubstring("maxActiveDuration of 30s should be greater than the activeDuration of 1m0s")))
+	})
+
+	It("should reject a maximum duration without its minimum duration", func() {
+		pulse.DormantDuration = ""
+		pulse.MaxDormantDuration = "1m"
+
+		Expect(pulse.Validate()).To(MatchError(ContainSubstring("maxDormantDuration requires a dormantDuration")))
+	})
+
+	It("should reject a seed with fixed durations", func() {
+		seed := int64(42)
+		pulse.Seed = &seed
+
+		Expect(pulse.Validate()).To(MatchError(ContainSubstring("pulse seed can only be set")))
+	})
+
+	It("should reject durations lower than the minimum pulsing duration", func() {
+		pulse.ActiveDuration = "100ms"
+
+		Expect(pulse.Validate()).To(MatchError(ContainSubstring("pulse activeDuration of 100ms should be greater than")))
+	})
+})
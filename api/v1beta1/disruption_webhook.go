@@ -43,6 +43,7 @@ var (
 	defaultClusterThreshold       float64
 	handlerEnabled                bool
 	defaultDuration               time.Duration
+	abortProbeAllowedHosts        []string
 	cloudServicesProvidersManager *cloudservice.CloudServicesProvidersManager
 	chaosNamespace                string
 	ddmarkClient                  ddmark.Client
@@ -70,6 +71,7 @@ func (r *Disruption) SetupWebhookWithManager(setupWebhookConfig utils.SetupWebho
 	defaultClusterThreshold = float64(setupWebhookConfig.ClusterThresholdFlag) / 100.0
 	handlerEnabled = setupWebhookConfig.HandlerEnabledFlag
 	defaultDuration = setupWebhookConfig.DefaultDurationFlag
+	abortProbeAllowedHosts = setupWebhookConfig.AbortProbeAllowedHostsFlag
 	cloudServicesProvidersManager = setupWebhookConfig.CloudServicesProvidersManager
 	chaosNamespace = setupWebhookConfig.ChaosNamespace
 	safemodeEnvironment = setupWebhookConfig.Environment
@@ -167,6 +169,17 @@ func (r *Disruption) ValidateCreate() error {
 		return err
 	}
 
+	// probes make the controller call their url, only the hosts allowed by the controller can be probed
+	if r.Spec.AbortConditions != nil {
+		if err := validateAbortProbesAllowedHosts("abortConditions.probes", r.Spec.AbortConditions.Probes); err != nil {
+			if mErr := metricsSink.MetricValidationFailed(r.getMetricsTags()); mErr != nil {
+				logger.Errorw("error sending a metric", "error", mErr)
+			}
+
+			return err
+		}
+	}
+
 	multiErr := ddmarkClient.ValidateStructMultierror(r.Spec, "validation_webhook")
 	if multiErr.ErrorOrNil() != nil {
 		return multierror.Prefix(multiErr, "ddmark: ")
@@ -232,25 +245,26 @@ You first need to remove those chaos pods (and potentially their finalizers) to
 	}
 
 	// compare old and new disruption hashes and deny any spec changes
+	// except the tunable fields, which are injected again by replacing the chaos pods
 	var oldHash, newHash string
 
 	if oldDisruption.Spec.StaticTargeting {
-		oldHash, err = oldDisruption.Spec.Hash()
+		oldHash, err = oldDisruption.Spec.WithoutTunables().Hash()
 		if err != nil {
 			return fmt.Errorf("error getting old disruption hash: %w", err)
 		}
 
-		newHash, err = r.Spec.Hash()
+		newHash, err = r.Spec.WithoutTunables().Hash()
 
 		if err != nil {
 			return fmt.Errorf("error getting new disruption hash: %w", err)
 		}
 	} else {
-		oldHash, err = oldDisruption.Spec.HashNoCount()
+		oldHash, err = oldDisruption.Spec.WithoutTunables().HashNoCount()
 		if err != nil {
 			return fmt.Errorf("error getting old disruption hash: %w", err)
 		}
-		newHash, err = r.Spec.HashNoCount()
+		newHash, err = r.Spec.WithoutTunables().HashNoCount()
 		if err != nil {
 			return fmt.Errorf("error getting new disruption hash: %w", err)
 		}
@@ -262,10 +276,10 @@ You first need to remove those chaos pods (and potentially their finalizers) to
 		logger.Errorw("error when comparing disruption spec hashes", "oldHash", oldHash, "newHash", newHash)
 
 		if oldDisruption.Spec.StaticTargeting {
-			return fmt.Errorf("[StaticTargeting: true] a disruption spec cannot be updated, please delete and recreate it if needed")
+			return fmt.Errorf("[StaticTargeting: true] only a disruption spec's paused field and tunable fields can be updated (%s), please delete and recreate it if needed", TunableFieldsDescription)
 		}
 
-		return fmt.Errorf("[StaticTargeting: false] only a disruption spec's Count field can be updated, please delete and recreate it if needed")
+		return fmt.Errorf("[StaticTargeting: false] only a disruption spec's Count field, paused field and tunable fields can be updated (%s), please delete and recreate it if needed", TunableFieldsDescription)
 	}
 
 	if err := r.Spec.Validate(); err != nil {
@@ -365,6 +379,14 @@ func (r *Disruption) initialSafetyNets() ([]string, error) {
 				responses = append(responses, response)
 			}
 		}
+
+		if r.Spec.DiskFill != nil {
+			if caught, response := safetyNetAllowRootDiskFill(r); caught {
+				logger.Debugw("the specified disruption fills the node root filesystem.", "SafetyNet Catch", "DiskFill")
+
+				responses = append(responses, response)
+			}
+		}
 	}
 
 	return responses, nil
@@ -549,9 +571,36 @@ func safetyNetAllowRootDiskFailure(r *Disruption) (bool, string) {
 		return false, ""
 	}
 
-	if strings.TrimSpace(r.Spec.DiskFailure.Path) == "/" {
-		return true, "the specified path for the disk failure disruption targeting a node must not be \"/\"."
+	for _, pattern := range r.Spec.DiskFailure.PathPatterns() {
+		if pattern.Pattern == "/" && pattern.Match == DiskFailurePathMatchPrefix {
+			return true, "the specified path for the disk failure disruption targeting a node must not be \"/\"."
+		}
+	}
+
+	return false, ""
+}
+
+// safetyNetAllowRootDiskFill is the safety net regarding a disk fill disruption filling the root filesystem of a node
+// it is an early guard, the injector also refuses to fill any path living on the host root filesystem unless allowed
+func safetyNetAllowRootDiskFill(r *Disruption) (bool, string) {
+	if r.Spec.Unsafemode != nil && r.Spec.Unsafemode.AllowRootDiskFill {
+		return false, ""
+	}
+
+	if r.Spec.DiskFill.FillsNodeRootFilesystem(r.Spec.Level) {
+		return true, "the specified path for the disk fill disruption targeting a node must not be \"/\"."
 	}
 
 	return false, ""
 }
+
+// validateAbortProbesAllowedHosts returns an error if any of the given probes targets a host not allowed by the controller
+func validateAbortProbesAllowedHosts(field string, probes []AbortProbe) (retErr error) {
+	for i, probe := range probes {
+		if err := probe.ValidateAllowedHosts(abortProbeAllowedHosts); err != nil {
+			retErr = multierror.Append(retErr, fmt.Errorf("%s[%d]: %w", field, i, err))
+		}
+	}
+
+	return retErr
+}
@@ -129,6 +129,54 @@ var _ = Describe("Disruption", func() {
 				})
 			})
 
+			When("a tunable field is updated", func() {
+				BeforeEach(func() {
+					newDisruption.Spec.Network.Drop = 50
+					newDisruption.Spec.Network.Delay = 500
+				})
+
+				Context("DynamicTargeting (StaticTargeting=false)", func() {
+					It("should succeed", func() {
+						Expect(newDisruption.ValidateUpdate(oldDisruption)).Should(Succeed())
+					})
+				})
+
+				Context("StaticTargeting", func() {
+					It("should succeed", func() {
+						oldDisruption.Spec.StaticTargeting = true
+						newDisruption.Spec.StaticTargeting = true
+
+						Expect(newDisruption.ValidateUpdate(oldDisruption)).Should(Succeed())
+					})
+				})
+			})
+
+			When("the paused field is updated", func() {
+				BeforeEach(func() {
+					newDisruption.Spec.Paused = true
+				})
+
+				It("should succeed", func() {
+					oldDisruption.Spec.StaticTargeting = true
+					newDisruption.Spec.StaticTargeting = true
+
+					Expect(newDisruption.ValidateUpdate(oldDisruption)).Should(Succeed())
+				})
+			})
+
+			When("a non tunable field is updated", func() {
+				BeforeEach(func() {
+					newDisruption.Spec.Network.Hosts = []NetworkDisruptionHostSpec{{Host: "10.0.0.1"}}
+				})
+
+				It("should fail", func() {
+					err := newDisruption.ValidateUpdate(oldDisruption)
+
+					Expect(err).Should(HaveOccurred())
+					Expect(err.Error()).Should(ContainSubstring("only a disruption spec's Count field, paused field and tunable fields can be updated"))
+				})
+			})
+
 			When("StaticTargeting is updated", func() {
 				When("static to dynamic", func() {
 					BeforeEach(func() {
@@ -368,6 +416,71 @@ var _ = Describe("Disruption", func() {
 				})
 			})
 		})
+
+		Describe("expectations with a disk fill disruption", func() {
+			BeforeEach(func() {
+				ddmarkMock.EXPECT().ValidateStructMultierror(mock.Anything, mock.Anything).Return(&multierror.Error{})
+				k8sClient = makek8sClientWithDisruptionPod()
+				recorder = record.NewFakeRecorder(1)
+				metricsSink = noop.New(logger)
+				deleteOnly = false
+				enableSafemode = true
+			})
+
+			JustBeforeEach(func() {
+				newDisruption = makeValidDiskFillDisruption()
+				controllerutil.AddFinalizer(newDisruption, chaostypes.DisruptionFinalizer)
+			})
+
+			AfterEach(func() {
+				k8sClient = nil
+				newDisruption = nil
+			})
+
+			When("the disruption target a 'node' with the '/' path", func() {
+				JustBeforeEach(func() {
+					newDisruption.Spec.Level = chaostypes.DisruptionLevelNode
+				})
+
+				It("should deny the usage of '/' path", func() {
+					// Action
+					err := newDisruption.ValidateCreate()
+
+					// Assert
+					Expect(err).Should(HaveOccurred())
+					Expect(err.Error()).Should(ContainSubstring("the specified path for the disk fill disruption targeting a node must not be \"/\"."))
+				})
+
+				Context("safe-mode disabled", func() {
+					It("should allow the '/' path", func() {
+						// Arrange
+						newDisruption.Spec.Unsafemode = &UnsafemodeSpec{
+							AllowRootDiskFill: true,
+						}
+
+						// Action
+						err := newDisruption.ValidateCreate()
+
+						// Assert
+						Expect(err).ShouldNot(HaveOccurred())
+					})
+				})
+			})
+
+			When("the disruption target a 'pod' with the '/' path", func() {
+				JustBeforeEach(func() {
+					newDisruption.Spec.Level = chaostypes.DisruptionLevelPod
+				})
+
+				It("should allow the usage of this path", func() {
+					// Action
+					err := newDisruption.ValidateCreate()
+
+					// Assert
+					Expect(err).ShouldNot(HaveOccurred())
+				})
+			})
+		})
 	})
 })
 
@@ -415,6 +528,31 @@ func makeValidDiskFailureDisruption() *Disruption {
 	}
 }
 
+// makeValidDiskFillDisruption is a helper that constructs a valid Disruption suited for basic webhook validation testing
+func makeValidDiskFillDisruption() *Disruption {
+	percentage := 90
+
+	return &Disruption{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testDisruptionName,
+			Namespace: chaosNamespace,
+		},
+		Spec: DisruptionSpec{
+			Count: &intstr.IntOrString{
+				IntVal: 1,
+			},
+			Selector: labels.Set{
+				"name":      "random",
+				"namespace": "random",
+			},
+			DiskFill: &DiskFillSpec{
+				Path:       "/",
+				Percentage: &percentage,
+			},
+		},
+	}
+}
+
 // makek8sClientWithDisruptionPod is a help that creates a k8sClient returning at least one valid pod associated with the Disruption created with makeValidNetworkDisruption
 func makek8sClientWithDisruptionPod() client.Client {
 	return fake.NewClientBuilder().
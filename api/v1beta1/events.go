@@ -73,17 +73,39 @@ const (
 	EventDisruptionNoMoreValidTargets   DisruptionEventReason = "NoMoreTargets"
 	EventDisruptionNoTargetsFound       DisruptionEventReason = "NoTargetsFound"
 	EventInvalidSpecDisruption          DisruptionEventReason = "InvalidSpec"
+	EventDisruptionPodDisruptionBudget  DisruptionEventReason = "PodDisruptionBudgetViolation"
+	EventDisruptionAborted              DisruptionEventReason = "Aborted"
+	EventDisruptionRolloutHalted        DisruptionEventReason = "RolloutHalted"
 	// Normal events
 	EventDisruptionChaosPodCreated DisruptionEventReason = "ChaosPodCreated"
 	EventDisruptionFinished        DisruptionEventReason = "Finished"
 	EventDisruptionCreated         DisruptionEventReason = "Created"
 	EventDisruptionDurationOver    DisruptionEventReason = "DurationOver"
 	EventDisruptionGCOver          DisruptionEventReason = "GCOver"
+	EventDisruptionPaused          DisruptionEventReason = "Paused"
+	EventDisruptionResumed         DisruptionEventReason = "Resumed"
+	EventDisruptionTuned           DisruptionEventReason = "Tuned"
+	EventDisruptionRolloutStage    DisruptionEventReason = "RolloutStage"
 	EventDisrupted                 DisruptionEventReason = "Disrupted"
 
 	// Injection related events
 	// Warning events
-	EventChaosPodFailedState DisruptionEventReason = "ChaosPodWarningState"
+	EventChaosPodFailedState      DisruptionEventReason = "ChaosPodWarningState"
+	EventChaosPodInjectionDrifted DisruptionEventReason = "InjectionDrifted"
+
+	// Disruption cron related events
+	// Warning events
+	EventDisruptionCronSkipped DisruptionEventReason = "DisruptionSkipped"
+	// Normal events
+	EventDisruptionCronScheduled DisruptionEventReason = "DisruptionScheduled"
+
+	// Chaos workflow related events
+	// Warning events
+	EventChaosWorkflowFailed DisruptionEventReason = "WorkflowFailed"
+	// Normal events
+	EventChaosWorkflowStepStarted   DisruptionEventReason = "StepStarted"
+	EventChaosWorkflowStepCompleted DisruptionEventReason = "StepCompleted"
+	EventChaosWorkflowSucceeded     DisruptionEventReason = "WorkflowSucceeded"
 )
 
 var Events = map[DisruptionEventReason]DisruptionEvent{
@@ -235,6 +257,48 @@ var Events = map[DisruptionEventReason]DisruptionEvent{
 		OnDisruptionTemplateMessage: "%s",
 		Category:                    DisruptEvent,
 	},
+	EventDisruptionPodDisruptionBudget: {
+		Type:                        corev1.EventTypeWarning,
+		Reason:                      EventDisruptionPodDisruptionBudget,
+		OnDisruptionTemplateMessage: "Some targets were not selected because disrupting them would violate a pod disruption budget: %s",
+		Category:                    DisruptEvent,
+	},
+	EventDisruptionAborted: {
+		Type:                        corev1.EventTypeWarning,
+		Reason:                      EventDisruptionAborted,
+		OnDisruptionTemplateMessage: "Disruption aborted before the end of its duration: %s",
+		Category:                    DisruptEvent,
+	},
+	EventDisruptionPaused: {
+		Type:                        corev1.EventTypeNormal,
+		Reason:                      EventDisruptionPaused,
+		OnDisruptionTemplateMessage: "Disruption paused, its chaos pods are being cleaned until it is resumed",
+		Category:                    DisruptEvent,
+	},
+	EventDisruptionResumed: {
+		Type:                        corev1.EventTypeNormal,
+		Reason:                      EventDisruptionResumed,
+		OnDisruptionTemplateMessage: "Disruption resumed after being paused for %s",
+		Category:                    DisruptEvent,
+	},
+	EventDisruptionTuned: {
+		Type:                        corev1.EventTypeNormal,
+		Reason:                      EventDisruptionTuned,
+		OnDisruptionTemplateMessage: "Disruption tunable fields updated, its chaos pods are being replaced to inject the new values: %s",
+		Category:                    DisruptEvent,
+	},
+	EventDisruptionRolloutHalted: {
+		Type:                        corev1.EventTypeWarning,
+		Reason:                      EventDisruptionRolloutHalted,
+		OnDisruptionTemplateMessage: "Disruption rollout halted, its targets won't be widened anymore: %s",
+		Category:                    DisruptEvent,
+	},
+	EventDisruptionRolloutStage: {
+		Type:                        corev1.EventTypeNormal,
+		Reason:                      EventDisruptionRolloutStage,
+		OnDisruptionTemplateMessage: "Disruption rollout stage started: %s",
+		Category:                    DisruptEvent,
+	},
 	EventDisruptionChaosPodCreated: {
 		Type:                        corev1.EventTypeNormal,
 		Reason:                      EventDisruptionChaosPodCreated,
@@ -266,6 +330,49 @@ var Events = map[DisruptionEventReason]DisruptionEvent{
 		OnDisruptionTemplateAggMessage: "Chaos pod(s) are not ready",
 		Category:                       ChaosPodEvent,
 	},
+	EventChaosPodInjectionDrifted: {
+		Type:                           corev1.EventTypeWarning,
+		Reason:                         EventChaosPodInjectionDrifted,
+		OnDisruptionTemplateMessage:    "Chaos pod %s reports the disruption is not in effect anymore on target %s",
+		OnDisruptionTemplateAggMessage: "Disruption is not in effect anymore on some targets",
+		Category:                       ChaosPodEvent,
+	},
+	EventDisruptionCronSkipped: {
+		Type:                        corev1.EventTypeWarning,
+		Reason:                      EventDisruptionCronSkipped,
+		OnDisruptionTemplateMessage: "Scheduled disruption has been skipped: %s",
+		Category:                    DisruptEvent,
+	},
+	EventDisruptionCronScheduled: {
+		Type:                        corev1.EventTypeNormal,
+		Reason:                      EventDisruptionCronScheduled,
+		OnDisruptionTemplateMessage: "Disruption %s has been created",
+		Category:                    DisruptEvent,
+	},
+	EventChaosWorkflowFailed: {
+		Type:                        corev1.EventTypeWarning,
+		Reason:                      EventChaosWorkflowFailed,
+		OnDisruptionTemplateMessage: "Workflow has failed on step %s: %s",
+		Category:                    DisruptEvent,
+	},
+	EventChaosWorkflowStepStarted: {
+		Type:                        corev1.EventTypeNormal,
+		Reason:                      EventChaosWorkflowStepStarted,
+		OnDisruptionTemplateMessage: "Step %s has started",
+		Category:                    DisruptEvent,
+	},
+	EventChaosWorkflowStepCompleted: {
+		Type:                        corev1.EventTypeNormal,
+		Reason:                      EventChaosWorkflowStepCompleted,
+		OnDisruptionTemplateMessage: "Step %s has been completed",
+		Category:                    DisruptEvent,
+	},
+	EventChaosWorkflowSucceeded: {
+		Type:                        corev1.EventTypeNormal,
+		Reason:                      EventChaosWorkflowSucceeded,
+		OnDisruptionTemplateMessage: "All the workflow steps have been completed",
+		Category:                    DisruptEvent,
+	},
 }
 
 // IsNotifiableEvent this event can be broadcasted to our notifiers
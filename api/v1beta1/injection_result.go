@@ -0,0 +1,60 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	chaostypes "github.com/DataDog/chaos-controller/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// InjectionResult is the result of the injection of a disruption kind on a target, reported by the injector
+// in the annotations of its chaos pod
+type InjectionResult struct {
+	// Whether the last injection or cleanup succeeded
+	Success bool `json:"success"`
+	// Error of the last failed injection or cleanup
+	Error string `json:"error,omitempty"`
+	// Time of the last successful injection
+	// +nullable
+	InjectedAt *metav1.Time `json:"injectedAt,omitempty"`
+	// Time of the last successful cleanup
+	// +nullable
+	CleanedAt *metav1.Time `json:"cleanedAt,omitempty"`
+	// Number of times the disruption was reinjected, on target container restart or on pulse
+	ReinjectionCount int `json:"reinjectionCount,omitempty"`
+	// IPs the hosts of a network disruption were resolved to, by host
+	// +nullable
+	ResolvedHosts map[string][]string `json:"resolvedHosts,omitempty"`
+}
+
+// GetInjectionResult returns the injection result reported in the given chaos pod annotations, nil if none was reported yet
+func GetInjectionResult(annotations map[string]string) (*InjectionResult, error) {
+	rawResult, found := annotations[chaostypes.InjectionResultAnnotation]
+	if !found {
+		return nil, nil
+	}
+
+	result := InjectionResult{}
+	if err := json.Unmarshal([]byte(rawResult), &result); err != nil {
+		return nil, fmt.Errorf("error unmarshaling the %s annotation: %w", chaostypes.InjectionResultAnnotation, err)
+	}
+
+	return &result, nil
+}
+
+// HasFailedResult returns true if the injection of at least one disruption kind failed on the target
+func (in TargetInjection) HasFailedResult() bool {
+	for _, result := range in.Results {
+		if !result.Success {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,58 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package v1beta1_test
+
+import (
+	. "github.com/DataDog/chaos-controller/api/v1beta1"
+	chaostypes "github.com/DataDog/chaos-controller/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("InjectionResult", func() {
+	Describe("GetInjectionResult", func() {
+		It("should return nil when no result was reported", func() {
+			result, err := GetInjectionResult(map[string]string{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(BeNil())
+		})
+
+		It("should parse the reported result", func() {
+			result, err := GetInjectionResult(map[string]string{
+				chaostypes.InjectionResultAnnotation: `{"success":false,"error":"injection failed: boom","reinjectionCount":2,"resolvedHosts":{"foo.bar":["10.0.0.1/32"]}}`,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*result).To(Equal(InjectionResult{
+				Success:          false,
+				Error:            "injection failed: boom",
+				ReinjectionCount: 2,
+				ResolvedHosts:    map[string][]string{"foo.bar": {"10.0.0.1/32"}},
+			}))
+		})
+
+		It("should fail with an invalid annotation", func() {
+			_, err := GetInjectionResult(map[string]string{chaostypes.InjectionResultAnnotation: "not json"})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("HasFailedResult", func() {
+		It("should be true if one of the kinds failed", func() {
+			injection := TargetInjection{Results: map[chaostypes.DisruptionKindName]InjectionResult{
+				chaostypes.DisruptionKindNetworkDisruption: {Success: true},
+				chaostypes.DisruptionKindDNSDisruption:     {Success: false, Error: "injection failed: boom"},
+			}}
+			Expect(injection.HasFailedResult()).To(BeTrue())
+		})
+
+		It("should be false without any failed kind", func() {
+			Expect(TargetInjection{}.HasFailedResult()).To(BeFalse())
+			Expect(TargetInjection{Results: map[chaostypes.DisruptionKindName]InjectionResult{
+				chaostypes.DisruptionKindNetworkDisruption: {Success: true},
+			}}.HasFailedResult()).To(BeFalse())
+		})
+	})
+})
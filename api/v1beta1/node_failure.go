@@ -5,14 +5,111 @@
 
 package v1beta1
 
-// NodeFailureSpec represents a node failure injection
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+// NodeFailureKubeletAction is the way the kubelet is disrupted
+type NodeFailureKubeletAction string
+
+const (
+	// NodeFailureKubeletFreeze pauses the kubelet processes with SIGSTOP, resuming them with SIGCONT on cleanup
+	NodeFailureKubeletFreeze NodeFailureKubeletAction = "freeze"
+	// NodeFailureKubeletStop stops the kubelet service of the node, starting it again on cleanup
+	NodeFailureKubeletStop NodeFailureKubeletAction = "stop"
+)
+
+// NodeFailureSpec represents a node failure injection,
+// the node crashing (or shutting down) unless a recoverable failure (kubelet, networkIsolation or drain) is specified
+// +ddmark:validation:ExclusiveFields={Shutdown,Kubelet,NetworkIsolation,Drain}
+// +ddmark:validation:ExclusiveFields={Kubelet,NetworkIsolation,Drain}
+// +ddmark:validation:ExclusiveFields={NetworkIsolation,Drain}
 type NodeFailureSpec struct {
+	// Shutdown shuts the node down instead of restarting it
 	Shutdown bool `json:"shutdown,omitempty"`
+	// Kubelet freezes or stops the kubelet of the node until the disruption is cleaned
+	// +nullable
+	Kubelet *NodeFailureKubeletSpec `json:"kubelet,omitempty"`
+	// NetworkIsolation drops all the traffic of the node except the one exchanged with the control plane until the disruption is cleaned
+	// +nullable
+	NetworkIsolation *NodeFailureNetworkIsolationSpec `json:"networkIsolation,omitempty"`
+	// Drain cordons the node and evicts its pods, the node being uncordoned when the disruption is cleaned
+	// +nullable
+	Drain *NodeFailureDrainSpec `json:"drain,omitempty"`
+}
+
+// NodeFailureKubeletSpec represents a kubelet failure
+type NodeFailureKubeletSpec struct {
+	// Action is either freeze (default) to pause the kubelet processes or stop to stop the kubelet service
+	// +kubebuilder:validation:Enum=freeze;stop
+	// +ddmark:validation:Enum=freeze;stop
+	Action NodeFailureKubeletAction `json:"action,omitempty"`
+}
+
+// NodeFailureNetworkIsolationSpec represents a node network isolation
+type NodeFailureNetworkIsolationSpec struct {
+	// AllowedHosts are IPs or CIDRs which can still be reached in addition to the control plane
+	AllowedHosts []string `json:"allowedHosts,omitempty"`
+}
+
+// NodeFailureDrainSpec represents a node drain
+type NodeFailureDrainSpec struct {
+	// GracePeriodSeconds overrides the termination grace period of the evicted pods
+	// +kubebuilder:validation:Minimum=0
+	GracePeriodSeconds *int64 `json:"gracePeriodSeconds,omitempty"`
 }
 
 // Validate validates args for the given disruption
-func (s *NodeFailureSpec) Validate() error {
-	return nil
+func (s *NodeFailureSpec) Validate() (retErr error) {
+	recoverable := 0
+
+	if s.Kubelet != nil {
+		recoverable++
+
+		switch s.Kubelet.Action {
+		case "", NodeFailureKubeletFreeze, NodeFailureKubeletStop:
+		default:
+			retErr = multierror.Append(retErr, fmt.Errorf("unknown kubelet action %q, expected one of freeze or stop", s.Kubelet.Action))
+		}
+	}
+
+	if s.NetworkIsolation != nil {
+		recoverable++
+
+		for _, host := range s.NetworkIsolation.AllowedHosts {
+			if net.ParseIP(host) == nil {
+				if _, _, err := net.ParseCIDR(host); err != nil {
+					retErr = multierror.Append(retErr, fmt.Errorf("the network isolation allowed host %s must be an IP or a CIDR", host))
+				}
+			}
+		}
+	}
+
+	if s.Drain != nil {
+		recoverable++
+
+		if s.Drain.GracePeriodSeconds != nil && *s.Drain.GracePeriodSeconds < 0 {
+			retErr = multierror.Append(retErr, fmt.Errorf("the drain grace period must be positive, got %d", *s.Drain.GracePeriodSeconds))
+		}
+	}
+
+	// Rule: a single way of failing the node
+	if recoverable > 1 {
+		retErr = multierror.Append(retErr, errors.New("kubelet, networkIsolation and drain node failures can't be used together"))
+	}
+
+	// Rule: a recoverable node failure does not shut the node down
+	if recoverable > 0 && s.Shutdown {
+		retErr = multierror.Append(retErr, errors.New("shutdown can't be used with a kubelet, networkIsolation or drain node failure"))
+	}
+
+	return retErr
 }
 
 // GenerateArgs generates injection or cleanup pod arguments for the given spec
@@ -22,9 +119,35 @@ func (s *NodeFailureSpec) GenerateArgs() []string {
 		"inject",
 	}
 
-	if s.Shutdown {
+	switch {
+	case s.Kubelet != nil:
+		action := s.Kubelet.Action
+		if action == "" {
+			action = NodeFailureKubeletFreeze
+		}
+
+		args = append(args, "--kubelet", string(action))
+	case s.NetworkIsolation != nil:
+		args = append(args, "--network-isolation")
+
+		if len(s.NetworkIsolation.AllowedHosts) > 0 {
+			args = append(args, "--allowed-hosts", strings.Join(s.NetworkIsolation.AllowedHosts, ","))
+		}
+	case s.Drain != nil:
+		args = append(args, "--drain")
+
+		if s.Drain.GracePeriodSeconds != nil {
+			args = append(args, "--grace-period-seconds", strconv.FormatInt(*s.Drain.GracePeriodSeconds, 10))
+		}
+	case s.Shutdown:
 		args = append(args, "--shutdown")
 	}
 
 	return args
 }
+
+// IsRecoverable returns true if the node failure is cleaned when the disruption ends,
+// a node crash or shutdown being unrecoverable
+func (s *NodeFailureSpec) IsRecoverable() bool {
+	return s != nil && (s.Kubelet != nil || s.NetworkIsolation != nil || s.Drain != nil)
+}
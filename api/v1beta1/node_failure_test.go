@@ -0,0 +1,65 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package v1beta1_test
+
+import (
+	. "github.com/DataDog/chaos-controller/api/v1beta1"
+	chaostypes "github.com/DataDog/chaos-controller/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NodeFailureSpec", func() {
+	gracePeriod := int64(30)
+	negativeGracePeriod := int64(-1)
+
+	Describe("Validate", func() {
+		DescribeTable("should succeed with a valid spec",
+			func(spec NodeFailureSpec) {
+				Expect(spec.Validate()).To(Succeed())
+			},
+			Entry("crash", NodeFailureSpec{}),
+			Entry("shutdown", NodeFailureSpec{Shutdown: true}),
+			Entry("kubelet freeze by default", NodeFailureSpec{Kubelet: &NodeFailureKubeletSpec{}}),
+			Entry("kubelet stop", NodeFailureSpec{Kubelet: &NodeFailureKubeletSpec{Action: NodeFailureKubeletStop}}),
+			Entry("network isolation", NodeFailureSpec{NetworkIsolation: &NodeFailureNetworkIsolationSpec{AllowedHosts: []string{"10.0.0.1", "10.1.0.0/16"}}}),
+			Entry("drain", NodeFailureSpec{Drain: &NodeFailureDrainSpec{GracePeriodSeconds: &gracePeriod}}),
+		)
+
+		DescribeTable("should fail with an invalid spec",
+			func(spec NodeFailureSpec) {
+				Expect(spec.Validate()).ToNot(Succeed())
+			},
+			Entry("unknown kubelet action", NodeFailureSpec{Kubelet: &NodeFailureKubeletSpec{Action: "kill"}}),
+			Entry("invalid allowed host", NodeFailureSpec{NetworkIsolation: &NodeFailureNetworkIsolationSpec{AllowedHosts: []string{"example.com"}}}),
+			Entry("negative grace period", NodeFailureSpec{Drain: &NodeFailureDrainSpec{GracePeriodSeconds: &negativeGracePeriod}}),
+			Entry("several recoverable failures", NodeFailureSpec{Kubelet: &NodeFailureKubeletSpec{}, Drain: &NodeFailureDrainSpec{}}),
+			Entry("shutdown with a recoverable failure", NodeFailureSpec{Shutdown: true, NetworkIsolation: &NodeFailureNetworkIsolationSpec{}}),
+		)
+	})
+
+	DescribeTable("GenerateArgs",
+		func(spec NodeFailureSpec, expectedArgs []string) {
+			Expect(spec.GenerateArgs()).To(Equal(expectedArgs))
+		},
+		Entry("crash", NodeFailureSpec{}, []string{"node-failure", "inject"}),
+		Entry("shutdown", NodeFailureSpec{Shutdown: true}, []string{"node-failure", "inject", "--shutdown"}),
+		Entry("kubelet freeze by default", NodeFailureSpec{Kubelet: &NodeFailureKubeletSpec{}}, []string{"node-failure", "inject", "--kubelet", "freeze"}),
+		Entry("kubelet stop", NodeFailureSpec{Kubelet: &NodeFailureKubeletSpec{Action: NodeFailureKubeletStop}}, []string{"node-failure", "inject", "--kubelet", "stop"}),
+		Entry("network isolation", NodeFailureSpec{NetworkIsolation: &NodeFailureNetworkIsolationSpec{AllowedHosts: []string{"10.0.0.1", "10.1.0.0/16"}}}, []string{"node-failure", "inject", "--network-isolation", "--allowed-hosts", "10.0.0.1,10.1.0.0/16"}),
+		Entry("drain", NodeFailureSpec{Drain: &NodeFailureDrainSpec{GracePeriodSeconds: &gracePeriod}}, []string{"node-failure", "inject", "--drain", "--grace-period-seconds", "30"}),
+	)
+
+	DescribeTable("HasNoSideEffects",
+		func(spec NodeFailureSpec, expected bool) {
+			Expect(DisruptionSpec{NodeFailure: &spec}.HasNoSideEffects(chaostypes.DisruptionKindNodeFailure)).To(Equal(expected))
+		},
+		Entry("crash", NodeFailureSpec{}, true),
+		Entry("kubelet", NodeFailureSpec{Kubelet: &NodeFailureKubeletSpec{}}, false),
+		Entry("network isolation", NodeFailureSpec{NetworkIsolation: &NodeFailureNetworkIsolationSpec{}}, false),
+		Entry("drain", NodeFailureSpec{Drain: &NodeFailureDrainSpec{}}, false),
+	)
+})
@@ -0,0 +1,166 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package v1beta1
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+// DefaultProcessFailureSignal is the signal sent to the processes when not specified
+const DefaultProcessFailureSignal = "SIGTERM"
+
+// ProcessFailureSignals are the signals which can be sent to the processes
+var ProcessFailureSignals = map[string]syscall.Signal{
+	"SIGABRT": syscall.SIGABRT,
+	"SIGCONT": syscall.SIGCONT,
+	"SIGHUP":  syscall.SIGHUP,
+	"SIGINT":  syscall.SIGINT,
+	"SIGKILL": syscall.SIGKILL,
+	"SIGQUIT": syscall.SIGQUIT,
+	"SIGSTOP": syscall.SIGSTOP,
+	"SIGTERM": syscall.SIGTERM,
+	"SIGUSR1": syscall.SIGUSR1,
+	"SIGUSR2": syscall.SIGUSR2,
+}
+
+// nonDisruptiveProcessFailureSignals are the signals which are usually handled by the processes without stopping them
+// (configuration reload, log rotation, debug dump...) and are not expected to make the targets unavailable
+var nonDisruptiveProcessFailureSignals = map[string]struct{}{
+	"SIGCONT": {},
+	"SIGHUP":  {},
+	"SIGUSR1": {},
+	"SIGUSR2": {},
+}
+
+// ProcessFailureSpec represents a process failure injection
+type ProcessFailureSpec struct {
+	// Pattern is a regular expression matched against the name and the command line of the processes of the targeted containers
+	// +ddmark:validation:Required=true
+	Pattern string `json:"pattern"`
+	// Signal is the signal sent to the matching processes, SIGTERM by default;
+	// SIGSTOP pauses the processes which are resumed with SIGCONT after the pause duration or when the disruption is cleaned
+	// +kubebuilder:validation:Enum=SIGABRT;SIGCONT;SIGHUP;SIGINT;SIGKILL;SIGQUIT;SIGSTOP;SIGTERM;SIGUSR1;SIGUSR2
+	// +ddmark:validation:Enum=SIGABRT;SIGCONT;SIGHUP;SIGINT;SIGKILL;SIGQUIT;SIGSTOP;SIGTERM;SIGUSR1;SIGUSR2
+	Signal string `json:"signal,omitempty"`
+	// PauseDuration is the time the processes are paused for when sending the SIGSTOP signal
+	PauseDuration DisruptionDuration `json:"pauseDuration,omitempty"`
+	// Interval repeats the injection at the given interval while the disruption is running
+	Interval DisruptionDuration `json:"interval,omitempty"`
+}
+
+// Validate validates args for the given disruption
+func (s *ProcessFailureSpec) Validate() (retErr error) {
+	if s.Pattern == "" {
+		retErr = multierror.Append(retErr, errors.New("the process failure pattern is required"))
+	} else if _, err := regexp.Compile(s.Pattern); err != nil {
+		retErr = multierror.Append(retErr, fmt.Errorf("invalid process failure pattern %s: %w", s.Pattern, err))
+	}
+
+	if _, err := ParseProcessFailureSignal(s.Signal); err != nil {
+		retErr = multierror.Append(retErr, err)
+	}
+
+	pauseDuration, err := parseOptionalDuration(s.PauseDuration)
+	if err != nil {
+		retErr = multierror.Append(retErr, fmt.Errorf("invalid process failure pause duration %s: %w", s.PauseDuration, err))
+	}
+
+	interval, err := parseOptionalDuration(s.Interval)
+	if err != nil {
+		retErr = multierror.Append(retErr, fmt.Errorf("invalid process failure interval %s: %w", s.Interval, err))
+	}
+
+	if pauseDuration != 0 && s.Signal != "SIGSTOP" {
+		retErr = multierror.Append(retErr, errors.New("the process failure pause duration can only be used with the SIGSTOP signal"))
+	}
+
+	if interval != 0 {
+		if interval < time.Second {
+			retErr = multierror.Append(retErr, errors.New("the process failure interval must be at least 1s"))
+		}
+
+		// a paused process must be resumed before being paused again
+		if s.Signal == "SIGSTOP" && (pauseDuration == 0 || pauseDuration >= interval) {
+			retErr = multierror.Append(retErr, errors.New("the process failure pause duration must be set and lower than the interval to repeat a pause"))
+		}
+	}
+
+	return retErr
+}
+
+// MakesTargetsUnavailable returns true if the signal sent to the processes is likely to make the targets unavailable,
+// which is the case of the signals terminating or pausing the processes
+func (s *ProcessFailureSpec) MakesTargetsUnavailable() bool {
+	signal := s.Signal
+	if signal == "" {
+		signal = DefaultProcessFailureSignal
+	}
+
+	_, nonDisruptive := nonDisruptiveProcessFailureSignals[signal]
+
+	return !nonDisruptive
+}
+
+// GenerateArgs generates injection or cleanup pod arguments for the given spec
+func (s *ProcessFailureSpec) GenerateArgs() []string {
+	args := []string{
+		"process-failure",
+		"--pattern",
+		s.Pattern,
+	}
+
+	if s.Signal != "" {
+		args = append(args, "--signal", s.Signal)
+	}
+
+	if s.PauseDuration != "" {
+		args = append(args, "--pause-duration", string(s.PauseDuration))
+	}
+
+	if s.Interval != "" {
+		args = append(args, "--interval", string(s.Interval))
+	}
+
+	return args
+}
+
+// ParseProcessFailureSignal returns the signal of the given name, SIGTERM if empty
+func ParseProcessFailureSignal(name string) (syscall.Signal, error) {
+	if name == "" {
+		name = DefaultProcessFailureSignal
+	}
+
+	signal, ok := ProcessFailureSignals[name]
+	if !ok {
+		names := make([]string, 0, len(ProcessFailureSignals))
+		for name := range ProcessFailureSignals {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		return 0, fmt.Errorf("unsupported process failure signal %s, expected one of %s", name, strings.Join(names, ", "))
+	}
+
+	return signal, nil
+}
+
+// parseOptionalDuration parses the given duration, returning 0 if empty
+func parseOptionalDuration(duration DisruptionDuration) (time.Duration, error) {
+	if duration == "" {
+		return 0, nil
+	}
+
+	return time.ParseDuration(string(duration))
+}
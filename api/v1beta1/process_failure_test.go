@@ -0,0 +1,58 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package v1beta1_test
+
+import (
+	"syscall"
+
+	. "github.com/DataDog/chaos-controller/api/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ProcessFailureSpec", func() {
+	Describe("Validate", func() {
+		DescribeTable("should succeed with a valid spec",
+			func(spec ProcessFailureSpec) {
+				Expect(spec.Validate()).To(Succeed())
+			},
+			Entry("default signal", ProcessFailureSpec{Pattern: "^worker"}),
+			Entry("custom signal repeated", ProcessFailureSpec{Pattern: "worker", Signal: "SIGKILL", Interval: "1m"}),
+			Entry("pause until cleanup", ProcessFailureSpec{Pattern: "worker", Signal: "SIGSTOP"}),
+			Entry("repeated pause", ProcessFailureSpec{Pattern: "worker", Signal: "SIGSTOP", PauseDuration: "10s", Interval: "1m"}),
+		)
+
+		DescribeTable("should fail with an invalid spec",
+			func(spec ProcessFailureSpec) {
+				Expect(spec.Validate()).ToNot(Succeed())
+			},
+			Entry("missing pattern", ProcessFailureSpec{}),
+			Entry("invalid pattern", ProcessFailureSpec{Pattern: "worker("}),
+			Entry("unknown signal", ProcessFailureSpec{Pattern: "worker", Signal: "SIGSEGV"}),
+			Entry("pause duration without SIGSTOP", ProcessFailureSpec{Pattern: "worker", PauseDuration: "10s"}),
+			Entry("interval too short", ProcessFailureSpec{Pattern: "worker", Interval: "10ms"}),
+			Entry("repeated pause without duration", ProcessFailureSpec{Pattern: "worker", Signal: "SIGSTOP", Interval: "1m"}),
+			Entry("repeated pause longer than interval", ProcessFailureSpec{Pattern: "worker", Signal: "SIGSTOP", PauseDuration: "2m", Interval: "1m"}),
+		)
+	})
+
+	Describe("GenerateArgs", func() {
+		It("should generate all the args", func() {
+			spec := ProcessFailureSpec{Pattern: "^worker", Signal: "SIGSTOP", PauseDuration: "10s", Interval: "1m0s"}
+			Expect(spec.GenerateArgs()).To(Equal([]string{"process-failure", "--pattern", "^worker", "--signal", "SIGSTOP", "--pause-duration", "10s", "--interval", "1m0s"}))
+		})
+	})
+
+	Describe("ParseProcessFailureSignal", func() {
+		It("should default to SIGTERM", func() {
+			Expect(ParseProcessFailureSignal("")).To(Equal(syscall.SIGTERM))
+		})
+
+		It("should parse the signal name", func() {
+			Expect(ParseProcessFailureSignal("SIGUSR1")).To(Equal(syscall.SIGUSR1))
+		})
+	})
+})
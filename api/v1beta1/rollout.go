@@ -0,0 +1,89 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package v1beta1
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// DisruptionRollout widens the targets of a disruption progressively, from the count of its first stage up to the disruption count
+type DisruptionRollout struct {
+	// Stages applied in order before the disruption count is reached, each of them targeting more targets than the previous one
+	// +kubebuilder:validation:MinItems=1
+	// +ddmark:validation:Required=true
+	Stages []DisruptionRolloutStage `json:"stages"`
+}
+
+// DisruptionRolloutStage targets the given count of targets for the given dwell time
+type DisruptionRolloutStage struct {
+	// +kubebuilder:validation:Required
+	// +ddmark:validation:Required=true
+	Count *intstr.IntOrString `json:"count"` // number of targets in either integer form or percent form appended with a %
+	// Time to wait before widening the targets to the next stage, provided no target warning has been observed meanwhile
+	// +kubebuilder:validation:Required
+	// +ddmark:validation:Required=true
+	Dwell DisruptionDuration `json:"dwell"`
+}
+
+// Validate validates the rollout stages
+func (s *DisruptionRollout) Validate(count *intstr.IntOrString) (retErr error) {
+	if len(s.Stages) == 0 {
+		retErr = multierror.Append(retErr, errors.New("rollout: at least one stage must be set"))
+	}
+
+	// counts of the same form must increase, the last one being the disruption count
+	counts := []*intstr.IntOrString{}
+
+	for i, stage := range s.Stages {
+		if err := ValidateCount(stage.Count); err != nil {
+			retErr = multierror.Append(retErr, fmt.Errorf("rollout.stages[%d]: %w", i, err))
+		}
+
+		if stage.Dwell.Duration() <= 0 {
+			retErr = multierror.Append(retErr, fmt.Errorf("rollout.stages[%d]: the dwell time must be greater than 0", i))
+		}
+
+		counts = append(counts, stage.Count)
+	}
+
+	counts = append(counts, count)
+
+	for i := 1; i < len(counts); i++ {
+		previous, previousIsPercent, err := GetIntOrPercentValueSafely(counts[i-1])
+		if err != nil {
+			continue
+		}
+
+		current, currentIsPercent, err := GetIntOrPercentValueSafely(counts[i])
+		if err != nil || previousIsPercent != currentIsPercent {
+			continue
+		}
+
+		if previous >= current {
+			retErr = multierror.Append(retErr, fmt.Errorf("rollout: the stage count %s must be lower than the next stage count %s, the last stage count being lower than the disruption count", counts[i-1].String(), counts[i].String()))
+		}
+	}
+
+	return retErr
+}
+
+// GetCurrentCount returns the count of targets to select, which is the count of the current rollout stage while the disruption is being rolled out
+func (r *Disruption) GetCurrentCount() *intstr.IntOrString {
+	if r.Spec.Rollout != nil && r.Status.RolloutStage < len(r.Spec.Rollout.Stages) {
+		return r.Spec.Rollout.Stages[r.Status.RolloutStage].Count
+	}
+
+	return r.Spec.Count
+}
+
+// IsRollingOut returns true if the disruption has rollout stages left to go through
+func (r *Disruption) IsRollingOut() bool {
+	return r.Spec.Rollout != nil && r.Status.RolloutHaltReason == "" && r.Status.RolloutStage < len(r.Spec.Rollout.Stages)
+}
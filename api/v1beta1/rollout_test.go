@@ -0,0 +1,93 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package v1beta1_test
+
+import (
+	. "github.com/DataDog/chaos-controller/api/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+var _ = Describe("DisruptionRollout", func() {
+	var (
+		rollout DisruptionRollout
+		count   intstr.IntOrString
+	)
+
+	BeforeEach(func() {
+		count = intstr.FromString("100%")
+		one := intstr.FromInt(1)
+		tenPercent := intstr.FromString("10%")
+		rollout = DisruptionRollout{
+			Stages: []DisruptionRolloutStage{
+				{Count: &one, Dwell: "5m"},
+				{Count: &tenPercent, Dwell: "10m"},
+			},
+		}
+	})
+
+	Describe("Validate", func() {
+		It("should succeed with valid stages", func() {
+			Expect(rollout.Validate(&count)).To(Succeed())
+		})
+
+		It("should fail without any stage", func() {
+			rollout.Stages = nil
+			Expect(rollout.Validate(&count)).ShouldNot(Succeed())
+		})
+
+		It("should fail without dwell time", func() {
+			rollout.Stages[0].Dwell = ""
+			Expect(rollout.Validate(&count)).ShouldNot(Succeed())
+		})
+
+		It("should fail with an invalid stage count", func() {
+			zero := intstr.FromInt(0)
+			rollout.Stages[0].Count = &zero
+			Expect(rollout.Validate(&count)).ShouldNot(Succeed())
+		})
+
+		It("should fail with decreasing stage counts", func() {
+			fivePercent := intstr.FromString("5%")
+			rollout.Stages = append(rollout.Stages, DisruptionRolloutStage{Count: &fivePercent, Dwell: "1m"})
+			Expect(rollout.Validate(&count)).ShouldNot(Succeed())
+		})
+
+		It("should fail with a last stage count not lower than the disruption count", func() {
+			count = intstr.FromString("10%")
+			Expect(rollout.Validate(&count)).ShouldNot(Succeed())
+		})
+	})
+
+	Describe("GetCurrentCount", func() {
+		var disruption Disruption
+
+		BeforeEach(func() {
+			disruption = Disruption{Spec: DisruptionSpec{Count: &count, Rollout: &rollout}}
+		})
+
+		It("should return the count of the current stage while rolling out", func() {
+			Expect(disruption.GetCurrentCount().String()).To(Equal("1"))
+			Expect(disruption.IsRollingOut()).To(BeTrue())
+
+			disruption.Status.RolloutStage = 1
+			Expect(disruption.GetCurrentCount().String()).To(Equal("10%"))
+		})
+
+		It("should return the disruption count once all the stages are over", func() {
+			disruption.Status.RolloutStage = 2
+			Expect(disruption.GetCurrentCount().String()).To(Equal("100%"))
+			Expect(disruption.IsRollingOut()).To(BeFalse())
+		})
+
+		It("should keep the current stage count once halted", func() {
+			disruption.Status.RolloutHaltReason = "a target warning has been observed"
+			Expect(disruption.GetCurrentCount().String()).To(Equal("1"))
+			Expect(disruption.IsRollingOut()).To(BeFalse())
+		})
+	})
+})
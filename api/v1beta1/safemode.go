@@ -13,12 +13,15 @@ type UnsafemodeSpec struct {
 	DisableNeitherHostNorPort  bool    `json:"disableNeitherHostNorPort,omitempty"`
 	DisableSpecificContainDisk bool    `json:"disableSpecificContainDisk,omitempty"`
 	AllowRootDiskFailure       bool    `json:"allowRootDiskFailure,omitempty"`
+	AllowRootDiskFill          bool    `json:"allowRootDiskFill,omitempty"`
+	DisablePodDisruptionBudget bool    `json:"disablePodDisruptionBudget,omitempty"`
 	Config                     *Config `json:"config,omitempty"`
 }
 
 // Config represents any configurable parameters for the safetynets, all of which have defaults
 type Config struct {
-	CountTooLarge *CountTooLargeConfig `json:"countTooLarge,omitempty"`
+	CountTooLarge       *CountTooLargeConfig       `json:"countTooLarge,omitempty"`
+	PodDisruptionBudget *PodDisruptionBudgetConfig `json:"podDisruptionBudget,omitempty"`
 }
 
 // CountTooLargeConfig represents the configuration for the countTooLarge safetynet
@@ -35,3 +38,68 @@ type CountTooLargeConfig struct {
 	// +ddmark:validation:Maximum=100
 	ClusterThreshold *int `json:"clusterThreshold,omitempty"`
 }
+
+// PodDisruptionBudgetConfig represents the configuration for the podDisruptionBudget safetynet
+type PodDisruptionBudgetConfig struct {
+	// Action to take when selecting new targets would violate a pod disruption budget:
+	// shrink the targets list to the targets respecting the budgets (default) or refuse all the new targets
+	// +kubebuilder:validation:Enum=shrink;refuse
+	// +ddmark:validation:Enum=shrink;refuse
+	Action string `json:"action,omitempty"`
+	// NetworkDropThreshold is the minimum percentage of dropped packets from which a network disruption is considered as making its targets unavailable
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +ddmark:validation:Minimum=1
+	// +ddmark:validation:Maximum=100
+	NetworkDropThreshold *int `json:"networkDropThreshold,omitempty"`
+}
+
+const (
+	// PodDisruptionBudgetActionShrink removes the targets violating a pod disruption budget from the targets list
+	PodDisruptionBudgetActionShrink = "shrink"
+	// PodDisruptionBudgetActionRefuse refuses all the new targets if any of them violates a pod disruption budget
+	PodDisruptionBudgetActionRefuse = "refuse"
+
+	defaultPodDisruptionBudgetNetworkDropThreshold = 50
+)
+
+// AllowsRootDiskFill returns true if a disk fill disruption is allowed to fill the host root filesystem
+func (s DisruptionSpec) AllowsRootDiskFill() bool {
+	return s.Unsafemode != nil && (s.Unsafemode.DisableAll || s.Unsafemode.AllowRootDiskFill)
+}
+
+// ShouldRespectPodDisruptionBudgets returns true if the disruption makes its targets unavailable
+// (container failure, container freeze, process failure terminating or pausing processes, node failure or heavy network drop)
+// and the podDisruptionBudget safety net is not disabled
+func (s DisruptionSpec) ShouldRespectPodDisruptionBudgets() bool {
+	networkDropThreshold := defaultPodDisruptionBudgetNetworkDropThreshold
+
+	if s.Unsafemode != nil {
+		if s.Unsafemode.DisableAll || s.Unsafemode.DisablePodDisruptionBudget {
+			return false
+		}
+
+		if s.Unsafemode.Config != nil && s.Unsafemode.Config.PodDisruptionBudget != nil && s.Unsafemode.Config.PodDisruptionBudget.NetworkDropThreshold != nil {
+			networkDropThreshold = *s.Unsafemode.Config.PodDisruptionBudget.NetworkDropThreshold
+		}
+	}
+
+	if s.ContainerFailure != nil || s.ContainerFreeze != nil || s.NodeFailure != nil {
+		return true
+	}
+
+	if s.ProcessFailure != nil && s.ProcessFailure.MakesTargetsUnavailable() {
+		return true
+	}
+
+	return s.Network != nil && s.Network.Drop >= networkDropThreshold
+}
+
+// PodDisruptionBudgetAction returns the action to take when new targets violate a pod disruption budget
+func (s DisruptionSpec) PodDisruptionBudgetAction() string {
+	if s.Unsafemode != nil && s.Unsafemode.Config != nil && s.Unsafemode.Config.PodDisruptionBudget != nil && s.Unsafemode.Config.PodDisruptionBudget.Action != "" {
+		return s.Unsafemode.Config.PodDisruptionBudget.Action
+	}
+
+	return PodDisruptionBudgetActionShrink
+}
@@ -0,0 +1,57 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package v1beta1_test
+
+import (
+	. "github.com/DataDog/chaos-controller/api/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Pod disruption budget safety net", func() {
+	When("Call the 'ShouldRespectPodDisruptionBudgets' method", func() {
+		threshold := 10
+
+		DescribeTable("should return the expected value",
+			func(spec DisruptionSpec, expected bool) {
+				Expect(spec.ShouldRespectPodDisruptionBudgets()).Should(Equal(expected))
+			},
+			Entry("with a container failure", DisruptionSpec{ContainerFailure: &ContainerFailureSpec{}}, true),
+			Entry("with a node failure", DisruptionSpec{NodeFailure: &NodeFailureSpec{}}, true),
+			Entry("with a heavy network drop", DisruptionSpec{Network: &NetworkDisruptionSpec{Drop: 100}}, true),
+			Entry("with a light network drop", DisruptionSpec{Network: &NetworkDisruptionSpec{Drop: 20}}, false),
+			Entry("with a light network drop above the configured threshold", DisruptionSpec{
+				Network:    &NetworkDisruptionSpec{Drop: 20},
+				Unsafemode: &UnsafemodeSpec{Config: &Config{PodDisruptionBudget: &PodDisruptionBudgetConfig{NetworkDropThreshold: &threshold}}},
+			}, true),
+			Entry("with a process failure using the default signal", DisruptionSpec{ProcessFailure: &ProcessFailureSpec{Pattern: "foo"}}, true),
+			Entry("with a process failure killing processes", DisruptionSpec{ProcessFailure: &ProcessFailureSpec{Pattern: "foo", Signal: "SIGKILL"}}, true),
+			Entry("with a process failure pausing processes", DisruptionSpec{ProcessFailure: &ProcessFailureSpec{Pattern: "foo", Signal: "SIGSTOP"}}, true),
+			Entry("with a process failure sending SIGHUP", DisruptionSpec{ProcessFailure: &ProcessFailureSpec{Pattern: "foo", Signal: "SIGHUP"}}, false),
+			Entry("with a process failure sending SIGUSR1", DisruptionSpec{ProcessFailure: &ProcessFailureSpec{Pattern: "foo", Signal: "SIGUSR1"}}, false),
+			Entry("with a cpu pressure", DisruptionSpec{CPUPressure: &CPUPressureSpec{}}, false),
+			Entry("with the safety net disabled", DisruptionSpec{
+				ContainerFailure: &ContainerFailureSpec{},
+				Unsafemode:       &UnsafemodeSpec{DisablePodDisruptionBudget: true},
+			}, false),
+			Entry("with all safety nets disabled", DisruptionSpec{
+				NodeFailure: &NodeFailureSpec{},
+				Unsafemode:  &UnsafemodeSpec{DisableAll: true},
+			}, false),
+		)
+	})
+
+	When("Call the 'PodDisruptionBudgetAction' method", func() {
+		It("should default to shrink", func() {
+			Expect(DisruptionSpec{}.PodDisruptionBudgetAction()).Should(Equal(PodDisruptionBudgetActionShrink))
+		})
+
+		It("should return the configured action", func() {
+			spec := DisruptionSpec{Unsafemode: &UnsafemodeSpec{Config: &Config{PodDisruptionBudget: &PodDisruptionBudgetConfig{Action: PodDisruptionBudgetActionRefuse}}}}
+			Expect(spec.PodDisruptionBudgetAction()).Should(Equal(PodDisruptionBudgetActionRefuse))
+		})
+	})
+})
@@ -0,0 +1,253 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package v1beta1
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+
+	chaostypes "github.com/DataDog/chaos-controller/types"
+	"github.com/hashicorp/go-multierror"
+)
+
+// TargetingStrategy defines how targets are picked among the eligible ones
+type TargetingStrategy string
+
+const (
+	// TargetingStrategySpread picks targets evenly across the topology domains
+	TargetingStrategySpread TargetingStrategy = "spread"
+	// TargetingStrategyConcentrate picks all targets in a single topology domain
+	TargetingStrategyConcentrate TargetingStrategy = "concentrate"
+	// TargetingStrategyPerOwner applies the count to each owner (deployment, statefulset...) of the targeted pods
+	TargetingStrategyPerOwner TargetingStrategy = "perOwner"
+
+	// DefaultTopologyKey is the node label used to group targets when no topology key is specified
+	DefaultTopologyKey = "topology.kubernetes.io/zone"
+	// TargetGroupUnknown is the group of targets whose topology domain or owner can't be determined
+	TargetGroupUnknown = "unknown"
+)
+
+// TargetingSpec defines the strategy used to select targets among the eligible ones
+type TargetingSpec struct {
+	// +kubebuilder:validation:Enum=spread;concentrate;perOwner
+	// +ddmark:validation:Enum=spread;concentrate;perOwner
+	// +ddmark:validation:Required=true
+	Strategy TargetingStrategy `json:"strategy"`
+	// TopologyKey is the node label used to group targets by topology domain for the spread and concentrate strategies
+	// it defaults to topology.kubernetes.io/zone
+	TopologyKey string `json:"topologyKey,omitempty"`
+}
+
+// GetTopologyKey returns the topology key to use, falling back to the default one
+func (s *TargetingSpec) GetTopologyKey() string {
+	if s.TopologyKey == "" {
+		return DefaultTopologyKey
+	}
+
+	return s.TopologyKey
+}
+
+// Validate validates args for the given targeting spec
+func (s *TargetingSpec) Validate(level chaostypes.DisruptionLevel) (retErr error) {
+	switch s.Strategy {
+	case TargetingStrategySpread, TargetingStrategyConcentrate:
+	case TargetingStrategyPerOwner:
+		if level == chaostypes.DisruptionLevelNode {
+			retErr = multierror.Append(retErr, errors.New("the perOwner targeting strategy can only be used at the pod level"))
+		}
+
+		if s.TopologyKey != "" {
+			retErr = multierror.Append(retErr, errors.New("the topologyKey field can't be used with the perOwner targeting strategy"))
+		}
+	default:
+		retErr = multierror.Append(retErr, fmt.Errorf("unknown targeting strategy %s, expected one of spread, concentrate or perOwner", s.Strategy))
+	}
+
+	return retErr
+}
+
+// ApplyTargetingStrategy adds or removes targets from the Target List to reach desiredCount following the given strategy
+// - eligibleTargets should be previously filtered to not include current targets and must have their group set
+// - desiredCountPerGroup is only used by the perOwner strategy and contains the desired targets count for each owner
+func (status *DisruptionStatus) ApplyTargetingStrategy(targeting TargetingSpec, desiredCount int, desiredCountPerGroup map[string]int, eligibleTargets TargetInjections) {
+	switch targeting.Strategy {
+	case TargetingStrategySpread:
+		status.spreadTargets(desiredCount, eligibleTargets)
+	case TargetingStrategyConcentrate:
+		status.concentrateTargets(desiredCount, eligibleTargets)
+	case TargetingStrategyPerOwner:
+		status.perOwnerTargets(desiredCountPerGroup, eligibleTargets)
+	}
+
+	status.TargetGroups = status.TargetInjections.CountPerGroup()
+}
+
+// spreadTargets adds targets to the least represented groups and removes targets from the most represented ones
+func (status *DisruptionStatus) spreadTargets(desiredCount int, eligibleTargets TargetInjections) {
+	eligibleGroups := eligibleTargets.namesPerGroup()
+
+	for len(status.TargetInjections) < desiredCount && len(eligibleGroups) > 0 {
+		currentCounts := status.TargetInjections.CountPerGroup()
+		group := pickGroup(eligibleGroups, func(group string) int { return -currentCounts[group] })
+		targetName := popRandomTarget(eligibleGroups, group)
+
+		status.TargetInjections[targetName] = eligibleTargets[targetName]
+		delete(eligibleTargets, targetName)
+	}
+
+	for len(status.TargetInjections) > desiredCount {
+		currentGroups := status.TargetInjections.namesPerGroup()
+		group := pickGroup(currentGroups, func(group string) int { return len(currentGroups[group]) })
+
+		delete(status.TargetInjections, popRandomTarget(currentGroups, group))
+	}
+}
+
+// concentrateTargets keeps all targets in the same group, picking the group with the most current targets
+// or, when there is no current target, a random group having enough eligible targets
+func (status *DisruptionStatus) concentrateTargets(desiredCount int, eligibleTargets TargetInjections) {
+	currentGroups := status.TargetInjections.namesPerGroup()
+	eligibleGroups := eligibleTargets.namesPerGroup()
+
+	var group string
+
+	if len(currentGroups) > 0 {
+		group = pickGroup(currentGroups, func(group string) int { return len(currentGroups[group]) })
+	} else if len(eligibleGroups) > 0 {
+		group = pickGroup(eligibleGroups, func(group string) int { return minInt(len(eligibleGroups[group]), desiredCount) })
+	}
+
+	// remove targets living outside of the selected group
+	for currentGroup, targetNames := range currentGroups {
+		if currentGroup == group {
+			continue
+		}
+
+		for _, targetName := range targetNames {
+			delete(status.TargetInjections, targetName)
+		}
+	}
+
+	for len(status.TargetInjections) < desiredCount && len(eligibleGroups[group]) > 0 {
+		targetName := popRandomTarget(eligibleGroups, group)
+
+		status.TargetInjections[targetName] = eligibleTargets[targetName]
+		delete(eligibleTargets, targetName)
+	}
+
+	if len(status.TargetInjections) > desiredCount {
+		status.RemoveTargets(len(status.TargetInjections) - desiredCount)
+	}
+}
+
+// perOwnerTargets adds or removes targets so each group reaches its own desired count
+func (status *DisruptionStatus) perOwnerTargets(desiredCountPerGroup map[string]int, eligibleTargets TargetInjections) {
+	currentGroups := status.TargetInjections.namesPerGroup()
+	eligibleGroups := eligibleTargets.namesPerGroup()
+
+	for group, targetNames := range currentGroups {
+		for i := len(targetNames); i > desiredCountPerGroup[group]; i-- {
+			delete(status.TargetInjections, popRandomTarget(currentGroups, group))
+		}
+	}
+
+	for group, desiredCount := range desiredCountPerGroup {
+		for i := len(currentGroups[group]); i < desiredCount && len(eligibleGroups[group]) > 0; i++ {
+			targetName := popRandomTarget(eligibleGroups, group)
+
+			status.TargetInjections[targetName] = eligibleTargets[targetName]
+			delete(eligibleTargets, targetName)
+		}
+	}
+}
+
+// CountPerGroup returns the number of targets in each group
+func (in TargetInjections) CountPerGroup() map[string]int {
+	counts := map[string]int{}
+
+	for _, injection := range in {
+		counts[injection.GetGroup()]++
+	}
+
+	return counts
+}
+
+// namesPerGroup returns the sorted target names of each group
+func (in TargetInjections) namesPerGroup() map[string][]string {
+	groups := map[string][]string{}
+
+	for targetName, injection := range in {
+		groups[injection.GetGroup()] = append(groups[injection.GetGroup()], targetName)
+	}
+
+	for _, targetNames := range groups {
+		sort.Strings(targetNames)
+	}
+
+	return groups
+}
+
+// GetGroup returns the group of the target injection, or the unknown group if not set
+func (t TargetInjection) GetGroup() string {
+	if t.Group == "" {
+		return TargetGroupUnknown
+	}
+
+	return t.Group
+}
+
+// pickGroup returns the group with the highest score, picking randomly between groups having the same score
+func pickGroup(groups map[string][]string, score func(group string) int) string {
+	candidates := []string{}
+	bestScore := 0
+
+	for group := range groups {
+		groupScore := score(group)
+
+		if len(candidates) == 0 || groupScore > bestScore {
+			candidates = []string{group}
+			bestScore = groupScore
+		} else if groupScore == bestScore {
+			candidates = append(candidates, group)
+		}
+	}
+
+	if len(candidates) == 0 {
+		return ""
+	}
+
+	sort.Strings(candidates)
+
+	return candidates[rand.Intn(len(candidates))] //nolint:gosec
+}
+
+// popRandomTarget removes and returns a random target name from the given group, deleting the group once empty
+func popRandomTarget(groups map[string][]string, group string) string {
+	targetNames := groups[group]
+	index := rand.Intn(len(targetNames)) //nolint:gosec
+	targetName := targetNames[index]
+
+	targetNames[len(targetNames)-1], targetNames[index] = targetNames[index], targetNames[len(targetNames)-1]
+	targetNames = targetNames[:len(targetNames)-1]
+
+	if len(targetNames) == 0 {
+		delete(groups, group)
+	} else {
+		groups[group] = targetNames
+	}
+
+	return targetName
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,130 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package v1beta1_test
+
+import (
+	. "github.com/DataDog/chaos-controller/api/v1beta1"
+	chaostypes "github.com/DataDog/chaos-controller/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TargetingSpec", func() {
+	When("Call the 'Validate' method", func() {
+		DescribeTable("with valid specs",
+			func(spec TargetingSpec, level chaostypes.DisruptionLevel) {
+				Expect(spec.Validate(level)).Should(Succeed())
+			},
+			Entry("spread at the pod level", TargetingSpec{Strategy: TargetingStrategySpread}, chaostypes.DisruptionLevelPod),
+			Entry("concentrate at the node level with a topology key", TargetingSpec{Strategy: TargetingStrategyConcentrate, TopologyKey: "kubernetes.io/hostname"}, chaostypes.DisruptionLevelNode),
+			Entry("perOwner at the pod level", TargetingSpec{Strategy: TargetingStrategyPerOwner}, chaostypes.DisruptionLevelPod),
+		)
+
+		DescribeTable("with invalid specs",
+			func(spec TargetingSpec, level chaostypes.DisruptionLevel, expectedErr string) {
+				err := spec.Validate(level)
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).Should(ContainSubstring(expectedErr))
+			},
+			Entry("unknown strategy", TargetingSpec{Strategy: "random"}, chaostypes.DisruptionLevelPod, "unknown targeting strategy random, expected one of spread, concentrate or perOwner"),
+			Entry("perOwner at the node level", TargetingSpec{Strategy: TargetingStrategyPerOwner}, chaostypes.DisruptionLevelNode, "the perOwner targeting strategy can only be used at the pod level"),
+			Entry("perOwner with a topology key", TargetingSpec{Strategy: TargetingStrategyPerOwner, TopologyKey: "foo"}, chaostypes.DisruptionLevelPod, "the topologyKey field can't be used with the perOwner targeting strategy"),
+		)
+
+		It("should report every error of a spec breaking several rules", func() {
+			err := (&TargetingSpec{Strategy: TargetingStrategyPerOwner, TopologyKey: "foo"}).Validate(chaostypes.DisruptionLevelNode)
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("the perOwner targeting strategy can only be used at the pod level"))
+			Expect(err.Error()).Should(ContainSubstring("the topologyKey field can't be used with the perOwner targeting strategy"))
+		})
+	})
+
+	When("Call the 'GetTopologyKey' method", func() {
+		It("should return the default topology key when not set", func() {
+			Expect((&TargetingSpec{}).GetTopologyKey()).Should(Equal(DefaultTopologyKey))
+		})
+
+		It("should return the given topology key", func() {
+			Expect((&TargetingSpec{TopologyKey: "foo"}).GetTopologyKey()).Should(Equal("foo"))
+		})
+	})
+})
+
+var _ = Describe("DisruptionStatus ApplyTargetingStrategy", func() {
+	var (
+		status          DisruptionStatus
+		eligibleTargets TargetInjections
+	)
+
+	BeforeEach(func() {
+		status = DisruptionStatus{TargetInjections: TargetInjections{}}
+		eligibleTargets = TargetInjections{
+			"a-1": {Group: "zone-a"},
+			"a-2": {Group: "zone-a"},
+			"a-3": {Group: "zone-a"},
+			"b-1": {Group: "zone-b"},
+			"b-2": {Group: "zone-b"},
+			"c-1": {Group: "zone-c"},
+		}
+	})
+
+	Context("with the spread strategy", func() {
+		It("should pick targets evenly across groups", func() {
+			status.ApplyTargetingStrategy(TargetingSpec{Strategy: TargetingStrategySpread}, 3, nil, eligibleTargets)
+
+			Expect(status.TargetInjections).Should(HaveLen(3))
+			Expect(status.TargetGroups).Should(Equal(map[string]int{"zone-a": 1, "zone-b": 1, "zone-c": 1}))
+			Expect(eligibleTargets).Should(HaveLen(3))
+		})
+
+		It("should fill the remaining groups once a group is exhausted", func() {
+			status.ApplyTargetingStrategy(TargetingSpec{Strategy: TargetingStrategySpread}, 5, nil, eligibleTargets)
+
+			Expect(status.TargetGroups).Should(Equal(map[string]int{"zone-a": 2, "zone-b": 2, "zone-c": 1}))
+		})
+
+		It("should remove targets from the most represented groups", func() {
+			status.TargetInjections = TargetInjections{
+				"a-1": {Group: "zone-a"},
+				"a-2": {Group: "zone-a"},
+				"b-1": {Group: "zone-b"},
+			}
+
+			status.ApplyTargetingStrategy(TargetingSpec{Strategy: TargetingStrategySpread}, 2, nil, TargetInjections{})
+
+			Expect(status.TargetGroups).Should(Equal(map[string]int{"zone-a": 1, "zone-b": 1}))
+		})
+	})
+
+	Context("with the concentrate strategy", func() {
+		It("should pick all targets in a single group having enough targets", func() {
+			status.ApplyTargetingStrategy(TargetingSpec{Strategy: TargetingStrategyConcentrate}, 3, nil, eligibleTargets)
+
+			Expect(status.TargetGroups).Should(Equal(map[string]int{"zone-a": 3}))
+		})
+
+		It("should keep the group of the current targets", func() {
+			status.TargetInjections = TargetInjections{"b-1": {Group: "zone-b"}}
+			delete(eligibleTargets, "b-1")
+
+			status.ApplyTargetingStrategy(TargetingSpec{Strategy: TargetingStrategyConcentrate}, 3, nil, eligibleTargets)
+
+			Expect(status.TargetGroups).Should(Equal(map[string]int{"zone-b": 2}))
+		})
+	})
+
+	Context("with the perOwner strategy", func() {
+		It("should reach the desired count of each group", func() {
+			status.TargetInjections = TargetInjections{"a-1": {Group: "zone-a"}, "a-2": {Group: "zone-a"}}
+			delete(eligibleTargets, "a-1")
+			delete(eligibleTargets, "a-2")
+
+			status.ApplyTargetingStrategy(TargetingSpec{Strategy: TargetingStrategyPerOwner}, 3, map[string]int{"zone-a": 1, "zone-b": 1, "zone-c": 1}, eligibleTargets)
+
+			Expect(status.TargetGroups).Should(Equal(map[string]int{"zone-a": 1, "zone-b": 1, "zone-c": 1}))
+		})
+	})
+})
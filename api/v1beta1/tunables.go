@@ -0,0 +1,137 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	chaostypes "github.com/DataDog/chaos-controller/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// TunableFieldsDescription lists the fields which can be updated on a running disruption
+const TunableFieldsDescription = "network drop, duplicate, corrupt, delay, delayJitter and bandwidthLimit, cpuPressure count, targetUtilization and throttling percentage, diskPressure throttling and grpc endpoints queryPercent"
+
+// networkDisruptionTunables are the network disruption fields which can be updated on a running disruption
+type networkDisruptionTunables struct {
+	Drop           int  `json:"drop,omitempty"`
+	Duplicate      int  `json:"duplicate,omitempty"`
+	Corrupt        int  `json:"corrupt,omitempty"`
+	Delay          uint `json:"delay,omitempty"`
+	DelayJitter    uint `json:"delayJitter,omitempty"`
+	BandwidthLimit int  `json:"bandwidthLimit,omitempty"`
+}
+
+// cpuPressureTunables are the cpu pressure fields which can be updated on a running disruption
+type cpuPressureTunables struct {
+	Count                *intstr.IntOrString `json:"count,omitempty"`
+	TargetUtilization    *int                `json:"targetUtilization,omitempty"`
+	ThrottlingPercentage int                 `json:"throttlingPercentage,omitempty"`
+}
+
+// grpcDisruptionTunables are the grpc disruption fields which can be updated on a running disruption
+type grpcDisruptionTunables struct {
+	QueryPercents []int `json:"queryPercents,omitempty"`
+}
+
+// Tunables returns the serialized values of the fields of the given disruption kind which can be updated on a running disruption,
+// so they can be compared with the ones the chaos pods of the kind were created with
+// it is empty for the kinds without any tunable field
+func (s DisruptionSpec) Tunables(kind chaostypes.DisruptionKindName) (string, error) {
+	tunables := s.DeepCopy().popTunables(kind)
+	if tunables == nil {
+		return "", nil
+	}
+
+	tunablesBytes, err := json.Marshal(tunables)
+	if err != nil {
+		return "", fmt.Errorf("error serializing %s tunable fields: %w", kind, err)
+	}
+
+	return string(tunablesBytes), nil
+}
+
+// WithoutTunables returns a copy of the spec without the fields which can be updated on a running disruption,
+// the paused field included
+func (s DisruptionSpec) WithoutTunables() DisruptionSpec {
+	spec := s.DeepCopy()
+	spec.Paused = false
+
+	for _, kind := range chaostypes.DisruptionKindNames {
+		spec.popTunables(kind)
+	}
+
+	return *spec
+}
+
+// popTunables resets the tunable fields of the given disruption kind and returns their values, nil if the kind has none
+func (s *DisruptionSpec) popTunables(kind chaostypes.DisruptionKindName) interface{} {
+	switch kind {
+	case chaostypes.DisruptionKindNetworkDisruption:
+		if s.Network == nil {
+			return nil
+		}
+
+		tunables := networkDisruptionTunables{
+			Drop:           s.Network.Drop,
+			Duplicate:      s.Network.Duplicate,
+			Corrupt:        s.Network.Corrupt,
+			Delay:          s.Network.Delay,
+			DelayJitter:    s.Network.DelayJitter,
+			BandwidthLimit: s.Network.BandwidthLimit,
+		}
+
+		s.Network.Drop, s.Network.Duplicate, s.Network.Corrupt = 0, 0, 0
+		s.Network.Delay, s.Network.DelayJitter = 0, 0
+		s.Network.BandwidthLimit = 0
+
+		return tunables
+	case chaostypes.DisruptionKindCPUPressure:
+		if s.CPUPressure == nil {
+			return nil
+		}
+
+		tunables := cpuPressureTunables{
+			Count:             s.CPUPressure.Count,
+			TargetUtilization: s.CPUPressure.TargetUtilization,
+		}
+
+		s.CPUPressure.Count, s.CPUPressure.TargetUtilization = nil, nil
+
+		// the throttling can be tuned but not enabled nor disabled on a running disruption
+		if s.CPUPressure.Throttling != nil {
+			tunables.ThrottlingPercentage = s.CPUPressure.Throttling.Percentage
+			s.CPUPressure.Throttling.Percentage = 0
+		}
+
+		return tunables
+	case chaostypes.DisruptionKindDiskPressure:
+		if s.DiskPressure == nil {
+			return nil
+		}
+
+		tunables := s.DiskPressure.Throttling
+		s.DiskPressure.Throttling = DiskPressureThrottlingSpec{}
+
+		return tunables
+	case chaostypes.DisruptionKindGRPCDisruption:
+		if s.GRPC == nil {
+			return nil
+		}
+
+		tunables := grpcDisruptionTunables{}
+
+		for i := range s.GRPC.Endpoints {
+			tunables.QueryPercents = append(tunables.QueryPercents, s.GRPC.Endpoints[i].QueryPercent)
+			s.GRPC.Endpoints[i].QueryPercent = 0
+		}
+
+		return tunables
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package v1beta1_test
+
+import (
+	. "github.com/DataDog/chaos-controller/api/v1beta1"
+	chaostypes "github.com/DataDog/chaos-controller/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+var _ = Describe("DisruptionSpec tunables", func() {
+	var spec DisruptionSpec
+
+	BeforeEach(func() {
+		spec = DisruptionSpec{
+			Network: &NetworkDisruptionSpec{
+				Hosts: []NetworkDisruptionHostSpec{{Host: "10.0.0.1"}},
+				Drop:  10,
+				Delay: 50,
+			},
+			CPUPressure: &CPUPressureSpec{
+				Count: &intstr.IntOrString{Type: intstr.String, StrVal: "50%"},
+			},
+			GRPC: &GRPCDisruptionSpec{
+				Port:      50051,
+				Endpoints: []EndpointAlteration{{TargetEndpoint: "/svc/Method", ErrorToReturn: "NOT_FOUND", QueryPercent: 25}},
+			},
+			ContainerFailure: &ContainerFailureSpec{},
+		}
+	})
+
+	Describe("Tunables", func() {
+		It("should serialize the tunable fields of the kind", func() {
+			Expect(spec.Tunables(chaostypes.DisruptionKindNetworkDisruption)).To(Equal(`{"drop":10,"delay":50}`))
+			Expect(spec.Tunables(chaostypes.DisruptionKindCPUPressure)).To(Equal(`{"count":"50%"}`))
+			Expect(spec.Tunables(chaostypes.DisruptionKindGRPCDisruption)).To(Equal(`{"queryPercents":[25]}`))
+		})
+
+		It("should be empty for the kinds without tunable fields", func() {
+			Expect(spec.Tunables(chaostypes.DisruptionKindContainerFailure)).To(BeEmpty())
+			Expect(spec.Tunables(chaostypes.DisruptionKindDiskPressure)).To(BeEmpty())
+		})
+
+		It("should not alter the spec", func() {
+			_, err := spec.Tunables(chaostypes.DisruptionKindNetworkDisruption)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(spec.Network.Drop).To(Equal(10))
+		})
+	})
+
+	Describe("WithoutTunables", func() {
+		It("should ignore the tunable fields only", func() {
+			hash, err := spec.WithoutTunables().Hash()
+			Expect(err).ToNot(HaveOccurred())
+
+			spec.Network.Delay = 500
+			spec.CPUPressure.Count = &intstr.IntOrString{IntVal: 2}
+			spec.GRPC.Endpoints[0].QueryPercent = 100
+			Expect(spec.WithoutTunables().Hash()).To(Equal(hash))
+
+			spec.Network.Hosts[0].Port = 80
+			Expect(spec.WithoutTunables().Hash()).ToNot(Equal(hash))
+		})
+
+		It("should ignore the paused field", func() {
+			hash, err := spec.WithoutTunables().Hash()
+			Expect(err).ToNot(HaveOccurred())
+
+			spec.Paused = true
+			Expect(spec.WithoutTunables().Hash()).To(Equal(hash))
+
+			spec.ExcludePausedTime = true
+			Expect(spec.WithoutTunables().Hash()).ToNot(Equal(hash))
+		})
+
+		It("should not allow to enable the cpu throttling", func() {
+			hash, err := spec.WithoutTunables().Hash()
+			Expect(err).ToNot(HaveOccurred())
+
+			spec.CPUPressure.Throttling = &CPUPressureThrottlingSpec{Percentage: 50}
+			Expect(spec.WithoutTunables().Hash()).ToNot(Equal(hash))
+		})
+	})
+})
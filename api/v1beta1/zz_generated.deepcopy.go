@@ -21,12 +21,44 @@ limitations under the License.
 package v1beta1
 
 import (
-	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"github.com/DataDog/chaos-controller/types"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AbortProbe) DeepCopyInto(out *AbortProbe) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AbortProbe.
+func (in *AbortProbe) DeepCopy() *AbortProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(AbortProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CPUPressureProfileSpec) DeepCopyInto(out *CPUPressureProfileSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CPUPressureProfileSpec.
+func (in *CPUPressureProfileSpec) DeepCopy() *CPUPressureProfileSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CPUPressureProfileSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CPUPressureSpec) DeepCopyInto(out *CPUPressureSpec) {
 	*out = *in
@@ -35,6 +67,21 @@ func (in *CPUPressureSpec) DeepCopyInto(out *CPUPressureSpec) {
 		*out = new(intstr.IntOrString)
 		**out = **in
 	}
+	if in.TargetUtilization != nil {
+		in, out := &in.TargetUtilization, &out.TargetUtilization
+		*out = new(int)
+		**out = **in
+	}
+	if in.Profile != nil {
+		in, out := &in.Profile, &out.Profile
+		*out = new(CPUPressureProfileSpec)
+		**out = **in
+	}
+	if in.Throttling != nil {
+		in, out := &in.Throttling, &out.Throttling
+		*out = new(CPUPressureThrottlingSpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CPUPressureSpec.
@@ -47,6 +94,280 @@ func (in *CPUPressureSpec) DeepCopy() *CPUPressureSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CPUPressureThrottlingSpec) DeepCopyInto(out *CPUPressureThrottlingSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CPUPressureThrottlingSpec.
+func (in *CPUPressureThrottlingSpec) DeepCopy() *CPUPressureThrottlingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CPUPressureThrottlingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChaosWorkflow) DeepCopyInto(out *ChaosWorkflow) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChaosWorkflow.
+func (in *ChaosWorkflow) DeepCopy() *ChaosWorkflow {
+	if in == nil {
+		return nil
+	}
+	out := new(ChaosWorkflow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ChaosWorkflow) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChaosWorkflowDisruption) DeepCopyInto(out *ChaosWorkflowDisruption) {
+	*out = *in
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChaosWorkflowDisruption.
+func (in *ChaosWorkflowDisruption) DeepCopy() *ChaosWorkflowDisruption {
+	if in == nil {
+		return nil
+	}
+	out := new(ChaosWorkflowDisruption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChaosWorkflowDisruptionStatus) DeepCopyInto(out *ChaosWorkflowDisruptionStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChaosWorkflowDisruptionStatus.
+func (in *ChaosWorkflowDisruptionStatus) DeepCopy() *ChaosWorkflowDisruptionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ChaosWorkflowDisruptionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChaosWorkflowList) DeepCopyInto(out *ChaosWorkflowList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ChaosWorkflow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChaosWorkflowList.
+func (in *ChaosWorkflowList) DeepCopy() *ChaosWorkflowList {
+	if in == nil {
+		return nil
+	}
+	out := new(ChaosWorkflowList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ChaosWorkflowList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChaosWorkflowSpec) DeepCopyInto(out *ChaosWorkflowSpec) {
+	*out = *in
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]ChaosWorkflowStep, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChaosWorkflowSpec.
+func (in *ChaosWorkflowSpec) DeepCopy() *ChaosWorkflowSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ChaosWorkflowSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChaosWorkflowStatus) DeepCopyInto(out *ChaosWorkflowStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]ChaosWorkflowStepStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChaosWorkflowStatus.
+func (in *ChaosWorkflowStatus) DeepCopy() *ChaosWorkflowStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ChaosWorkflowStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChaosWorkflowStep) DeepCopyInto(out *ChaosWorkflowStep) {
+	*out = *in
+	if in.Disruptions != nil {
+		in, out := &in.Disruptions, &out.Disruptions
+		*out = make([]ChaosWorkflowDisruption, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.HealthProbes != nil {
+		in, out := &in.HealthProbes, &out.HealthProbes
+		*out = make([]AbortProbe, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChaosWorkflowStep.
+func (in *ChaosWorkflowStep) DeepCopy() *ChaosWorkflowStep {
+	if in == nil {
+		return nil
+	}
+	out := new(ChaosWorkflowStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChaosWorkflowStepStatus) DeepCopyInto(out *ChaosWorkflowStepStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ConditionsMetTime != nil {
+		in, out := &in.ConditionsMetTime, &out.ConditionsMetTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Disruptions != nil {
+		in, out := &in.Disruptions, &out.Disruptions
+		*out = make([]ChaosWorkflowDisruptionStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChaosWorkflowStepStatus.
+func (in *ChaosWorkflowStepStatus) DeepCopy() *ChaosWorkflowStepStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ChaosWorkflowStepStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDisruptionTemplate) DeepCopyInto(out *ClusterDisruptionTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDisruptionTemplate.
+func (in *ClusterDisruptionTemplate) DeepCopy() *ClusterDisruptionTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDisruptionTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterDisruptionTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDisruptionTemplateList) DeepCopyInto(out *ClusterDisruptionTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterDisruptionTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDisruptionTemplateList.
+func (in *ClusterDisruptionTemplateList) DeepCopy() *ClusterDisruptionTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDisruptionTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterDisruptionTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Config) DeepCopyInto(out *Config) {
 	*out = *in
@@ -55,6 +376,11 @@ func (in *Config) DeepCopyInto(out *Config) {
 		*out = new(CountTooLargeConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(PodDisruptionBudgetConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Config.
@@ -82,6 +408,21 @@ func (in *ContainerFailureSpec) DeepCopy() *ContainerFailureSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerFreezeSpec) DeepCopyInto(out *ContainerFreezeSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerFreezeSpec.
+func (in *ContainerFreezeSpec) DeepCopy() *ContainerFreezeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerFreezeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CountTooLargeConfig) DeepCopyInto(out *CountTooLargeConfig) {
 	*out = *in
@@ -141,9 +482,29 @@ func (in *DNSRecord) DeepCopy() *DNSRecord {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskFailurePathSpec) DeepCopyInto(out *DiskFailurePathSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskFailurePathSpec.
+func (in *DiskFailurePathSpec) DeepCopy() *DiskFailurePathSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskFailurePathSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DiskFailureSpec) DeepCopyInto(out *DiskFailureSpec) {
 	*out = *in
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]DiskFailurePathSpec, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskFailureSpec.
@@ -156,6 +517,31 @@ func (in *DiskFailureSpec) DeepCopy() *DiskFailureSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskFillSpec) DeepCopyInto(out *DiskFillSpec) {
+	*out = *in
+	if in.Percentage != nil {
+		in, out := &in.Percentage, &out.Percentage
+		*out = new(int)
+		**out = **in
+	}
+	if in.RemainingBytes != nil {
+		in, out := &in.RemainingBytes, &out.RemainingBytes
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskFillSpec.
+func (in *DiskFillSpec) DeepCopy() *DiskFillSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskFillSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DiskPressureSpec) DeepCopyInto(out *DiskPressureSpec) {
 	*out = *in
@@ -185,6 +571,16 @@ func (in *DiskPressureThrottlingSpec) DeepCopyInto(out *DiskPressureThrottlingSp
 		*out = new(int)
 		**out = **in
 	}
+	if in.ReadIOPS != nil {
+		in, out := &in.ReadIOPS, &out.ReadIOPS
+		*out = new(int)
+		**out = **in
+	}
+	if in.WriteIOPS != nil {
+		in, out := &in.WriteIOPS, &out.WriteIOPS
+		*out = new(int)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskPressureThrottlingSpec.
@@ -224,6 +620,140 @@ func (in *Disruption) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DisruptionAbortConditions) DeepCopyInto(out *DisruptionAbortConditions) {
+	*out = *in
+	if in.NotReadyTargets != nil {
+		in, out := &in.NotReadyTargets, &out.NotReadyTargets
+		*out = new(NotReadyTargetsAbortCondition)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Restarts != nil {
+		in, out := &in.Restarts, &out.Restarts
+		*out = new(RestartsAbortCondition)
+		**out = **in
+	}
+	if in.Probes != nil {
+		in, out := &in.Probes, &out.Probes
+		*out = make([]AbortProbe, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisruptionAbortConditions.
+func (in *DisruptionAbortConditions) DeepCopy() *DisruptionAbortConditions {
+	if in == nil {
+		return nil
+	}
+	out := new(DisruptionAbortConditions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DisruptionCron) DeepCopyInto(out *DisruptionCron) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisruptionCron.
+func (in *DisruptionCron) DeepCopy() *DisruptionCron {
+	if in == nil {
+		return nil
+	}
+	out := new(DisruptionCron)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DisruptionCron) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DisruptionCronList) DeepCopyInto(out *DisruptionCronList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DisruptionCron, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisruptionCronList.
+func (in *DisruptionCronList) DeepCopy() *DisruptionCronList {
+	if in == nil {
+		return nil
+	}
+	out := new(DisruptionCronList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DisruptionCronList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DisruptionCronSpec) DeepCopyInto(out *DisruptionCronSpec) {
+	*out = *in
+	if in.HistoryLimit != nil {
+		in, out := &in.HistoryLimit, &out.HistoryLimit
+		*out = new(int)
+		**out = **in
+	}
+	in.DisruptionTemplate.DeepCopyInto(&out.DisruptionTemplate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisruptionCronSpec.
+func (in *DisruptionCronSpec) DeepCopy() *DisruptionCronSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DisruptionCronSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DisruptionCronStatus) DeepCopyInto(out *DisruptionCronStatus) {
+	*out = *in
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Active != nil {
+		in, out := &in.Active, &out.Active
+		*out = make([]v1.ObjectReference, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisruptionCronStatus.
+func (in *DisruptionCronStatus) DeepCopy() *DisruptionCronStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DisruptionCronStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DisruptionEvent) DeepCopyInto(out *DisruptionEvent) {
 	*out = *in
@@ -294,16 +824,63 @@ func (in *DisruptionList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DisruptionPulse) DeepCopyInto(out *DisruptionPulse) {
+func (in *DisruptionPulse) DeepCopyInto(out *DisruptionPulse) {
+	*out = *in
+	if in.Seed != nil {
+		in, out := &in.Seed, &out.Seed
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisruptionPulse.
+func (in *DisruptionPulse) DeepCopy() *DisruptionPulse {
+	if in == nil {
+		return nil
+	}
+	out := new(DisruptionPulse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DisruptionRollout) DeepCopyInto(out *DisruptionRollout) {
+	*out = *in
+	if in.Stages != nil {
+		in, out := &in.Stages, &out.Stages
+		*out = make([]DisruptionRolloutStage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisruptionRollout.
+func (in *DisruptionRollout) DeepCopy() *DisruptionRollout {
+	if in == nil {
+		return nil
+	}
+	out := new(DisruptionRollout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DisruptionRolloutStage) DeepCopyInto(out *DisruptionRolloutStage) {
 	*out = *in
+	if in.Count != nil {
+		in, out := &in.Count, &out.Count
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisruptionPulse.
-func (in *DisruptionPulse) DeepCopy() *DisruptionPulse {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisruptionRolloutStage.
+func (in *DisruptionRolloutStage) DeepCopy() *DisruptionRolloutStage {
 	if in == nil {
 		return nil
 	}
-	out := new(DisruptionPulse)
+	out := new(DisruptionRolloutStage)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -325,7 +902,7 @@ func (in *DisruptionSpec) DeepCopyInto(out *DisruptionSpec) {
 	}
 	if in.AdvancedSelector != nil {
 		in, out := &in.AdvancedSelector, &out.AdvancedSelector
-		*out = make([]v1.LabelSelectorRequirement, len(*in))
+		*out = make([]metav1.LabelSelectorRequirement, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -340,11 +917,26 @@ func (in *DisruptionSpec) DeepCopyInto(out *DisruptionSpec) {
 		*out = new(UnsafemodeSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Targeting != nil {
+		in, out := &in.Targeting, &out.Targeting
+		*out = new(TargetingSpec)
+		**out = **in
+	}
 	in.Triggers.DeepCopyInto(&out.Triggers)
 	if in.Pulse != nil {
 		in, out := &in.Pulse, &out.Pulse
 		*out = new(DisruptionPulse)
-		**out = **in
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AbortConditions != nil {
+		in, out := &in.AbortConditions, &out.AbortConditions
+		*out = new(DisruptionAbortConditions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(DisruptionRollout)
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Containers != nil {
 		in, out := &in.Containers, &out.Containers
@@ -359,13 +951,23 @@ func (in *DisruptionSpec) DeepCopyInto(out *DisruptionSpec) {
 	if in.NodeFailure != nil {
 		in, out := &in.NodeFailure, &out.NodeFailure
 		*out = new(NodeFailureSpec)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.ContainerFailure != nil {
 		in, out := &in.ContainerFailure, &out.ContainerFailure
 		*out = new(ContainerFailureSpec)
 		**out = **in
 	}
+	if in.ProcessFailure != nil {
+		in, out := &in.ProcessFailure, &out.ProcessFailure
+		*out = new(ProcessFailureSpec)
+		**out = **in
+	}
+	if in.ContainerFreeze != nil {
+		in, out := &in.ContainerFreeze, &out.ContainerFreeze
+		*out = new(ContainerFreezeSpec)
+		**out = **in
+	}
 	if in.CPUPressure != nil {
 		in, out := &in.CPUPressure, &out.CPUPressure
 		*out = new(CPUPressureSpec)
@@ -379,7 +981,12 @@ func (in *DisruptionSpec) DeepCopyInto(out *DisruptionSpec) {
 	if in.DiskFailure != nil {
 		in, out := &in.DiskFailure, &out.DiskFailure
 		*out = new(DiskFailureSpec)
-		**out = **in
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DiskFill != nil {
+		in, out := &in.DiskFill, &out.DiskFill
+		*out = new(DiskFillSpec)
+		(*in).DeepCopyInto(*out)
 	}
 	if in.DNS != nil {
 		in, out := &in.DNS, &out.DNS
@@ -396,6 +1003,11 @@ func (in *DisruptionSpec) DeepCopyInto(out *DisruptionSpec) {
 		*out = new(Reporting)
 		**out = **in
 	}
+	if in.TemplateRef != nil {
+		in, out := &in.TemplateRef, &out.TemplateRef
+		*out = new(DisruptionTemplateRef)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisruptionSpec.
@@ -418,6 +1030,21 @@ func (in *DisruptionStatus) DeepCopyInto(out *DisruptionStatus) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.TargetGroups != nil {
+		in, out := &in.TargetGroups, &out.TargetGroups
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PausedSince != nil {
+		in, out := &in.PausedSince, &out.PausedSince
+		*out = (*in).DeepCopy()
+	}
+	if in.RolloutStageSince != nil {
+		in, out := &in.RolloutStageSince, &out.RolloutStageSince
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisruptionStatus.
@@ -430,6 +1057,129 @@ func (in *DisruptionStatus) DeepCopy() *DisruptionStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DisruptionTemplate) DeepCopyInto(out *DisruptionTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisruptionTemplate.
+func (in *DisruptionTemplate) DeepCopy() *DisruptionTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(DisruptionTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DisruptionTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DisruptionTemplateList) DeepCopyInto(out *DisruptionTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DisruptionTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisruptionTemplateList.
+func (in *DisruptionTemplateList) DeepCopy() *DisruptionTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(DisruptionTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DisruptionTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DisruptionTemplateParameter) DeepCopyInto(out *DisruptionTemplateParameter) {
+	*out = *in
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisruptionTemplateParameter.
+func (in *DisruptionTemplateParameter) DeepCopy() *DisruptionTemplateParameter {
+	if in == nil {
+		return nil
+	}
+	out := new(DisruptionTemplateParameter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DisruptionTemplateRef) DeepCopyInto(out *DisruptionTemplateRef) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisruptionTemplateRef.
+func (in *DisruptionTemplateRef) DeepCopy() *DisruptionTemplateRef {
+	if in == nil {
+		return nil
+	}
+	out := new(DisruptionTemplateRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DisruptionTemplateSpec) DeepCopyInto(out *DisruptionTemplateSpec) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make([]DisruptionTemplateParameter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Disruption.DeepCopyInto(&out.Disruption)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisruptionTemplateSpec.
+func (in *DisruptionTemplateSpec) DeepCopy() *DisruptionTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DisruptionTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DisruptionTrigger) DeepCopyInto(out *DisruptionTrigger) {
 	*out = *in
@@ -514,6 +1264,44 @@ func (in *HostRecordPair) DeepCopy() *HostRecordPair {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InjectionResult) DeepCopyInto(out *InjectionResult) {
+	*out = *in
+	if in.InjectedAt != nil {
+		in, out := &in.InjectedAt, &out.InjectedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.CleanedAt != nil {
+		in, out := &in.CleanedAt, &out.CleanedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.ResolvedHosts != nil {
+		in, out := &in.ResolvedHosts, &out.ResolvedHosts
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InjectionResult.
+func (in *InjectionResult) DeepCopy() *InjectionResult {
+	if in == nil {
+		return nil
+	}
+	out := new(InjectionResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkDisruptionCloudServiceSpec) DeepCopyInto(out *NetworkDisruptionCloudServiceSpec) {
 	*out = *in
@@ -663,9 +1451,79 @@ func (in *NetworkDisruptionSpec) DeepCopy() *NetworkDisruptionSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeFailureDrainSpec) DeepCopyInto(out *NodeFailureDrainSpec) {
+	*out = *in
+	if in.GracePeriodSeconds != nil {
+		in, out := &in.GracePeriodSeconds, &out.GracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeFailureDrainSpec.
+func (in *NodeFailureDrainSpec) DeepCopy() *NodeFailureDrainSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeFailureDrainSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeFailureKubeletSpec) DeepCopyInto(out *NodeFailureKubeletSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeFailureKubeletSpec.
+func (in *NodeFailureKubeletSpec) DeepCopy() *NodeFailureKubeletSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeFailureKubeletSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeFailureNetworkIsolationSpec) DeepCopyInto(out *NodeFailureNetworkIsolationSpec) {
+	*out = *in
+	if in.AllowedHosts != nil {
+		in, out := &in.AllowedHosts, &out.AllowedHosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeFailureNetworkIsolationSpec.
+func (in *NodeFailureNetworkIsolationSpec) DeepCopy() *NodeFailureNetworkIsolationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeFailureNetworkIsolationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeFailureSpec) DeepCopyInto(out *NodeFailureSpec) {
 	*out = *in
+	if in.Kubelet != nil {
+		in, out := &in.Kubelet, &out.Kubelet
+		*out = new(NodeFailureKubeletSpec)
+		**out = **in
+	}
+	if in.NetworkIsolation != nil {
+		in, out := &in.NetworkIsolation, &out.NetworkIsolation
+		*out = new(NodeFailureNetworkIsolationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Drain != nil {
+		in, out := &in.Drain, &out.Drain
+		*out = new(NodeFailureDrainSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeFailureSpec.
@@ -678,6 +1536,61 @@ func (in *NodeFailureSpec) DeepCopy() *NodeFailureSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotReadyTargetsAbortCondition) DeepCopyInto(out *NotReadyTargetsAbortCondition) {
+	*out = *in
+	if in.Count != nil {
+		in, out := &in.Count, &out.Count
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotReadyTargetsAbortCondition.
+func (in *NotReadyTargetsAbortCondition) DeepCopy() *NotReadyTargetsAbortCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(NotReadyTargetsAbortCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodDisruptionBudgetConfig) DeepCopyInto(out *PodDisruptionBudgetConfig) {
+	*out = *in
+	if in.NetworkDropThreshold != nil {
+		in, out := &in.NetworkDropThreshold, &out.NetworkDropThreshold
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodDisruptionBudgetConfig.
+func (in *PodDisruptionBudgetConfig) DeepCopy() *PodDisruptionBudgetConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PodDisruptionBudgetConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProcessFailureSpec) DeepCopyInto(out *ProcessFailureSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProcessFailureSpec.
+func (in *ProcessFailureSpec) DeepCopy() *ProcessFailureSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProcessFailureSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Reporting) DeepCopyInto(out *Reporting) {
 	*out = *in
@@ -693,10 +1606,37 @@ func (in *Reporting) DeepCopy() *Reporting {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestartsAbortCondition) DeepCopyInto(out *RestartsAbortCondition) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestartsAbortCondition.
+func (in *RestartsAbortCondition) DeepCopy() *RestartsAbortCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(RestartsAbortCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TargetInjection) DeepCopyInto(out *TargetInjection) {
 	*out = *in
 	in.Since.DeepCopyInto(&out.Since)
+	if in.InitialRestartCount != nil {
+		in, out := &in.InitialRestartCount, &out.InitialRestartCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = make(map[types.DisruptionKindName]InjectionResult, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetInjection.
@@ -730,6 +1670,21 @@ func (in TargetInjections) DeepCopy() TargetInjections {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetingSpec) DeepCopyInto(out *TargetingSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetingSpec.
+func (in *TargetingSpec) DeepCopy() *TargetingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UnsafemodeSpec) DeepCopyInto(out *UnsafemodeSpec) {
 	*out = *in
@@ -6,12 +6,14 @@
 package cgroup
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
 	"strings"
 
 	"github.com/DataDog/chaos-controller/cpuset"
 	"go.uber.org/zap"
+	"golang.org/x/sys/unix"
 )
 
 // Manager represents a cgroup manager able to join the given cgroup
@@ -28,6 +30,8 @@ type Manager interface {
 	IsCgroupV2() bool
 	// RelativePath returns the controller relative path
 	RelativePath(controller string) string
+	// ID returns the cgroup ID as returned by the bpf_get_current_cgroup_id BPF helper (cgroups v2 only)
+	ID() (uint64, error)
 }
 
 type instCGroupManager interface {
@@ -129,3 +133,19 @@ func (m manager) IsCgroupV2() bool {
 func (m manager) RelativePath(controller string) string {
 	return strings.TrimPrefix(m.cgroups.Path(controller), m.mountPath)
 }
+
+// ID returns the cgroup ID, being the inode number of the cgroup directory in the unified hierarchy
+func (m manager) ID() (uint64, error) {
+	if !m.isV2 {
+		return 0, errors.New("cgroup IDs can only be retrieved with cgroups v2")
+	}
+
+	stat := unix.Stat_t{}
+	path := m.cgroups.Path("")
+
+	if err := unix.Stat(path, &stat); err != nil {
+		return 0, fmt.Errorf("error getting the inode of the cgroup %s: %w", path, err)
+	}
+
+	return stat.Ino, nil
+}
@@ -24,6 +24,57 @@ func (_m *ManagerMock) EXPECT() *ManagerMock_Expecter {
 	return &ManagerMock_Expecter{mock: &_m.Mock}
 }
 
+// ID provides a mock function with given fields:
+func (_m *ManagerMock) ID() (uint64, error) {
+	ret := _m.Called()
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (uint64, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() uint64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ManagerMock_ID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ID'
+type ManagerMock_ID_Call struct {
+	*mock.Call
+}
+
+// ID is a helper method to define mock.On call
+func (_e *ManagerMock_Expecter) ID() *ManagerMock_ID_Call {
+	return &ManagerMock_ID_Call{Call: _e.mock.On("ID")}
+}
+
+func (_c *ManagerMock_ID_Call) Run(run func()) *ManagerMock_ID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *ManagerMock_ID_Call) Return(_a0 uint64, _a1 error) *ManagerMock_ID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ManagerMock_ID_Call) RunAndReturn(run func() (uint64, error)) *ManagerMock_ID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // IsCgroupV2 provides a mock function with given fields:
 func (_m *ManagerMock) IsCgroupV2() bool {
 	ret := _m.Called()
@@ -20,6 +20,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/util/homedir"
 )
@@ -377,14 +378,24 @@ func printNodeStatus(targetsInfo []v1.Node) {
 	PrintSeparator()
 }
 
-func setKubeconfig() error {
+// buildKubeconfig builds the client configuration of the kubeconfig file, defaulting to ~/.kube/config
+func buildKubeconfig() (*rest.Config, error) {
 	if len(kubeconfig) == 0 {
 		kubeconfig = filepath.Join(homedir.HomeDir(), ".kube", "config")
 	}
 
 	config, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
 	if err != nil {
-		return fmt.Errorf("failed to Build the kubeconfiguration: %v", err)
+		return nil, fmt.Errorf("failed to Build the kubeconfiguration: %v", err)
+	}
+
+	return config, nil
+}
+
+func setKubeconfig() error {
+	config, err := buildKubeconfig()
+	if err != nil {
+		return err
 	}
 
 	clientset, err = kubernetes.NewForConfig(config)
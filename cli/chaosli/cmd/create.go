@@ -103,7 +103,7 @@ func createSpec() (v1beta1.DisruptionSpec, error) {
 		spec.Containers = getContainers()
 	}
 
-	if spec.ContainerFailure == nil && spec.CPUPressure == nil && spec.DiskPressure == nil && spec.NodeFailure == nil && spec.GRPC == nil && spec.DiskFailure == nil && spec.Level == types.DisruptionLevelPod && len(spec.Containers) == 0 {
+	if spec.ContainerFailure == nil && spec.CPUPressure == nil && spec.DiskPressure == nil && spec.NodeFailure == nil && spec.GRPC == nil && spec.DiskFailure == nil && spec.DiskFill == nil && spec.ProcessFailure == nil && spec.ContainerFreeze == nil && spec.Level == types.DisruptionLevelPod && len(spec.Containers) == 0 {
 		spec.OnInit = getOnInit()
 	}
 
@@ -115,11 +115,15 @@ func createSpec() (v1beta1.DisruptionSpec, error) {
 func promptForKind(spec *v1beta1.DisruptionSpec) error {
 	initial := "Let's begin by choosing the type of disruption to apply! Which disruption kind would you like to add?"
 	followUp := "Would you like to add another disruption kind? It's not necessary, most disruptions involve only one kind. Select .. to finish adding kinds."
-	kinds := []string{"dns", "network", "cpu", "disk pressure", "node failure", "container failure", "disk failure"}
+	kinds := []string{"dns", "network", "cpu", "disk pressure", "node failure", "container failure", "container freeze", "process failure", "disk failure", "disk fill"}
 	helpText := `The DNS disruption allows for overriding the A or CNAME records returned by DNS queries.
 The Network disruption allows for injecting a variety of different network issues into your target.
 The CPU and Disk disruptions apply cpu pressure or IO throttling to your target, respectively.
-Tne Node Failure disruption can either shutdown or restart the targeted node, or the node hosting the targeted pod.
+The Disk Fill disruption allocates files until the filesystem of your target is (almost) full.
+The Container Freeze disruption freezes all the processes of your target, making it hang instead of dying.
+The Process Failure disruption sends a signal to some processes of your target, or pauses them.
+Tne Node Failure disruption can either shutdown or restart the targeted node, or the node hosting the targeted pod,
+or make it fail in a recoverable way by freezing or stopping its kubelet, isolating its network or draining it.
 
 Select one for more information on it.`
 
@@ -207,6 +211,21 @@ Select one for more information on it.`
 
 				spec.DiskFailure = nil
 
+				continue
+			}
+		case "disk fill":
+			spec.DiskFill = getDiskFill()
+
+			if spec.DiskFill == nil {
+				continue
+			}
+
+			err := spec.DiskFill.Validate()
+			if err != nil {
+				fmt.Printf("There were some problems with your disk fill disruption's spec: %v\n\n", err)
+
+				spec.DiskFill = nil
+
 				continue
 			}
 		case "node failure":
@@ -237,6 +256,27 @@ Select one for more information on it.`
 
 				spec.ContainerFailure = nil
 
+				continue
+			}
+		case "container freeze":
+			spec.ContainerFreeze = getContainerFreeze()
+
+			if spec.ContainerFreeze == nil {
+				continue
+			}
+		case "process failure":
+			spec.ProcessFailure = getProcessFailure()
+
+			if spec.ProcessFailure == nil {
+				continue
+			}
+
+			err := spec.ProcessFailure.Validate()
+			if err != nil {
+				fmt.Printf("There were some problems with your process failure disruption's spec: %v\n\n", err)
+
+				spec.ProcessFailure = nil
+
 				continue
 			}
 		}
@@ -425,6 +465,25 @@ func getDiskPressure() *v1beta1.DiskPressureSpec {
 		spec.Throttling.WriteBytesPerSec = &writeBPS
 	}
 
+	if confirmOption("Would you like to apply read IOPS throttling?", "This limits the number of read IO operations per second (check the docs)") {
+		readIOPS, _ := strconv.Atoi(getInput("Specify the target amount of throttling, in read operations per second.", "check the docs", survey.WithValidator(integerValidator)))
+		spec.Throttling.ReadIOPS = &readIOPS
+	}
+
+	if confirmOption("Would you like to apply write IOPS throttling?", "This limits the number of write IO operations per second (check the docs)") {
+		writeIOPS, _ := strconv.Atoi(getInput("Specify the target amount of throttling, in write operations per second.", "check the docs", survey.WithValidator(integerValidator)))
+		spec.Throttling.WriteIOPS = &writeIOPS
+	}
+
+	if confirmOption("Would you like to set an IO latency target? (cgroups v2 only)", "The other cgroups of the node having a higher latency target are throttled when the target is missed (check the docs)") {
+		spec.Throttling.LatencyTarget = v1beta1.DisruptionDuration(getInput(
+			"Specify the IO latency target. This can be a golang's time.Duration.",
+			"Please specify a golang's time.Duration, e.g., \"10ms\", \"500us\".",
+			survey.WithValidator(survey.Required),
+			survey.WithValidator(durationValidator),
+		))
+	}
+
 	return spec
 }
 
@@ -436,28 +495,129 @@ func getDiskFailure() *v1beta1.DiskFailureSpec {
 	spec := &v1beta1.DiskFailureSpec{}
 
 	spec.Path = getInput("Add a filter path prefix matcher",
-		"The filter path is a strict prefix matcher and avoid a program to access file or directory with this prefix. Currently it does not support wildcards and could not exceed 62 characters.",
+		"The filter path is a strict prefix matcher and avoid a program to access file or directory with this prefix. It could not exceed 255 characters.",
+	)
+
+	for confirmOption("Would you like to add another path pattern?", "Patterns can match paths by prefix, exactly or by suffix, e.g., \".sst\" to match a file extension") {
+		match, err := selectInput("How should the pattern be matched?", []string{string(v1beta1.DiskFailurePathMatchPrefix), string(v1beta1.DiskFailurePathMatchExact), string(v1beta1.DiskFailurePathMatchSuffix)}, "check the docs")
+		if err != nil {
+			break
+		}
+
+		spec.Paths = append(spec.Paths, v1beta1.DiskFailurePathSpec{
+			Pattern: getInput("Specify the pattern", "It could not exceed 255 characters.", survey.WithValidator(survey.Required)),
+			Match:   v1beta1.DiskFailurePathMatch(match),
+		})
+	}
+
+	return spec
+}
+
+func getDiskFill() *v1beta1.DiskFillSpec {
+	if !confirmKind("Disk Fill", "Simulates a full disk by allocating files on the filesystem of the target") {
+		return nil
+	}
+
+	spec := &v1beta1.DiskFillSpec{}
+
+	spec.Path = getInput(
+		"Specify a path to fill the filesystem of, e.g., /mnt/data",
+		"The files are allocated in a hidden directory under this path and deleted on cleanup",
+		survey.WithValidator(survey.Required),
 	)
 
+	if confirmOption("Would you like to fill the filesystem up to a percentage?", "Otherwise, you'll specify the number of bytes to leave available") {
+		percentage, _ := strconv.Atoi(getInput("Specify the used space percentage of the filesystem to reach, from 1 to 100.", "check the docs", survey.WithValidator(survey.Required), survey.WithValidator(integerValidator)))
+		spec.Percentage = &percentage
+	} else {
+		remainingBytes, _ := strconv.Atoi(getInput("Specify the number of bytes to leave available on the filesystem.", "check the docs", survey.WithValidator(survey.Required), survey.WithValidator(integerValidator)))
+		spec.RemainingBytes = &remainingBytes
+	}
+
 	return spec
 }
 
 func getCPUPressure() *v1beta1.CPUPressureSpec {
-	if confirmKind("CPU Pressure", "Applies CPU pressure to the target") {
-		return &v1beta1.CPUPressureSpec{}
+	if !confirmKind("CPU Pressure", "Applies CPU pressure to the target") {
+		return nil
 	}
 
-	return nil
+	spec := &v1beta1.CPUPressureSpec{}
+
+	if confirmOption("Would you like to throttle the CPU instead of stressing it?", "The CPU quota of the target is lowered to simulate an aggressive CPU limit without generating any load") {
+		percentage, _ := strconv.Atoi(getInput("Specify the percentage of the CPU limit to keep, from 1 to 99.", "check the docs", survey.WithValidator(survey.Required), survey.WithValidator(integerValidator)))
+		spec.Throttling = &v1beta1.CPUPressureThrottlingSpec{Percentage: percentage}
+
+		return spec
+	}
+
+	if confirmOption("Would you like to keep the targeted cores at a given utilization?", "The stress adapts to the load of the target instead of stressing every core by a fixed amount") {
+		targetUtilization, _ := strconv.Atoi(getInput("Specify the percentage of time each core should be kept busy, from 1 to 100.", "check the docs", survey.WithValidator(survey.Required), survey.WithValidator(integerValidator)))
+		spec.TargetUtilization = &targetUtilization
+	}
+
+	if confirmOption("Would you like the stress level to vary over time?", "The stress level can ramp up, follow a sine wave or increase by steps") {
+		profileType, err := selectInput(
+			"Which profile would you like to apply?",
+			[]string{string(v1beta1.CPUPressureProfileRamp), string(v1beta1.CPUPressureProfileSine), string(v1beta1.CPUPressureProfileStep)},
+			"ramp goes up over the duration, sine oscillates with a period of the duration, step goes up by steps each lasting the duration",
+		)
+		if err != nil {
+			fmt.Printf("selectInput failed: %v", err)
+		}
+
+		profile := &v1beta1.CPUPressureProfileSpec{
+			Type: v1beta1.CPUPressureProfileType(profileType),
+		}
+
+		profile.Duration = v1beta1.DisruptionDuration(getInput(
+			"Specify the ramp duration, the sine period or the duration of each step, e.g., 5m",
+			"Please specify a golang's time.Duration, e.g., \"5m\", \"30s\".",
+			survey.WithValidator(survey.Required),
+			survey.WithValidator(durationValidator),
+		))
+		profile.Start, _ = strconv.Atoi(getInput("Specify the stress level percentage to start from, from 0 to 100.", "check the docs", survey.WithValidator(integerValidator)))
+
+		if profile.Type == v1beta1.CPUPressureProfileStep {
+			profile.Steps, _ = strconv.Atoi(getInput("Specify the number of steps to reach the final level, 4 by default.", "check the docs", survey.WithValidator(integerValidator)))
+		}
+
+		spec.Profile = profile
+	}
+
+	return spec
 }
 
 func getNodeFailure() *v1beta1.NodeFailureSpec {
-	if !confirmKind("Node Failure", "This will either shutdown or restart the targeted node (or node hosting the targeted pod)") {
+	if !confirmKind("Node Failure", "This will either shutdown or restart the targeted node (or node hosting the targeted pod), or make it fail in a recoverable way") {
 		return nil
 	}
 
 	spec := &v1beta1.NodeFailureSpec{}
-	spec.Shutdown = confirmOption("Would you like to shutdown the node permanently?",
-		"Choosing yes will terminate the VM completely. If you don't enable this, we will just restart the target node.")
+
+	failure, err := selectInput("How would you like the node to fail?", []string{"crash", "kubelet freeze", "kubelet stop", "network isolation", "drain"},
+		"crash triggers a kernel panic or shuts the node down, the other failures are cleaned when the disruption ends")
+	if err != nil {
+		fmt.Printf("selectInput failed: %v", err)
+	}
+
+	switch failure {
+	case "kubelet freeze":
+		spec.Kubelet = &v1beta1.NodeFailureKubeletSpec{Action: v1beta1.NodeFailureKubeletFreeze}
+	case "kubelet stop":
+		spec.Kubelet = &v1beta1.NodeFailureKubeletSpec{Action: v1beta1.NodeFailureKubeletStop}
+	case "network isolation":
+		spec.NetworkIsolation = &v1beta1.NodeFailureNetworkIsolationSpec{}
+
+		if hosts := getInput("Specify a comma separated list of IPs or CIDRs which can still be reached in addition to the control plane, or leave blank", "check the docs"); hosts != "" {
+			spec.NetworkIsolation.AllowedHosts = strings.Split(hosts, ",")
+		}
+	case "drain":
+		spec.Drain = &v1beta1.NodeFailureDrainSpec{}
+	default:
+		spec.Shutdown = confirmOption("Would you like to shutdown the node permanently?",
+			"Choosing yes will terminate the VM completely. If you don't enable this, we will just restart the target node.")
+	}
 
 	return spec
 }
@@ -474,6 +634,44 @@ func getContainerFailure() *v1beta1.ContainerFailureSpec {
 	return spec
 }
 
+func getContainerFreeze() *v1beta1.ContainerFreezeSpec {
+	if !confirmKind("Container Freeze", "This will freeze all the processes of the targeted pod's container(s) until the disruption is cleaned, use pulse to create periodic stalls") {
+		return nil
+	}
+
+	return &v1beta1.ContainerFreezeSpec{}
+}
+
+func getProcessFailure() *v1beta1.ProcessFailureSpec {
+	if !confirmKind("Process Failure", "This will send a signal to the processes of the targeted pod's container(s) matching a pattern, or pause them") {
+		return nil
+	}
+
+	spec := &v1beta1.ProcessFailureSpec{}
+
+	spec.Pattern = getInput(
+		"Specify a regular expression matching the name or the command line of the processes to target, e.g., ^worker",
+		"Every process of the targeted containers whose name or command line matches this pattern is targeted",
+		survey.WithValidator(survey.Required),
+	)
+
+	if confirmOption("Would you like to pause the processes instead of sending them a signal?", "The processes are paused with SIGSTOP and resumed with SIGCONT") {
+		spec.Signal = "SIGSTOP"
+		spec.PauseDuration = v1beta1.DisruptionDuration(getInput("Specify how long the processes should be paused for, e.g., 30s, or leave blank to pause them until the end of the disruption", "check the docs"))
+	} else {
+		signal, err := selectInput("Which signal would you like to send?", []string{"SIGTERM", "SIGKILL", "SIGINT", "SIGHUP", "SIGQUIT", "SIGABRT", "SIGUSR1", "SIGUSR2"}, "SIGTERM lets the processes exit gracefully, SIGKILL terminates them immediately")
+		if err != nil {
+			fmt.Printf("selectInput failed: %v", err)
+		}
+
+		spec.Signal = signal
+	}
+
+	spec.Interval = v1beta1.DisruptionDuration(getInput("Specify an interval to repeat the injection at, e.g., 5m, or leave blank to inject once", "check the docs"))
+
+	return spec
+}
+
 func getHosts() []v1beta1.NetworkDisruptionHostSpec {
 	if !confirmOption("Would you like to specify any hosts?",
 		"If you want to target _all_ traffic, or only want to target k8s services, don't specify any hosts.") {
@@ -845,6 +1043,16 @@ func percentageValidator(val interface{}) error {
 	return nil
 }
 
+func durationValidator(val interface{}) error {
+	if str, ok := val.(string); ok {
+		_, err := time.ParseDuration(str)
+
+		return err
+	}
+
+	return fmt.Errorf("expected a string response, rather than type %v", reflect.TypeOf(val).Name())
+}
+
 func integerValidator(val interface{}) error {
 	if str, ok := val.(string); ok {
 		if str == "" {
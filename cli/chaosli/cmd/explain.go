@@ -59,6 +59,10 @@ func explainMetaSpec(spec v1beta1.DisruptionSpec) {
 
 	if spec.Pulse != nil {
 		fmt.Printf("\tℹ️  has the pulse mode activated meaning that after an initial delay of %s the disruptions will alternate between an active injected state with a duration of %s, and an inactive dormant state with a duration of %s.\n", spec.Pulse.InitialDelay.Duration().String(), spec.Pulse.ActiveDuration.Duration().String(), spec.Pulse.DormantDuration.Duration().String())
+
+		if spec.Pulse.IsRandom() {
+			fmt.Printf("\t\t🎲  the active and dormant durations are randomized up to %s and %s respectively.\n", spec.Pulse.MaxActiveDuration.Duration().String(), spec.Pulse.MaxDormantDuration.Duration().String())
+		}
 	}
 
 	if spec.OnInit {
@@ -67,6 +71,27 @@ func explainMetaSpec(spec v1beta1.DisruptionSpec) {
 
 	fmt.Printf("\tℹ️  is going to target %s %s(s) (either described as a percentage of total %ss or actual number of them).\n", spec.Count, spec.Level, spec.Level)
 
+	if spec.Rollout != nil {
+		fmt.Printf("\tℹ️  will be rolled out progressively, widening its targets through the following stages as long as no target warning is observed:\n")
+
+		for _, stage := range spec.Rollout.Stages {
+			fmt.Printf("\t\t🎯  %s %s(s) for %s\n", stage.Count, spec.Level, stage.Dwell.Duration().String())
+		}
+
+		fmt.Printf("\t\t🎯  %s %s(s) until the end of the disruption\n", spec.Count, spec.Level)
+	}
+
+	if spec.Targeting != nil {
+		switch spec.Targeting.Strategy {
+		case v1beta1.TargetingStrategySpread:
+			fmt.Printf("\tℹ️  will spread its targets evenly across the %s topology domains.\n", spec.Targeting.GetTopologyKey())
+		case v1beta1.TargetingStrategyConcentrate:
+			fmt.Printf("\tℹ️  will concentrate all its targets in a single %s topology domain.\n", spec.Targeting.GetTopologyKey())
+		case v1beta1.TargetingStrategyPerOwner:
+			fmt.Printf("\tℹ️  will apply the count to each owner (deployment, statefulset...) of the targeted pods.\n")
+		}
+	}
+
 	if spec.StaticTargeting {
 		fmt.Printf("\tℹ️  has StaticTargeting activated, so new pods/nodes will be NOT be targeted \n")
 	} else {
@@ -92,6 +117,49 @@ func explainContainerFailure(spec v1beta1.DisruptionSpec) {
 	PrintSeparator()
 }
 
+func explainContainerFreeze(spec v1beta1.DisruptionSpec) {
+	if spec.ContainerFreeze == nil {
+		return
+	}
+
+	fmt.Println("💉 injects a container freeze which freezes all the processes of the pod's container(s) through the cgroup freezer, unfreezing them when the disruption is cleaned.")
+
+	if spec.Pulse != nil {
+		fmt.Println("\t🔁 the container(s) are frozen and unfrozen on each pulse, creating periodic stalls")
+	}
+
+	PrintSeparator()
+}
+
+func explainProcessFailure(spec v1beta1.DisruptionSpec) {
+	processFailure := spec.ProcessFailure
+
+	if processFailure == nil {
+		return
+	}
+
+	signal := processFailure.Signal
+	if signal == "" {
+		signal = v1beta1.DefaultProcessFailureSignal
+	}
+
+	fmt.Printf("💉 injects a process failure which sends the %s signal to the processes of the pod's container(s) matching %s.\n", signal, processFailure.Pattern)
+
+	if signal == "SIGSTOP" {
+		if processFailure.PauseDuration != "" {
+			fmt.Printf("\t⏸  the processes are paused and resumed with the SIGCONT signal after %s\n", processFailure.PauseDuration)
+		} else {
+			fmt.Println("\t⏸  the processes are paused and resumed with the SIGCONT signal when the disruption is cleaned")
+		}
+	}
+
+	if processFailure.Interval != "" {
+		fmt.Printf("\t🔁 the injection is repeated every %s\n", processFailure.Interval)
+	}
+
+	PrintSeparator()
+}
+
 func explainNodeFailure(spec v1beta1.DisruptionSpec) {
 	nodeFailure := spec.NodeFailure
 
@@ -99,9 +167,22 @@ func explainNodeFailure(spec v1beta1.DisruptionSpec) {
 		return
 	}
 
-	if nodeFailure.Shutdown {
+	switch {
+	case nodeFailure.Kubelet != nil && nodeFailure.Kubelet.Action == v1beta1.NodeFailureKubeletStop:
+		fmt.Println("💉 injects a node failure which stops the kubelet service of the node, starting it again when the disruption is cleaned.")
+	case nodeFailure.Kubelet != nil:
+		fmt.Println("💉 injects a node failure which freezes the kubelet of the node, resuming it when the disruption is cleaned.")
+	case nodeFailure.NetworkIsolation != nil:
+		fmt.Println("💉 injects a node failure which drops all the traffic of the node except the one exchanged with the control plane, until the disruption is cleaned.")
+
+		if len(nodeFailure.NetworkIsolation.AllowedHosts) > 0 {
+			fmt.Printf("\t✅ the following hosts can still be reached: %s\n", strings.Join(nodeFailure.NetworkIsolation.AllowedHosts, ", "))
+		}
+	case nodeFailure.Drain != nil:
+		fmt.Println("💉 injects a node failure which cordons the node and evicts its pods (except daemonset and static pods), uncordoning it when the disruption is cleaned.")
+	case nodeFailure.Shutdown:
 		fmt.Println("💉 injects a node failure which shuts down the host (violently) instead of triggering a kernel panic so the host is kept down and not restarted.")
-	} else {
+	default:
 		fmt.Println("💉 injects a node failure which triggers a kernel panic on the node.")
 	}
 
@@ -116,6 +197,32 @@ func explainCPUPressure(spec v1beta1.DisruptionSpec) {
 	}
 
 	fmt.Println("💉 injects a cpu pressure disruption ...")
+
+	if cpuPressure.Throttling != nil {
+		fmt.Printf("\t🧊 lowering the cpu quota of the targets to %d%% of their limit (or of their allocated cores if not limited), without stressing them\n", cpuPressure.Throttling.Percentage)
+		fmt.Println("\t\t🔁 the original quota is persisted on the node and restored on cleanup")
+	} else if cpuPressure.TargetUtilization != nil {
+		fmt.Printf("\t🔥 keeping every targeted core %d%% busy, the stress adapting to the load of the target\n", *cpuPressure.TargetUtilization)
+	} else if cpuPressure.Count != nil {
+		fmt.Printf("\t🔥 stressing the targeted cores according to a count of %s\n", cpuPressure.Count.String())
+	}
+
+	if profile := cpuPressure.Profile; profile != nil {
+		switch profile.Type {
+		case v1beta1.CPUPressureProfileRamp:
+			fmt.Printf("\t\t📈 ramping the stress level up from %d%% over %s\n", profile.Start, profile.Duration)
+		case v1beta1.CPUPressureProfileSine:
+			fmt.Printf("\t\t🌊 oscillating the stress level from %d%% with a period of %s\n", profile.Start, profile.Duration)
+		case v1beta1.CPUPressureProfileStep:
+			steps := profile.Steps
+			if steps == 0 {
+				steps = v1beta1.DefaultCPUPressureProfileSteps
+			}
+
+			fmt.Printf("\t\t📶 raising the stress level from %d%% in %d steps of %s\n", profile.Start, steps, profile.Duration)
+		}
+	}
+
 	PrintSeparator()
 }
 
@@ -144,6 +251,45 @@ func explainDiskPressure(spec v1beta1.DisruptionSpec) {
 		fmt.Printf("\t\t📝 %d write bytes per second\n", *diskPressure.Throttling.WriteBytesPerSec)
 	}
 
+	if diskPressure.Throttling.ReadIOPS != nil {
+		fmt.Printf("\t\t📖 %d read operations per second\n", *diskPressure.Throttling.ReadIOPS)
+	}
+
+	if diskPressure.Throttling.WriteIOPS != nil {
+		fmt.Printf("\t\t📝 %d write operations per second\n", *diskPressure.Throttling.WriteIOPS)
+	}
+
+	if diskPressure.Throttling.LatencyTarget != "" {
+		fmt.Printf("\t\t⏱  an IO latency target of %s, throttling the other cgroups of the node missing it (cgroups v2 only)\n", diskPressure.Throttling.LatencyTarget)
+	}
+
+	PrintSeparator()
+}
+
+func explainDiskFill(spec v1beta1.DisruptionSpec) {
+	diskFill := spec.DiskFill
+
+	if diskFill == nil {
+		return
+	}
+
+	fmt.Println("💉 injects a disk fill disruption ...")
+	fmt.Printf("\t🗂  on path %s\n", diskFill.Path)
+
+	if diskFill.Percentage != nil {
+		fmt.Printf("\t\t💾 allocating files until %d%% of the filesystem is used\n", *diskFill.Percentage)
+	}
+
+	if diskFill.RemainingBytes != nil {
+		fmt.Printf("\t\t💾 allocating files until only %d bytes are available on the filesystem\n", *diskFill.RemainingBytes)
+	}
+
+	fmt.Println("\t\t🔁 keeping this level if some space is freed, and deleting the allocated files on cleanup")
+
+	if spec.AllowsRootDiskFill() {
+		fmt.Println("\t\t⚠️  the host root filesystem is allowed to be filled")
+	}
+
 	PrintSeparator()
 }
 
@@ -347,9 +493,12 @@ func explanation(path string) {
 	explainMultiDisruption(disruption.Spec)
 	explainNodeFailure(disruption.Spec)
 	explainContainerFailure(disruption.Spec)
+	explainContainerFreeze(disruption.Spec)
+	explainProcessFailure(disruption.Spec)
 	explainNetworkFailure(disruption.Spec)
 	explainCPUPressure(disruption.Spec)
 	explainDiskPressure(disruption.Spec)
+	explainDiskFill(disruption.Spec)
 	explainDNS(disruption.Spec)
 	explainGRPC(disruption.Spec)
 }
@@ -0,0 +1,153 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/DataDog/chaos-controller/injector"
+	"github.com/DataDog/chaos-controller/types"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var injectorCmd = &cobra.Command{
+	Use:   "injector",
+	Short: "inspect and operate a running chaos pod",
+	Long: `makes use of the injector control API of a chaos pod to inspect its current state and trigger operations on it.
+The controller must expose the injector control API (--injector-control-port) and you must be connected to the cluster hosting the chaos pod.`,
+}
+
+var injectorStateCmd = &cobra.Command{
+	Use:   "state",
+	Short: "display the current state of a chaos pod injector",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runInjectorControl(cmd, "GET", injector.ControlStatePath, nil)
+	},
+}
+
+var injectorCleanCmd = &cobra.Command{
+	Use:   "clean",
+	Short: "clean the disruption injected by a chaos pod, leaving it running until it is reinjected or its deadline is reached",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runInjectorControl(cmd, "POST", injector.ControlCleanPath, nil)
+	},
+}
+
+var injectorReinjectCmd = &cobra.Command{
+	Use:   "reinject",
+	Short: "clean and inject again the disruption of a chaos pod",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runInjectorControl(cmd, "POST", injector.ControlReinjectPath, nil)
+	},
+}
+
+var injectorDeadlineCmd = &cobra.Command{
+	Use:   "deadline",
+	Short: "move the deadline of a chaos pod disruption, up to the end of the disruption duration",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		deadline, _ := cmd.Flags().GetString("deadline")
+
+		if _, err := time.Parse(time.RFC3339, deadline); err != nil {
+			return fmt.Errorf("invalid deadline, expected a RFC3339 time: %w", err)
+		}
+
+		return runInjectorControl(cmd, "POST", injector.ControlDeadlinePath, map[string]string{injector.ControlDeadlineKey: deadline})
+	},
+}
+
+func init() {
+	injectorCmd.PersistentFlags().String("pod", "", "The name of the chaos pod to operate.")
+	injectorCmd.PersistentFlags().String("namespace", "chaos-engineering", "The namespace of the chaos pod to operate.")
+	injectorCmd.PersistentFlags().String("control-token-secret", "chaos-injector-control", "The name of the secret of the chaos pod namespace holding the injector control API token.")
+	injectorCmd.PersistentFlags().String("kubeconfig", "", "The path to your kube configuration directory (.../.kube/config). defaults to ~/.kube/config.")
+	injectorDeadlineCmd.Flags().String("deadline", "", "The RFC3339 time at which the disruption must be over by.")
+
+	if err := injectorCmd.MarkPersistentFlagRequired("pod"); err != nil {
+		return
+	}
+
+	if err := injectorDeadlineCmd.MarkFlagRequired("deadline"); err != nil {
+		return
+	}
+
+	injectorCmd.AddCommand(injectorStateCmd)
+	injectorCmd.AddCommand(injectorCleanCmd)
+	injectorCmd.AddCommand(injectorReinjectCmd)
+	injectorCmd.AddCommand(injectorDeadlineCmd)
+}
+
+// runInjectorControl calls the given path of the injector control API of the chaos pod through the API server proxy,
+// authenticated with the token of the control token secret, and prints the returned injector state
+func runInjectorControl(cmd *cobra.Command, verb string, path string, params map[string]string) error {
+	podName, _ := cmd.Flags().GetString("pod")
+	namespace, _ := cmd.Flags().GetString("namespace")
+	kubeconfig, _ = cmd.Flags().GetString("kubeconfig")
+
+	if err := setKubeconfig(); err != nil {
+		return err
+	}
+
+	pod, err := clientset.CoreV1().Pods(namespace).Get(context.Background(), podName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error getting chaos pod %s/%s: %w", namespace, podName, err)
+	}
+
+	var port int32
+
+	for _, container := range pod.Spec.Containers {
+		for _, containerPort := range container.Ports {
+			if containerPort.Name == types.InjectorControlPortName {
+				port = containerPort.ContainerPort
+			}
+		}
+	}
+
+	if port == 0 {
+		return fmt.Errorf("chaos pod %s/%s does not expose the injector control API, the controller must be started with a non-zero --injector-control-port", namespace, podName)
+	}
+
+	tokenSecretName, _ := cmd.Flags().GetString("control-token-secret")
+
+	tokenSecret, err := clientset.CoreV1().Secrets(namespace).Get(context.Background(), tokenSecretName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error getting the injector control API token secret %s/%s: %w", namespace, tokenSecretName, err)
+	}
+
+	req := clientset.CoreV1().RESTClient().Verb(verb).
+		Namespace(namespace).
+		Resource("pods").
+		Name(fmt.Sprintf("%s:%d", podName, port)).
+		SubResource("proxy").
+		Suffix(path).
+		SetHeader(injector.ControlTokenHeader, string(tokenSecret.Data[types.InjectorControlTokenSecretKey]))
+
+	for key, value := range params {
+		req = req.Param(key, value)
+	}
+
+	body, err := req.DoRaw(context.Background())
+	if err != nil {
+		return fmt.Errorf("error calling the injector control API of chaos pod %s/%s: %w: %s", namespace, podName, err, string(body))
+	}
+
+	state := injector.ControlState{}
+	if err := json.Unmarshal(body, &state); err != nil {
+		return fmt.Errorf("error reading the injector state: %w", err)
+	}
+
+	output, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(output))
+
+	return nil
+}
@@ -66,7 +66,11 @@ func init() {
 	rootCmd.AddCommand(validateCmd)
 	rootCmd.AddCommand(explainCmd)
 	rootCmd.AddCommand(contextCmd)
+	rootCmd.AddCommand(scheduleCmd)
+	rootCmd.AddCommand(workflowCmd)
 	rootCmd.AddCommand(versionCmd)
+	rootCmd.AddCommand(injectorCmd)
+	rootCmd.AddCommand(templateCmd)
 
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.chaosli.yaml)")
 
@@ -0,0 +1,68 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/DataDog/chaos-controller/api/v1beta1"
+	"github.com/spf13/cobra"
+	goyaml "sigs.k8s.io/yaml"
+)
+
+var scheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "preview disruption cron schedule",
+	Long:  `validates the yaml of the disruption cron and prints the next times it will create a disruption.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, _ := cmd.Flags().GetString("path")
+		count, _ := cmd.Flags().GetInt("count")
+
+		return PreviewSchedule(path, count)
+	},
+}
+
+func init() {
+	scheduleCmd.Flags().String("path", "", "The path to the disruption cron file to preview.")
+	scheduleCmd.Flags().Int("count", 5, "The number of next schedule times to print.")
+
+	if err := scheduleCmd.MarkFlagRequired("path"); err != nil {
+		return
+	}
+}
+
+// PreviewSchedule prints the count next schedule times of the disruption cron located at the given path
+func PreviewSchedule(path string, count int) error {
+	yamlBytes, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return fmt.Errorf("could not read yaml file at %s: %w", path, err)
+	}
+
+	disruptionCron := v1beta1.DisruptionCron{}
+	if err := goyaml.UnmarshalStrict(yamlBytes, &disruptionCron); err != nil {
+		return fmt.Errorf("could not unmarshal yaml file to DisruptionCron: %w", err)
+	}
+
+	if err := disruptionCron.Spec.Validate(); err != nil {
+		return fmt.Errorf("there were some problems when validating your disruption cron:\n%w", err)
+	}
+
+	times, err := disruptionCron.Spec.GetNextScheduleTimes(time.Now(), count)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Next %d disruptions of %s (%s, concurrency policy %s):\n", len(times), disruptionCron.Name, disruptionCron.Spec.Schedule, disruptionCron.Spec.GetConcurrencyPolicy())
+
+	for _, t := range times {
+		fmt.Printf("\t- %s\n", t.Format(time.RFC1123))
+	}
+
+	return nil
+}
@@ -0,0 +1,260 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/DataDog/chaos-controller/api/v1beta1"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/cli-runtime/pkg/printers"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	goyaml "sigs.k8s.io/yaml"
+)
+
+var templateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "list and instantiate disruption templates",
+	Long: `lists the disruption templates available in the cluster and instantiates them into disruptions.
+Both namespaced DisruptionTemplates and ClusterDisruptionTemplates are supported.`,
+}
+
+var templateListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "list the disruption templates of a namespace and the cluster disruption templates",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		namespace, _ := cmd.Flags().GetString("namespace")
+		kubeconfig, _ = cmd.Flags().GetString("kubeconfig")
+
+		return ListTemplates(namespace)
+	},
+}
+
+var templateInstantiateCmd = &cobra.Command{
+	Use:   "instantiate",
+	Short: "print the disruption instantiated from a template with the given parameter values",
+	Long: `expands the template with the given parameter values, validates the resulting disruption and prints it.
+The template is read from the cluster, or from a local file when --path is given.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, _ := cmd.Flags().GetString("name")
+		kind, _ := cmd.Flags().GetString("kind")
+		namespace, _ := cmd.Flags().GetString("namespace")
+		path, _ := cmd.Flags().GetString("path")
+		disruptionName, _ := cmd.Flags().GetString("disruption-name")
+		params, _ := cmd.Flags().GetStringArray("param")
+		selector, _ := cmd.Flags().GetStringToString("selector")
+		count, _ := cmd.Flags().GetString("count")
+		kubeconfig, _ = cmd.Flags().GetString("kubeconfig")
+
+		values, err := parseTemplateParameters(params)
+		if err != nil {
+			return err
+		}
+
+		// the selector and the count are set on the disruption, and replaced by the template if it defines them
+		fields := map[string]interface{}{}
+
+		if len(selector) > 0 {
+			fields["selector"] = selector
+		}
+
+		if count != "" {
+			fields["count"] = intstr.Parse(count)
+		}
+
+		return InstantiateTemplate(v1beta1.DisruptionTemplateRef{Kind: kind, Name: name, Parameters: values}, namespace, path, disruptionName, fields)
+	},
+}
+
+func init() {
+	templateCmd.PersistentFlags().String("namespace", "", "The namespace of the disruption templates, and of the instantiated disruption.")
+	templateCmd.PersistentFlags().String("kubeconfig", "", "The path to your kube configuration directory (.../.kube/config). defaults to ~/.kube/config.")
+	templateInstantiateCmd.Flags().String("name", "", "The name of the template to instantiate.")
+	templateInstantiateCmd.Flags().String("kind", v1beta1.DisruptionTemplateKind, "The kind of the template to instantiate, either DisruptionTemplate or ClusterDisruptionTemplate.")
+	templateInstantiateCmd.Flags().String("path", "", "The path to a local template file to instantiate instead of a template of the cluster.")
+	templateInstantiateCmd.Flags().String("disruption-name", "", "The name of the instantiated disruption, defaults to the template name.")
+	templateInstantiateCmd.Flags().StringArray("param", []string{}, "A parameter value of the template as name=value, can be repeated.")
+	templateInstantiateCmd.Flags().StringToString("selector", map[string]string{}, "The label selector of the disruption if not defined by the template, as key=value.")
+	templateInstantiateCmd.Flags().String("count", "", "The number or percentage of targets of the disruption if not defined by the template.")
+
+	templateCmd.AddCommand(templateListCmd)
+	templateCmd.AddCommand(templateInstantiateCmd)
+}
+
+// newTemplateClient creates a client of the cluster knowing the disruption template types
+func newTemplateClient() (client.Client, error) {
+	config, err := buildKubeconfig()
+	if err != nil {
+		return nil, err
+	}
+
+	scheme := runtime.NewScheme()
+	if err := v1beta1.AddToScheme(scheme); err != nil {
+		return nil, fmt.Errorf("error registering the chaos types: %w", err)
+	}
+
+	return client.New(config, client.Options{Scheme: scheme})
+}
+
+// ListTemplates prints the disruption templates of the given namespace, or of all namespaces if empty, and the cluster disruption templates
+func ListTemplates(namespace string) error {
+	c, err := newTemplateClient()
+	if err != nil {
+		return err
+	}
+
+	templates := v1beta1.DisruptionTemplateList{}
+	if err := c.List(context.Background(), &templates, client.InNamespace(namespace)); err != nil {
+		return fmt.Errorf("error listing disruption templates: %w", err)
+	}
+
+	clusterTemplates := v1beta1.ClusterDisruptionTemplateList{}
+	if err := c.List(context.Background(), &clusterTemplates); err != nil {
+		return fmt.Errorf("error listing cluster disruption templates: %w", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "KIND\tNAMESPACE\tNAME\tPARAMETERS\tDESCRIPTION")
+
+	for _, template := range templates.Items {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", v1beta1.DisruptionTemplateKind, template.Namespace, template.Name, describeTemplateParameters(template.Spec), template.Spec.Description)
+	}
+
+	for _, template := range clusterTemplates.Items {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", v1beta1.ClusterDisruptionTemplateKind, "", template.Name, describeTemplateParameters(template.Spec), template.Spec.Description)
+	}
+
+	return w.Flush()
+}
+
+// InstantiateTemplate prints the disruption with the given raw spec fields instantiated from the referenced template,
+// read from the given path if any or from the cluster
+func InstantiateTemplate(ref v1beta1.DisruptionTemplateRef, namespace, path, disruptionName string, fields map[string]interface{}) error {
+	var (
+		spec v1beta1.DisruptionTemplateSpec
+		err  error
+	)
+
+	if path != "" {
+		ref.Kind, ref.Name, spec, err = templateFromFile(path)
+	} else {
+		if ref.Name == "" {
+			return fmt.Errorf("the name of the template to instantiate is required")
+		}
+
+		var c client.Client
+
+		if c, err = newTemplateClient(); err == nil {
+			spec, err = v1beta1.GetDisruptionTemplateSpec(context.Background(), c, namespace, ref)
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if err := spec.Apply(fields, ref.Parameters); err != nil {
+		return fmt.Errorf("error instantiating %s %s: %w", ref.GetKind(), ref.Name, err)
+	}
+
+	fields[v1beta1.DisruptionTemplateRefKey] = ref
+
+	raw, err := json.Marshal(fields)
+	if err != nil {
+		return fmt.Errorf("error encoding the instantiated disruption spec: %w", err)
+	}
+
+	disruptionSpec := v1beta1.DisruptionSpec{}
+	if err := json.Unmarshal(raw, &disruptionSpec); err != nil {
+		return fmt.Errorf("the instantiated template is not a valid disruption spec: %w", err)
+	}
+
+	if disruptionName == "" {
+		disruptionName = ref.Name
+	}
+
+	disruption := v1beta1.Disruption{
+		TypeMeta:   v1beta1.TypeMeta,
+		ObjectMeta: metav1.ObjectMeta{Name: disruptionName, Namespace: namespace},
+		Spec:       disruptionSpec,
+	}
+
+	// the template can leave some fields to the disruption (e.g. the selector), so the disruption is printed anyway
+	if err := RunAllValidation(disruption, ref.Name); err != nil {
+		fmt.Fprintf(os.Stderr, "the instantiated disruption is not valid yet, complete it before applying it:\n%v\n", err)
+	}
+
+	return (&printers.YAMLPrinter{}).PrintObj(&disruption, os.Stdout)
+}
+
+// templateFromFile returns the kind, name and spec of the disruption template located at the given path
+func templateFromFile(path string) (string, string, v1beta1.DisruptionTemplateSpec, error) {
+	template := struct {
+		metav1.TypeMeta   `json:",inline"`
+		metav1.ObjectMeta `json:"metadata,omitempty"`
+		Spec              v1beta1.DisruptionTemplateSpec `json:"spec"`
+	}{}
+
+	yamlBytes, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return "", "", template.Spec, fmt.Errorf("could not read yaml file at %s: %w", path, err)
+	}
+
+	if err := goyaml.UnmarshalStrict(yamlBytes, &template); err != nil {
+		return "", "", template.Spec, fmt.Errorf("could not unmarshal yaml file to a disruption template: %w", err)
+	}
+
+	if template.Kind != v1beta1.DisruptionTemplateKind && template.Kind != v1beta1.ClusterDisruptionTemplateKind {
+		return "", "", template.Spec, fmt.Errorf("unexpected kind %s, expected one of %s or %s", template.Kind, v1beta1.DisruptionTemplateKind, v1beta1.ClusterDisruptionTemplateKind)
+	}
+
+	if err := template.Spec.Validate(); err != nil {
+		return "", "", template.Spec, fmt.Errorf("there were some problems when validating your disruption template:\n%w", err)
+	}
+
+	return template.Kind, template.Name, template.Spec, nil
+}
+
+// parseTemplateParameters parses the given name=value parameters
+func parseTemplateParameters(params []string) (map[string]string, error) {
+	values := map[string]string{}
+
+	for _, param := range params {
+		name, value, found := strings.Cut(param, "=")
+		if !found || name == "" {
+			return nil, fmt.Errorf("invalid parameter %q, expected name=value", param)
+		}
+
+		values[name] = value
+	}
+
+	return values, nil
+}
+
+// describeTemplateParameters returns a short description of the template parameters and their default values
+func describeTemplateParameters(spec v1beta1.DisruptionTemplateSpec) string {
+	parameters := []string{}
+
+	for _, parameter := range spec.Parameters {
+		description := fmt.Sprintf("%s:%s", parameter.Name, parameter.GetType())
+		if parameter.Default != nil {
+			description += "=" + *parameter.Default
+		}
+
+		parameters = append(parameters, description)
+	}
+
+	return strings.Join(parameters, ",")
+}
@@ -0,0 +1,111 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/DataDog/chaos-controller/api/v1beta1"
+	"github.com/spf13/cobra"
+	goyaml "sigs.k8s.io/yaml"
+)
+
+var workflowCmd = &cobra.Command{
+	Use:   "workflow",
+	Short: "render chaos workflow plan",
+	Long:  `validates the yaml of the chaos workflow and prints its steps, the disruptions they create and what they wait for.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, _ := cmd.Flags().GetString("path")
+
+		return RenderWorkflow(path)
+	},
+}
+
+func init() {
+	workflowCmd.Flags().String("path", "", "The path to the chaos workflow file to render.")
+
+	if err := workflowCmd.MarkFlagRequired("path"); err != nil {
+		return
+	}
+}
+
+// RenderWorkflow prints the plan of the chaos workflow located at the given path
+func RenderWorkflow(path string) error {
+	yamlBytes, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return fmt.Errorf("could not read yaml file at %s: %w", path, err)
+	}
+
+	workflow := v1beta1.ChaosWorkflow{}
+	if err := goyaml.UnmarshalStrict(yamlBytes, &workflow); err != nil {
+		return fmt.Errorf("could not unmarshal yaml file to ChaosWorkflow: %w", err)
+	}
+
+	if err := workflow.Spec.Validate(); err != nil {
+		return fmt.Errorf("there were some problems when validating your chaos workflow:\n%w", err)
+	}
+
+	fmt.Printf("Plan of %s (%d steps, run one after the other):\n", workflow.Name, len(workflow.Spec.Steps))
+
+	for i, step := range workflow.Spec.Steps {
+		fmt.Printf("%d. %s\n", i+1, step.Name)
+
+		for _, disruption := range step.Disruptions {
+			fmt.Printf("\t- creates disruption %s: %s\n", v1beta1.GetChaosWorkflowDisruptionName(workflow.Name, step.Name, disruption.Name), describeWorkflowDisruption(disruption.Spec))
+		}
+
+		if len(step.Disruptions) > 0 {
+			fmt.Printf("\t- waits for its disruptions to be %s\n", strings.ToLower(string(step.GetWaitFor())))
+		}
+
+		for _, probe := range step.HealthProbes {
+			fmt.Printf("\t- waits for the health probe %s not to be %s %s\n", probe.GetName(), probe.Operator, probe.Threshold)
+		}
+
+		if step.Wait.Duration() > 0 {
+			fmt.Printf("\t- waits %s\n", step.Wait.Duration())
+		}
+
+		if step.Timeout.Duration() > 0 {
+			fmt.Printf("\t- fails the workflow if its conditions are not met within %s\n", step.Timeout.Duration())
+		}
+	}
+
+	return nil
+}
+
+// describeWorkflowDisruption returns a one line summary of the given disruption spec
+func describeWorkflowDisruption(spec v1beta1.DisruptionSpec) string {
+	kinds := []string{}
+	for _, kind := range spec.KindNames() {
+		kinds = append(kinds, string(kind))
+	}
+
+	targets := "targets"
+	if spec.Level != "" {
+		targets = fmt.Sprintf("%ss", spec.Level)
+	}
+
+	if spec.Count != nil {
+		targets = fmt.Sprintf("%s %s", spec.Count.String(), targets)
+	}
+
+	selector := spec.Selector.String()
+	for _, requirement := range spec.AdvancedSelector {
+		selector = strings.TrimPrefix(fmt.Sprintf("%s,%s %s %v", selector, requirement.Key, requirement.Operator, requirement.Values), ",")
+	}
+
+	description := fmt.Sprintf("%s on %s matching %s", strings.Join(kinds, ", "), targets, selector)
+
+	if spec.Duration.Duration() > 0 {
+		description += fmt.Sprintf(" for %s", spec.Duration.Duration())
+	}
+
+	return description
+}
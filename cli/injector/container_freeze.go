@@ -0,0 +1,27 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package main
+
+import (
+	"github.com/DataDog/chaos-controller/api/v1beta1"
+	"github.com/DataDog/chaos-controller/injector"
+	"github.com/spf13/cobra"
+)
+
+var containerFreezeCmd = &cobra.Command{
+	Use:   "container-freeze",
+	Short: "Container freeze subcommands",
+	Run:   injectAndWait,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		// prepare spec
+		spec := v1beta1.ContainerFreezeSpec{}
+
+		// create injectors
+		for _, config := range configs {
+			injectors = append(injectors, injector.NewContainerFreezeInjector(spec, injector.ContainerFreezeInjectorConfig{Config: config}))
+		}
+	},
+}
@@ -0,0 +1,162 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/DataDog/chaos-controller/env"
+	"github.com/DataDog/chaos-controller/injector"
+	chaostypes "github.com/DataDog/chaos-controller/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// injectorControl exposes the injector process through the injector control API
+type injectorControl struct {
+	cmd         *cobra.Command
+	maxDeadline time.Time // end of the disruption duration, the deadline can't be moved past it
+}
+
+// startControlServer starts the injector control API server on the given port in the background,
+// protected by the token given by the controller
+func startControlServer(cmd *cobra.Command, port int) {
+	token := os.Getenv(env.InjectorControlToken)
+	if token == "" {
+		log.Errorw("no injector control API token given, the control API is not started", "env", env.InjectorControlToken)
+
+		return
+	}
+
+	server := &http.Server{
+		Addr:              fmt.Sprintf(":%d", port),
+		Handler:           injector.NewControlHandler(injectorControl{cmd: cmd, maxDeadline: getDeadline()}, token),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		log.Infow("starting the injector control API", "port", port)
+
+		if err := server.ListenAndServe(); err != nil {
+			log.Errorw("the injector control API stopped", "error", err)
+		}
+	}()
+}
+
+// State returns the current state of the injector process
+func (c injectorControl) State() injector.ControlState {
+	injectionLock.Lock()
+	defer injectionLock.Unlock()
+
+	state := injector.ControlState{
+		Kind:       chaostypes.DisruptionKindName(c.cmd.Name()),
+		Level:      disruptionArgs.Level,
+		TargetName: disruptionArgs.TargetName,
+		DryRun:     disruptionArgs.DryRun,
+		Injected:   injected,
+		Deadline:   getDeadline(),
+		Injectors:  []injector.ControlInjectorState{},
+		Result:     injectionResult,
+	}
+
+	if disruptionArgs.Level == chaostypes.DisruptionLevelNode {
+		state.TargetName = disruptionArgs.TargetNodeName
+	}
+
+	if disruptionArgs.PulseActiveDuration > 0 && disruptionArgs.PulseDormantDuration > 0 {
+		state.PulsePhase = injector.PulsePhaseDormant
+
+		if injected {
+			state.PulsePhase = injector.PulsePhaseInjected
+		}
+	}
+
+	// injectors all share the configuration given to the disruption kind subcommand
+	config := map[string]string{}
+
+	c.cmd.LocalNonPersistentFlags().VisitAll(func(f *pflag.Flag) {
+		config[f.Name] = f.Value.String()
+	})
+
+	for i, inj := range injectors {
+		injState := injector.ControlInjectorState{
+			Kind:   inj.GetDisruptionKind(),
+			Target: injector.UnknownTargetName,
+			Config: config,
+		}
+
+		if i < len(configs) {
+			injState.Target = configs[i].TargetName()
+		}
+
+		state.Injectors = append(state.Injectors, injState)
+	}
+
+	return state
+}
+
+// Clean cleans the injected disruptions without exiting, marking the chaos pod as not ready
+// until the disruptions are injected again
+func (c injectorControl) Clean() error {
+	injectionLock.Lock()
+	defer injectionLock.Unlock()
+
+	log.Info("cleaning the disruption on demand of the control API")
+
+	if ok := clean(c.cmd.Name(), true, false); !ok {
+		return fmt.Errorf("an injector could not clean the disruption successfully, please look at the injector logs for more details")
+	}
+
+	if err := os.Remove(readinessProbeFile); err != nil && !os.IsNotExist(err) {
+		log.Errorw("error removing readiness probe file", "error", err)
+	}
+
+	return nil
+}
+
+// Reinject cleans and injects the disruptions again, marking the chaos pod as ready on success
+func (c injectorControl) Reinject() error {
+	log.Info("reinjecting the disruption on demand of the control API")
+
+	if err := reinject(c.cmd.Name(), true); err != nil {
+		return err
+	}
+
+	if err := ioutil.WriteFile(readinessProbeFile, []byte("1"), 0o400); err != nil {
+		log.Errorw("error writing readiness probe file", "error", err)
+	}
+
+	return nil
+}
+
+// UpdateDeadline moves the disruption deadline, which can't go past the end of the disruption duration
+// given by the controller
+func (c injectorControl) UpdateDeadline(newDeadline time.Time) error {
+	if !newDeadline.After(time.Now()) {
+		return fmt.Errorf("the new deadline %s is in the past", newDeadline.Format(time.RFC3339))
+	}
+
+	if newDeadline.After(c.maxDeadline) {
+		return fmt.Errorf("the new deadline %s is after the end of the disruption duration (%s)", newDeadline.Format(time.RFC3339), c.maxDeadline.Format(time.RFC3339))
+	}
+
+	log.Infow("updating the disruption deadline on demand of the control API", "oldDeadline", getDeadline(), "newDeadline", newDeadline)
+
+	// configurations are used on reinjection, background processes started before keep their initial deadline
+	injectionLock.Lock()
+	for i := range configs {
+		configs[i].DisruptionDeadline = newDeadline
+	}
+	injectionLock.Unlock()
+
+	setDeadline(newDeadline)
+
+	return nil
+}
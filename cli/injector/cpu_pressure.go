@@ -6,10 +6,22 @@
 package main
 
 import (
+	"github.com/DataDog/chaos-controller/api/v1beta1"
 	"github.com/DataDog/chaos-controller/command"
 	"github.com/DataDog/chaos-controller/injector"
 	"github.com/DataDog/chaos-controller/process"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	targetUtilizationFlagName    = "target-utilization"
+	throttlingPercentageFlagName = "throttling-percentage"
+	profileFlagName              = "profile"
+	profileDurationFlagName      = "profile-duration"
+	profileStartFlagName         = "profile-start"
+	profileStepsFlagName         = "profile-steps"
 )
 
 var cpuPressureCmd = &cobra.Command{
@@ -18,6 +30,32 @@ var cpuPressureCmd = &cobra.Command{
 	Run:   injectAndWait,
 	PreRun: func(cmd *cobra.Command, args []string) {
 		countStr, _ := cmd.Flags().GetString("count")
+		targetUtilization, _ := cmd.Flags().GetInt(targetUtilizationFlagName)
+		throttlingPercentage, _ := cmd.Flags().GetInt(throttlingPercentageFlagName)
+
+		// throttling lowers the cpu quota of the targets instead of stressing them
+		if throttlingPercentage != 0 {
+			spec := v1beta1.CPUPressureThrottlingSpec{
+				Percentage: throttlingPercentage,
+			}
+
+			for _, config := range configs {
+				injectors = append(injectors, injector.NewCPUThrottlingInjector(spec, injector.CPUThrottlingInjectorConfig{Config: config}))
+			}
+
+			return
+		}
+
+		spec := v1beta1.CPUPressureSpec{
+			Profile: getCPUPressureProfile(cmd.Flags()),
+		}
+
+		if targetUtilization != 0 {
+			spec.TargetUtilization = &targetUtilization
+		} else {
+			count := intstr.Parse(countStr)
+			spec.Count = &count
+		}
 
 		cmdFactory := command.NewFactory(disruptionArgs.DryRun)
 		processManager := process.NewManager(disruptionArgs.DryRun)
@@ -29,7 +67,7 @@ var cpuPressureCmd = &cobra.Command{
 				injectors,
 				injector.NewCPUPressureInjector(
 					config,
-					countStr,
+					spec,
 					injectorCmdFactory,
 					cpuStressArgsBuilder,
 				),
@@ -40,4 +78,34 @@ var cpuPressureCmd = &cobra.Command{
 
 func init() {
 	cpuPressureCmd.Flags().String("count", "", "number of cpus to target, either an integer form or a percentage form appended with a %")
+	cpuPressureCmd.Flags().Int(targetUtilizationFlagName, 0, "percentage of time each cpu should be kept busy, the stress adapting to the load of the target")
+	cpuPressureCmd.Flags().Int(throttlingPercentageFlagName, 0, "percentage of the cpu limit to keep, lowering the cpu quota of the target instead of stressing it")
+	addCPUPressureProfileFlags(cpuPressureCmd.Flags())
+}
+
+// addCPUPressureProfileFlags adds the flags describing the variation of the stress level over time
+func addCPUPressureProfileFlags(flags *pflag.FlagSet) {
+	flags.String(profileFlagName, "", "profile of the stress level over time (ramp, sine or step)")
+	flags.Duration(profileDurationFlagName, 0, "ramp duration, sine period or duration of each step of the profile")
+	flags.Int(profileStartFlagName, 0, "stress level percentage the profile starts from")
+	flags.Int(profileStepsFlagName, 0, "number of steps of a step profile")
+}
+
+// getCPUPressureProfile returns the profile described by the flags, nil if none
+func getCPUPressureProfile(flags *pflag.FlagSet) *v1beta1.CPUPressureProfileSpec {
+	profileType, _ := flags.GetString(profileFlagName)
+	if profileType == "" {
+		return nil
+	}
+
+	duration, _ := flags.GetDuration(profileDurationFlagName)
+	start, _ := flags.GetInt(profileStartFlagName)
+	steps, _ := flags.GetInt(profileStepsFlagName)
+
+	return &v1beta1.CPUPressureProfileSpec{
+		Type:     v1beta1.CPUPressureProfileType(profileType),
+		Duration: v1beta1.DisruptionDuration(duration.String()),
+		Start:    start,
+		Steps:    steps,
+	}
 }
@@ -29,9 +29,16 @@ var cpuPressureStressCmd = &cobra.Command{
 		config := configs[0]
 
 		percentage, _ := cmd.Flags().GetInt(percentageFlagName)
+		targetUtilization, _ := cmd.Flags().GetInt(targetUtilizationFlagName)
 
-		log = log.With("percentage", percentage)
-		log.Infow("stressing every CPU allocated to target", "disruption_target", config.TargetName())
+		load := injector.CPUStressLoad{
+			Percentage:        percentage,
+			TargetUtilization: targetUtilization,
+			Profile:           getCPUPressureProfile(cmd.Flags()),
+		}
+
+		log = log.With("percentage", percentage, "target_utilization", targetUtilization)
+		log.Infow("stressing every CPU allocated to target", "disruption_target", config.TargetName(), "profile", load.Profile)
 
 		runtime := process.NewRuntime(config.Disruption.DryRun)
 		process := process.NewManager(config.Disruption.DryRun)
@@ -40,9 +47,10 @@ var cpuPressureStressCmd = &cobra.Command{
 			injectors,
 			injector.NewCPUStressInjector(
 				config,
-				percentage,
+				load,
 				process,
 				runtime,
+				injector.NewCPUUsageReader(),
 			))
 
 		return nil
@@ -51,13 +59,25 @@ var cpuPressureStressCmd = &cobra.Command{
 
 func init() {
 	cpuPressureStressCmd.Flags().Int(percentageFlagName, 100, "percentage of stress to perform on a single cpu")
+	cpuPressureStressCmd.Flags().Int(targetUtilizationFlagName, 0, "percentage of time each cpu should be kept busy, the stress adapting to the load of the cpu")
+	addCPUPressureProfileFlags(cpuPressureStressCmd.Flags())
 }
 
 type cpuStressArgsBuilder struct{}
 
-func (c cpuStressArgsBuilder) GenerateArgs(percentage int) []string {
-	return []string{
+func (c cpuStressArgsBuilder) GenerateArgs(load injector.CPUStressLoad) []string {
+	args := []string{
 		cpuStressCommandName,
-		fmt.Sprintf("--%s=%d", percentageFlagName, percentage),
+		fmt.Sprintf("--%s=%d", percentageFlagName, load.Percentage),
+	}
+
+	if load.TargetUtilization != 0 {
+		args = append(args, fmt.Sprintf("--%s=%d", targetUtilizationFlagName, load.TargetUtilization))
 	}
+
+	if load.Profile != nil {
+		args = append(args, load.Profile.GenerateArgs()...)
+	}
+
+	return args
 }
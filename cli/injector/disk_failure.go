@@ -20,11 +20,22 @@ var diskFailureCmd = &cobra.Command{
 	Run:   injectAndWait,
 	PreRun: func(cmd *cobra.Command, args []string) {
 		path, _ := cmd.Flags().GetString("path")
+		rawPathPatterns, _ := cmd.Flags().GetStringArray("path-pattern")
 
 		spec := v1beta1.DiskFailureSpec{
 			Path: path,
 		}
 
+		// parse path patterns
+		for _, rawPathPattern := range rawPathPatterns {
+			pathPattern, err := v1beta1.ParseDiskFailurePathPattern(rawPathPattern)
+			if err != nil {
+				log.Fatalw("error parsing the disk failure path pattern", "error", err)
+			}
+
+			spec.Paths = append(spec.Paths, pathPattern)
+		}
+
 		// create injectors
 		for _, config := range configs {
 			inj, err := injector.NewDiskFailureInjector(spec, injector.DiskFailureInjectorConfig{Config: config})
@@ -47,5 +58,6 @@ var diskFailureCmd = &cobra.Command{
 }
 
 func init() {
-	diskFailureCmd.Flags().String("path", "", "Path to apply the disk failure")
+	diskFailureCmd.Flags().String("path", "", "Path prefix to apply the disk failure")
+	diskFailureCmd.Flags().StringArray("path-pattern", []string{}, "Path pattern to apply the disk failure, formatted as <prefix|exact|suffix>:<pattern> (can be repeated)")
 }
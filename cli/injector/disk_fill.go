@@ -0,0 +1,66 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package main
+
+import (
+	"errors"
+	"os"
+
+	"github.com/DataDog/chaos-controller/api/v1beta1"
+	"github.com/DataDog/chaos-controller/injector"
+	"github.com/spf13/cobra"
+)
+
+var diskFillCmd = &cobra.Command{
+	Use:   "disk-fill",
+	Short: "Disk fill subcommands",
+	Run:   injectAndWait,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		path, _ := cmd.Flags().GetString("path")
+		percentage, _ := cmd.Flags().GetInt("percentage")
+		remainingBytes, _ := cmd.Flags().GetInt("remaining-bytes")
+
+		// prepare spec
+		spec := v1beta1.DiskFillSpec{
+			Path: path,
+		}
+
+		if cmd.Flags().Changed("percentage") {
+			spec.Percentage = &percentage
+		}
+
+		if cmd.Flags().Changed("remaining-bytes") {
+			spec.RemainingBytes = &remainingBytes
+		}
+
+		// create injectors
+		for _, config := range configs {
+			inj, err := injector.NewDiskFillInjector(spec, injector.DiskFillInjectorConfig{Config: config})
+			if err != nil {
+				if errors.Is(errors.Unwrap(err), os.ErrNotExist) {
+					log.Errorw("error initializing the disk fill injector because the given path does not exist", "error", err)
+				} else {
+					log.Fatalw("error initializing the disk fill injector", "error", err)
+				}
+			}
+
+			if inj == nil {
+				log.Debugln("skipping this injector because path cannot be found on specified container")
+				continue
+			}
+
+			injectors = append(injectors, inj)
+		}
+	},
+}
+
+func init() {
+	diskFillCmd.Flags().String("path", "", "Path to fill the filesystem of")
+	diskFillCmd.Flags().Int("percentage", 0, "Used space percentage of the filesystem to reach")
+	diskFillCmd.Flags().Int("remaining-bytes", 0, "Available bytes to leave on the filesystem")
+
+	_ = cobra.MarkFlagRequired(diskFillCmd.PersistentFlags(), "path")
+}
@@ -22,6 +22,9 @@ var diskPressureCmd = &cobra.Command{
 		path, _ := cmd.Flags().GetString("path")
 		writeBytesPerSec, _ := cmd.Flags().GetInt("write-bytes-per-sec")
 		readBytesPerSec, _ := cmd.Flags().GetInt("read-bytes-per-sec")
+		writeIOPS, _ := cmd.Flags().GetInt("write-iops")
+		readIOPS, _ := cmd.Flags().GetInt("read-iops")
+		latencyTarget, _ := cmd.Flags().GetDuration("latency-target")
 
 		// prepare spec
 		var writeBytesPerSecP *int
@@ -34,11 +37,29 @@ var diskPressureCmd = &cobra.Command{
 			readBytesPerSecP = &readBytesPerSec
 		}
 
+		var writeIOPSP *int
+		if writeIOPS != 0 {
+			writeIOPSP = &writeIOPS
+		}
+
+		var readIOPSP *int
+		if readIOPS != 0 {
+			readIOPSP = &readIOPS
+		}
+
+		var latencyTargetD v1beta1.DisruptionDuration
+		if latencyTarget != 0 {
+			latencyTargetD = v1beta1.DisruptionDuration(latencyTarget.String())
+		}
+
 		spec := v1beta1.DiskPressureSpec{
 			Path: path,
 			Throttling: v1beta1.DiskPressureThrottlingSpec{
 				ReadBytesPerSec:  readBytesPerSecP,
 				WriteBytesPerSec: writeBytesPerSecP,
+				ReadIOPS:         readIOPSP,
+				WriteIOPS:        writeIOPSP,
+				LatencyTarget:    latencyTargetD,
 			},
 		}
 
@@ -67,6 +88,9 @@ func init() {
 	diskPressureCmd.Flags().String("path", "", "Path to apply/clean disk pressure to/from (will be applied to the whole disk)")
 	diskPressureCmd.Flags().Int("write-bytes-per-sec", 0, "Bytes per second throttling limit")
 	diskPressureCmd.Flags().Int("read-bytes-per-sec", 0, "Bytes per second throttling limit")
+	diskPressureCmd.Flags().Int("write-iops", 0, "IO operations per second throttling limit")
+	diskPressureCmd.Flags().Int("read-iops", 0, "IO operations per second throttling limit")
+	diskPressureCmd.Flags().Duration("latency-target", 0, "IO latency target (cgroups v2 only)")
 
 	_ = cobra.MarkFlagRequired(diskPressureCmd.PersistentFlags(), "path")
 }
@@ -7,11 +7,13 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"os/signal"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -67,6 +69,18 @@ var (
 	readyToInject       bool
 	clientset           *kubernetes.Clientset
 	deadline            time.Time
+	deadlineLock        sync.Mutex
+	deadlineUpdated     = make(chan struct{}, 1) // notifies the loops waiting for the deadline that it has been updated
+	verifyInterval      time.Duration
+	controlPort         int
+	injectionLock       sync.Mutex // prevents the injection from being verified while it is reinjected or cleaned
+	injected            bool       // whether the disruption is currently injected, protected by the injection lock
+	injectionResult     v1beta1.InjectionResult
+	pulseSeed           int64
+	pulseScheduler      *injector.PulseScheduler
+	journalPath         string
+	journal             injector.Journal
+	pulseStateEnd       time.Time // end of the current active or dormant state of a pulsing disruption
 )
 
 func init() {
@@ -80,6 +94,11 @@ func init() {
 		panic(err)
 	}
 
+	pulseAnchorFlag, err := pflag.NewTimeWithFormat(time.RFC3339, &disruptionArgs.PulseAnchor)
+	if err != nil {
+		panic(err)
+	}
+
 	rootCmd.AddCommand(networkDisruptionCmd)
 	rootCmd.AddCommand(nodeFailureCmd)
 	rootCmd.AddCommand(containerFailureCmd)
@@ -87,6 +106,9 @@ func init() {
 	rootCmd.AddCommand(cpuPressureStressCmd)
 	rootCmd.AddCommand(diskFailureCmd)
 	rootCmd.AddCommand(diskPressureCmd)
+	rootCmd.AddCommand(diskFillCmd)
+	rootCmd.AddCommand(processFailureCmd)
+	rootCmd.AddCommand(containerFreezeCmd)
 	rootCmd.AddCommand(dnsDisruptionCmd)
 	rootCmd.AddCommand(grpcDisruptionCmd)
 
@@ -100,12 +122,20 @@ func init() {
 	rootCmd.PersistentFlags().DurationVar(&disruptionArgs.PulseInitialDelay, "pulse-initial-delay", time.Duration(0), "Duration to wait after injector starts before beginning the activeDuration")
 	rootCmd.PersistentFlags().DurationVar(&disruptionArgs.PulseActiveDuration, "pulse-active-duration", time.Duration(0), "Duration of the disruption being active in a pulsing disruption (empty if the disruption is not pulsing)")
 	rootCmd.PersistentFlags().DurationVar(&disruptionArgs.PulseDormantDuration, "pulse-dormant-duration", time.Duration(0), "Duration of the disruption being dormant in a pulsing disruption (empty if the disruption is not pulsing)")
+	rootCmd.PersistentFlags().DurationVar(&disruptionArgs.PulseMaxActiveDuration, "pulse-max-active-duration", time.Duration(0), "Maximum duration of the disruption being active in a pulsing disruption, randomizing the active duration from the pulse active duration (empty for a fixed active duration)")
+	rootCmd.PersistentFlags().DurationVar(&disruptionArgs.PulseMaxDormantDuration, "pulse-max-dormant-duration", time.Duration(0), "Maximum duration of the disruption being dormant in a pulsing disruption, randomizing the dormant duration from the pulse dormant duration (empty for a fixed dormant duration)")
+	rootCmd.PersistentFlags().Int64Var(&pulseSeed, "pulse-seed", 0, "Seed of the random pulse durations (a random seed is used if not set)")
+	rootCmd.PersistentFlags().Var(pulseAnchorFlag, "pulse-anchor", "RFC3339 time at which the first active state of a pulsing disruption starts, shared by all the chaos pods of the disruption so they pulse in sync (replaces the initial delay)")
 	rootCmd.PersistentFlags().Var(notInjectedBeforeFlag, "not-injected-before", "")
 	rootCmd.PersistentFlags().Var(deadlineFlag, string(injector.DeadlineFlag), "RFC3339 time at which the disruption must be over by")
 	rootCmd.PersistentFlags().StringVar(&disruptionArgs.DNSServer, "dns-server", "8.8.8.8", "IP address of the upstream DNS server")
 	rootCmd.PersistentFlags().StringVar(&disruptionArgs.KubeDNS, "kube-dns", "off", "Whether to use kube-dns for DNS resolution (off, internal, all)")
+	rootCmd.PersistentFlags().BoolVar(&disruptionArgs.AllowRootDiskFill, "allow-root-filesystem", false, "Allow disk fill disruptions to fill the host root filesystem")
 	rootCmd.PersistentFlags().StringVar(&disruptionArgs.ChaosNamespace, "chaos-namespace", "chaos-engineering", "Namespace that contains this chaos pod")
 	rootCmd.PersistentFlags().Uint32Var(&parentPID, string(injector.ParentPIDFlag), 0, "Parent process PID")
+	rootCmd.PersistentFlags().DurationVar(&verifyInterval, "verify-interval", 30*time.Second, "Interval at which the injected disruption is verified to still be in effect (0 to disable the verification)")
+	rootCmd.PersistentFlags().IntVar(&controlPort, "control-port", 0, "Port of the injector control API exposing the injector state and operations (0 to disable the control API)")
+	rootCmd.PersistentFlags().StringVar(&journalPath, "journal-path", injector.JournalDirectory, "Directory of the node journal recording the injected changes so they can be reverted by the sweeper if the chaos pod dies before cleaning them (empty to disable the journal)")
 
 	// log context args
 	rootCmd.PersistentFlags().StringVar(&disruptionArgs.DisruptionName, "log-context-disruption-name", "", "Log value: current disruption name")
@@ -114,10 +144,15 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&disruptionArgs.TargetNodeName, "log-context-target-node-name", "", "Log value: node hosting the current target pod")
 
 	_ = cobra.MarkFlagRequired(rootCmd.PersistentFlags(), "level")
-	cobra.OnInitialize(initLogger)
-	cobra.OnInitialize(initMetricsSink)
-	cobra.OnInitialize(initConfig)
-	cobra.OnInitialize(initExitSignalsHandler)
+
+	// initialize the disruption commands only, the sweeper having its own initialization
+	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+		initLogger()
+		initMetricsSink()
+		initConfig()
+		initJournal()
+		initExitSignalsHandler()
+	}
 }
 
 func main() {
@@ -130,8 +165,16 @@ func main() {
 		}
 	}()
 
+	// the sweeper is a distinct mode of the injector binary which doesn't share the disruption flags
+	cmd := rootCmd
+	if len(os.Args) > 1 && os.Args[1] == sweeperCmd.Name() {
+		sweeperCmd.SetArgs(os.Args[2:])
+
+		cmd = sweeperCmd
+	}
+
 	// execute command
-	if err := rootCmd.Execute(); err != nil {
+	if err := cmd.Execute(); err != nil {
 		os.Exit(1) //nolint:gocritic
 	}
 }
@@ -178,19 +221,8 @@ func initManagers(pid uint32) (netns.Manager, cgroup.Manager, error) {
 		return nil, nil, fmt.Errorf("error creating network namespace manager: %w", err)
 	}
 
-	// retrieve cgroup mount path set in env or fallback to the default value
-	cgroupMount := ""
-	if mount, exists := os.LookupEnv(env.InjectorMountCgroup); exists {
-		cgroupMount = mount
-	} else {
-		// process ID 1 is usually the init process primarily responsible for starting and shutting down the system
-		// originally, process ID 1 was not specifically reserved for init by any technical measures
-		// it simply had this ID as a natural consequence of being the first process invoked by the kernel
-		cgroupMount = "/proc/1/root/sys/fs/cgroup"
-	}
-
 	// create cgroups manager
-	cgroupMgr, err := cgroup.NewManager(disruptionArgs.DryRun, pid, cgroupMount, log)
+	cgroupMgr, err := cgroup.NewManager(disruptionArgs.DryRun, pid, cgroupMountPath(), log)
 	if err != nil {
 		return nil, nil, fmt.Errorf("error creating cgroup manager: %w", err)
 	}
@@ -198,6 +230,18 @@ func initManagers(pid uint32) (netns.Manager, cgroup.Manager, error) {
 	return netnsMgr, cgroupMgr, nil
 }
 
+// cgroupMountPath returns the cgroup mount path set in env or fallback to the default value
+func cgroupMountPath() string {
+	if mount, exists := os.LookupEnv(env.InjectorMountCgroup); exists {
+		return mount
+	}
+
+	// process ID 1 is usually the init process primarily responsible for starting and shutting down the system
+	// originally, process ID 1 was not specifically reserved for init by any technical measures
+	// it simply had this ID as a natural consequence of being the first process invoked by the kernel
+	return "/proc/1/root/sys/fs/cgroup"
+}
+
 // initConfig initializes the injector config and main components from the given flags
 func initConfig() {
 	pids := []uint32{}
@@ -314,6 +358,94 @@ func initConfig() {
 	readyToInject = true
 }
 
+// initJournal initializes the node journal recording the injected changes, only used by the main process
+// as child processes share the chaos pod name and don't change the target kernel state
+func initJournal() {
+	journal = injector.NewNoopJournal()
+
+	if journalPath == "" || disruptionArgs.DryRun || parentPID != 0 {
+		return
+	}
+
+	if os.Getenv(env.InjectorPodName) == "" {
+		log.Warnw("the chaos pod name is unknown, the injected changes won't be journaled", "env", env.InjectorPodName)
+
+		return
+	}
+
+	fileJournal, err := injector.NewFileJournal(journalPath)
+	if err != nil {
+		log.Errorw("error creating the journal, the injected changes won't be journaled", "error", err, "path", journalPath)
+
+		return
+	}
+
+	journal = fileJournal
+}
+
+// recordJournal records the changes applied by the injector of the given configuration index in the journal
+func recordJournal(index int, inj injector.Injector) {
+	journaled, ok := inj.(injector.Journaled)
+	if !ok || index >= len(configs) {
+		return
+	}
+
+	// some injectors only apply changes depending on their spec (e.g. a process failure only stopping processes with SIGSTOP)
+	changes := journaled.JournalChanges()
+	if len(changes) == 0 {
+		return
+	}
+
+	pid := uint32(1)
+	if configs[index].TargetContainer != nil {
+		pid = configs[index].TargetContainer.PID()
+	}
+
+	target, err := injector.NewJournalTarget(os.Getenv(env.InjectorMountProc), pid)
+	if err != nil {
+		log.Errorw("error identifying the target to journal the injected changes", "error", err, "pid", pid)
+
+		return
+	}
+
+	// the chaos pod shares the host PID namespace, so the injector is identified by its host PID
+	injectorProcess, err := injector.NewJournalTarget(os.Getenv(env.InjectorMountProc), uint32(os.Getpid()))
+	if err != nil {
+		log.Errorw("error identifying the injector process to journal the injected changes", "error", err, "pid", os.Getpid())
+
+		return
+	}
+
+	entry := injector.JournalEntry{
+		ID:                  journalEntryID(index),
+		DisruptionName:      disruptionArgs.DisruptionName,
+		DisruptionNamespace: disruptionArgs.DisruptionNamespace,
+		ChaosNamespace:      disruptionArgs.ChaosNamespace,
+		ChaosPodName:        os.Getenv(env.InjectorPodName),
+		Kind:                inj.GetDisruptionKind(),
+		TargetName:          configs[index].TargetName(),
+		Target:              target,
+		Changes:             changes,
+		RecordedAt:          time.Now(),
+		Injector:            &injectorProcess,
+	}
+
+	if err := journal.Record(entry); err != nil {
+		log.Errorw("error journaling the injected changes", "error", err)
+	}
+}
+
+// removeJournal removes the changes cleaned by the injector of the given configuration index from the journal
+func removeJournal(index int) {
+	if err := journal.Remove(journalEntryID(index)); err != nil {
+		log.Errorw("error removing the cleaned changes from the journal", "error", err)
+	}
+}
+
+func journalEntryID(index int) string {
+	return fmt.Sprintf("%s-%d", os.Getenv(env.InjectorPodName), index)
+}
+
 // initExitSignalsHandler initializes the exit signal handler
 func initExitSignalsHandler() {
 	// signals needs to be a buffered channel, so that we can receive a signal anytime during the injection process
@@ -337,13 +469,23 @@ func initPodWatch(resourceVersion string) (<-chan watch.Event, error) {
 // inject all the disruptions using the list of injectors
 // returns true if injection succeeded, false otherwise
 func inject(kind string, sendToMetrics bool, reinjection bool) bool {
+	injectErrs := []string{}
+
 	errOnInject := false
 
-	for _, inj := range injectors {
+	for i, inj := range injectors {
+		// journal the changes before and after the injection so they are known even if the injection is interrupted
+		recordJournal(i, inj)
+
 		// start injection, do not fatal on error so we keep the pod
 		// running, allowing the cleanup to happen
-		if err := inj.Inject(); err != nil {
+		err := inj.Inject()
+
+		recordJournal(i, inj)
+
+		if err != nil {
 			errOnInject = true
+			injectErrs = append(injectErrs, err.Error())
 
 			if sendToMetrics {
 				if reinjection {
@@ -375,13 +517,51 @@ func inject(kind string, sendToMetrics bool, reinjection bool) bool {
 		log.Errorf("an injector could not inject the disruption successfully, please look at the logs above for more details")
 	}
 
+	// a partially injected disruption is still considered injected so it gets cleaned
+	injected = true
+
+	// report the injection result to the controller
+	if errOnInject {
+		injectionResult.Success = false
+		injectionResult.Error = "injection failed: " + strings.Join(injectErrs, "; ")
+	} else {
+		now := metav1.Now()
+		injectionResult.Success = true
+		injectionResult.Error = ""
+		injectionResult.InjectedAt = &now
+
+		if reinjection {
+			injectionResult.ReinjectionCount++
+		}
+	}
+
+	for _, inj := range injectors {
+		if resolver, ok := inj.(injector.HostsResolver); ok {
+			for host, ips := range resolver.ResolvedHosts() {
+				if injectionResult.ResolvedHosts == nil {
+					injectionResult.ResolvedHosts = map[string][]string{}
+				}
+
+				injectionResult.ResolvedHosts[host] = ips
+			}
+		}
+	}
+
+	reportInjectionResult()
+
 	return !errOnInject
 }
 
 // reinject reinitialize conf, clean and inject all the disruptions
-func reinject(cmdName string) error {
+// a disruption which is not injected (dormant pulse or cleaned through the control API) is only injected again if forced
+func reinject(cmdName string, force bool) error {
 	var err error
 
+	injectionLock.Lock()
+	defer injectionLock.Unlock()
+
+	wasInjected := injected
+
 	// Clean all injections to reinject on an empty slate
 	if ok := clean(cmdName, true, true); !ok {
 		log.Errorw("couldn't clean targets before reinjection. Reinjecting anyway")
@@ -410,6 +590,15 @@ func reinject(cmdName string) error {
 		conf.Disruption = disruptionArgs
 
 		injectors[i].UpdateConfig(conf)
+
+		// keep the configuration up to date so the journal identifies the new target
+		configs[i] = conf
+	}
+
+	if !wasInjected && !force {
+		log.Infow("disruption is not injected, skipping the reinjection", "kind", cmdName)
+
+		return nil
 	}
 
 	// Reinject target
@@ -423,12 +612,15 @@ func reinject(cmdName string) error {
 // clean will remove or undo all the disruptions using the list of injectors
 // returns true if cleanup succeeded, false otherwise
 func clean(kind string, sendToMetrics bool, reinjectionClean bool) bool {
+	cleanErrs := []string{}
+
 	errOnClean := false
 
-	for _, inj := range injectors {
+	for i, inj := range injectors {
 		// start cleanup which is retried up to 3 times using an exponential backoff algorithm
 		if err := backoff.RetryNotify(inj.Clean, backoff.WithMaxRetries(backoff.NewExponentialBackOff(), 3), retryNotifyHandler); err != nil {
 			errOnClean = true
+			cleanErrs = append(cleanErrs, err.Error())
 
 			if sendToMetrics {
 				if reinjectionClean {
@@ -441,6 +633,8 @@ func clean(kind string, sendToMetrics bool, reinjectionClean bool) bool {
 			log.Errorw("disruption cleaning failed", "error", err)
 		} else {
 			log.Infof("disruption %s cleaned", inj.GetDisruptionKind())
+
+			removeJournal(i)
 		}
 
 		if sendToMetrics {
@@ -454,33 +648,168 @@ func clean(kind string, sendToMetrics bool, reinjectionClean bool) bool {
 
 	if errOnClean {
 		log.Errorw("an injector could not clean the disruption successfully, please look at the logs above for more details")
+	} else {
+		injected = false
+	}
+
+	// report the cleanup result to the controller, cleanups before a reinjection being only reported on failure
+	if errOnClean {
+		injectionResult.Success = false
+		injectionResult.Error = "cleanup failed: " + strings.Join(cleanErrs, "; ")
+
+		reportInjectionResult()
+	} else if !reinjectionClean {
+		now := metav1.Now()
+		injectionResult.CleanedAt = &now
+
+		reportInjectionResult()
 	}
 
 	return !errOnClean
 }
 
+// reportInjectionResult reports the injection result in the chaos pod annotations
+// so the controller can surface it in the disruption status
+func reportInjectionResult() {
+	// child processes share the chaos pod of their parent which reports its own result
+	if parentPID != 0 || len(configs) == 0 {
+		return
+	}
+
+	rawResult, err := json.Marshal(injectionResult)
+	if err != nil {
+		log.Errorw("error marshaling the injection result", "error", err)
+
+		return
+	}
+
+	report := func() error {
+		pod, err := configs[0].K8sClient.CoreV1().Pods(disruptionArgs.ChaosNamespace).Get(context.Background(), os.Getenv(env.InjectorPodName), metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		if pod.Annotations == nil {
+			pod.Annotations = map[string]string{}
+		}
+
+		pod.Annotations[chaostypes.InjectionResultAnnotation] = string(rawResult)
+
+		_, err = configs[0].K8sClient.CoreV1().Pods(disruptionArgs.ChaosNamespace).Update(context.Background(), pod, metav1.UpdateOptions{})
+
+		return err
+	}
+
+	if err := backoff.Retry(report, backoff.WithMaxRetries(backoff.NewExponentialBackOff(), 3)); err != nil {
+		log.Warnw("couldn't report the injection result in this pod's annotations", "pod", os.Getenv(env.InjectorPodName), "error", err)
+	}
+}
+
+// verify reads back the state of the disruptions injected by the injectors implementing the injector.Verifier interface
+// returns an error if one of them is not in effect anymore
+func verify() error {
+	for _, inj := range injectors {
+		verifier, ok := inj.(injector.Verifier)
+		if !ok {
+			continue
+		}
+
+		if err := verifier.Verify(); err != nil {
+			return fmt.Errorf("disruption %s is not in effect anymore: %w", inj.GetDisruptionKind(), err)
+		}
+	}
+
+	return nil
+}
+
+// verifyInjection verifies the injection at the given interval, removing the readiness probe file when it drifted
+// so the chaos pod is marked as not ready, and writing it back once the injection is in effect again
+func verifyInjection(interval time.Duration) {
+	drifted := false
+
+	for range time.Tick(interval) {
+		injectionLock.Lock()
+
+		// the disruption can have been cleaned on purpose, while a pulse is dormant or through the control API,
+		// it is verified again once injected back
+		if !injected {
+			injectionLock.Unlock()
+
+			if drifted {
+				if err := ioutil.WriteFile(readinessProbeFile, []byte("1"), 0o400); err != nil {
+					log.Errorw("error writing readiness probe file", "error", err)
+				}
+
+				drifted = false
+			}
+
+			continue
+		}
+
+		err := verify()
+		injectionLock.Unlock()
+
+		switch {
+		case err != nil && !drifted:
+			log.Errorw("the injected disruption drifted, marking the chaos pod as not ready", "error", err)
+
+			if err := os.Remove(readinessProbeFile); err != nil && !os.IsNotExist(err) {
+				log.Errorw("error removing readiness probe file", "error", err)
+			}
+		case err == nil && drifted:
+			log.Info("the injected disruption is in effect again, marking the chaos pod as ready")
+
+			if err := ioutil.WriteFile(readinessProbeFile, []byte("1"), 0o400); err != nil {
+				log.Errorw("error writing readiness probe file", "error", err)
+			}
+		}
+
+		drifted = err != nil
+	}
+}
+
+// initPulseScheduler initializes the scheduler of the pulse durations, seeded with the given seed if any
+// or with a random one otherwise which is logged so the pulse durations can be reproduced
+func initPulseScheduler(seeded bool) {
+	if !seeded {
+		pulseSeed = time.Now().UnixNano()
+	}
+
+	pulseScheduler = injector.NewPulseScheduler(disruptionArgs.PulseActiveDuration, disruptionArgs.PulseMaxActiveDuration, disruptionArgs.PulseDormantDuration, disruptionArgs.PulseMaxDormantDuration, pulseSeed)
+
+	if disruptionArgs.PulseMaxActiveDuration > 0 || disruptionArgs.PulseMaxDormantDuration > 0 {
+		log.Infow("pulse durations are randomized", "seed", pulseSeed, "minActiveDuration", disruptionArgs.PulseActiveDuration, "maxActiveDuration", disruptionArgs.PulseMaxActiveDuration, "minDormantDuration", disruptionArgs.PulseDormantDuration, "maxDormantDuration", disruptionArgs.PulseMaxDormantDuration)
+	}
+}
+
 // pulse pulse disruptions (injection and cleaning)
 // nolint: unparam,staticcheck
 func pulse(isInjected *bool, sleepDuration *time.Duration, action func(string, bool, bool) bool, cmdName string) (func(string, bool, bool) bool, error) {
+	injectionLock.Lock()
+	defer injectionLock.Unlock()
+
 	actionName := ""
 
+	// the end of the next state is computed from the end of the current one so the pulse doesn't drift
+	// with the time spent injecting and cleaning, and stays in sync with the other chaos pods
 	if !*isInjected {
 		action = inject
 		actionName = "inject"
-		*sleepDuration = disruptionArgs.PulseDormantDuration
+		pulseStateEnd = pulseStateEnd.Add(pulseScheduler.NextActive())
 	} else {
 		action = clean
 		actionName = "clean"
-		*sleepDuration = disruptionArgs.PulseActiveDuration
+		pulseStateEnd = pulseStateEnd.Add(pulseScheduler.NextDormant())
 	}
 
+	*sleepDuration = time.Until(pulseStateEnd)
+
+	log.Debugw("pulsing the disruption", "action", actionName, "nextPulseAt", pulseStateEnd)
+
 	if ok := action(cmdName, true, true); !ok {
 		return nil, fmt.Errorf("error on pulsing disruption mechanism when attempting to %s", actionName)
 	}
 
-	newInjected := !*isInjected
-	*isInjected = newInjected
-
 	return action, nil
 }
 
@@ -506,7 +835,23 @@ func injectAndWait(cmd *cobra.Command, args []string) {
 		}
 	}
 
-	if disruptionArgs.PulseInitialDelay > 0 {
+	// child processes are started by their parent when it injects the disruption, they must inject it right away
+	pulsing := parentPID == 0 && disruptionArgs.PulseActiveDuration > 0 && disruptionArgs.PulseDormantDuration > 0
+
+	// the pulse anchor already includes the initial delay
+	if pulsing && !disruptionArgs.PulseAnchor.IsZero() {
+		if time.Now().Before(disruptionArgs.PulseAnchor) {
+			log.Infow("waiting for the pulse anchor", "pulseAnchor", disruptionArgs.PulseAnchor)
+			select {
+			case <-time.After(time.Until(disruptionArgs.PulseAnchor)):
+				break
+			case sig := <-signals:
+				log.Infow("an exit signal has been received", "signal", sig.String())
+
+				return
+			}
+		}
+	} else if parentPID == 0 && disruptionArgs.PulseInitialDelay > 0 {
 		log.Infow("waiting for initialDelay to pass", "initialDelay", disruptionArgs.PulseInitialDelay)
 		select {
 		case <-time.After(disruptionArgs.PulseInitialDelay):
@@ -520,9 +865,39 @@ func injectAndWait(cmd *cobra.Command, args []string) {
 
 	processManager := process.NewManager(disruptionArgs.DryRun)
 
-	log.Infow("injecting the disruption", "kind", cmd.Name())
+	// a chaos pod starting after the pulse anchor joins the pulse in its current state
+	startActive := true
+
+	if pulsing {
+		initPulseScheduler(cmd.Flags().Changed("pulse-seed"))
+
+		anchor := disruptionArgs.PulseAnchor
+		if anchor.IsZero() {
+			anchor = time.Now()
+		}
+
+		startActive, pulseStateEnd = pulseScheduler.Sync(anchor, time.Now())
+
+		log.Infow("pulse synchronized", "pulseAnchor", anchor, "active", startActive, "stateEnd", pulseStateEnd)
+	}
+
+	if controlPort > 0 && parentPID == 0 {
+		startControlServer(cmd, controlPort)
+	}
+
+	var injectSuccess bool
 
-	injectSuccess := inject(cmd.Name(), true, false)
+	if startActive {
+		log.Infow("injecting the disruption", "kind", cmd.Name())
+
+		injectionLock.Lock()
+		injectSuccess = inject(cmd.Name(), true, false)
+		injectionLock.Unlock()
+	} else {
+		log.Infow("the pulse is dormant, the disruption will be injected with the next active state", "kind", cmd.Name(), "nextPulseAt", pulseStateEnd)
+
+		injectSuccess = true
+	}
 
 	// create and write readiness probe file if injection succeeded so the pod is marked as ready
 	if injectSuccess {
@@ -531,6 +906,11 @@ func injectAndWait(cmd *cobra.Command, args []string) {
 		if err := ioutil.WriteFile(readinessProbeFile, []byte("1"), 0o400); err != nil {
 			log.Errorw("error writing readiness probe file", "error", err)
 		}
+
+		// dry-run disruptions don't inject anything to read back, pulsing ones are only verified while active
+		if verifyInterval > 0 && !disruptionArgs.DryRun {
+			go verifyInjection(verifyInterval)
+		}
 	}
 
 	// once injected, send a signal to the handler container so it can exit and let other containers go on
@@ -557,14 +937,13 @@ func injectAndWait(cmd *cobra.Command, args []string) {
 	// those disruptions should not watch target to re-inject on container restart
 	case v1beta1.DisruptionIsNotReinjectable((chaostypes.DisruptionKindName)(cmd.Name())):
 	case disruptionArgs.Level == chaostypes.DisruptionLevelNode:
-		if disruptionArgs.PulseActiveDuration > 0 && disruptionArgs.PulseDormantDuration > 0 {
+		if pulsing {
 			var (
 				action func(string, bool, bool) bool
 				err    error
 			)
 
-			isInjected := true
-			sleepDuration := disruptionArgs.PulseActiveDuration
+			var sleepDuration time.Duration
 
 			// using a label for the loop to be able to break out of it
 		pulsingLoop:
@@ -574,12 +953,14 @@ func injectAndWait(cmd *cobra.Command, args []string) {
 					log.Infow("an exit signal has been received", "signal", sig.String())
 
 					return
-				case <-time.After(getDuration(deadline)):
+				case <-deadlineUpdated:
+					log.Infow("deadline has been updated", "deadline", getDeadline())
+				case <-time.After(getDuration(getDeadline())):
 					log.Infow("duration has expired")
 
 					return
-				case <-time.After(sleepDuration):
-					action, err = pulse(&isInjected, &sleepDuration, action, cmd.Name())
+				case <-time.After(time.Until(pulseStateEnd)):
+					action, err = pulse(&injected, &sleepDuration, action, cmd.Name())
 					if err != nil {
 						log.Errorf(err.Error())
 
@@ -614,7 +995,7 @@ func injectAndWait(cmd *cobra.Command, args []string) {
 		// we watch for targeted pod containers restart to reinject
 		if err := backoff.RetryNotify(
 			func() error {
-				return watchTargetAndReinject(deadline, cmd.Name(), disruptionArgs.PulseActiveDuration, disruptionArgs.PulseDormantDuration)
+				return watchTargetAndReinject(cmd.Name(), disruptionArgs.PulseActiveDuration, disruptionArgs.PulseDormantDuration)
 			}, backoff.WithMaxRetries(backoff.NewExponentialBackOff(), 5), func(err error, delay time.Duration) {
 				log.Warnln("couldn't watch targeted pod, retrying", "error", err, "retrying", delay)
 			}); err != nil {
@@ -638,7 +1019,7 @@ func injectAndWait(cmd *cobra.Command, args []string) {
 
 		noParentSignalTimer = time.NewTimer(maxDurationWithoutParentSignal)
 	} else {
-		noParentSignalTimer = time.NewTimer(getDuration(deadline) + 1*time.Minute)
+		noParentSignalTimer = time.NewTimer(getDuration(getDeadline()) + 1*time.Minute)
 	}
 
 	log.Warn("waiting for system signals...")
@@ -657,7 +1038,17 @@ func injectAndWait(cmd *cobra.Command, args []string) {
 		case sig := <-signals:
 			log.Infow("an exit signal has been received, exiting", "signal", sig.String())
 			return
-		case <-time.After(getDuration(deadline)):
+		case <-deadlineUpdated:
+			log.Infow("deadline has been updated", "deadline", getDeadline())
+
+			if parentPID == 0 {
+				if !noParentSignalTimer.Stop() {
+					<-noParentSignalTimer.C
+				}
+
+				noParentSignalTimer.Reset(getDuration(getDeadline()) + 1*time.Minute)
+			}
+		case <-time.After(getDuration(getDeadline())):
 			log.Info("disruption duration has expired, exiting")
 			return
 		}
@@ -665,22 +1056,20 @@ func injectAndWait(cmd *cobra.Command, args []string) {
 }
 
 // watchTargetAndReinject handle reinjection of the disruption on container restart
-func watchTargetAndReinject(deadline time.Time, commandName string, pulseActiveDuration time.Duration, pulseDormantDuration time.Duration) error {
+func watchTargetAndReinject(commandName string, pulseActiveDuration time.Duration, pulseDormantDuration time.Duration) error {
 	// we keep track of resource version in case of errors during watch to pick up where we were before the error
 	resourceVersion, err := getPodResourceVersion()
 	if err != nil {
 		return err
 	}
 
-	pulseIndexIsInjected := true
-
 	var pulseSleepDuration time.Duration
 
 	// set sleepDuration to after deadline duration to never go into the pulsing condition
 	if pulseActiveDuration > 0 && pulseDormantDuration > 0 {
-		pulseSleepDuration = pulseActiveDuration
+		pulseSleepDuration = time.Until(pulseStateEnd)
 	} else {
-		pulseSleepDuration = getDuration(deadline) + time.Hour
+		pulseSleepDuration = getDuration(getDeadline()) + time.Hour
 	}
 
 	var channel <-chan watch.Event
@@ -694,6 +1083,11 @@ func watchTargetAndReinject(deadline time.Time, commandName string, pulseActiveD
 			}
 		}
 
+		// the pulse timer is restarted on each event, so the sleep duration is computed from the end of the current state
+		if pulseActiveDuration > 0 && pulseDormantDuration > 0 {
+			pulseSleepDuration = time.Until(pulseStateEnd)
+		}
+
 		select {
 		case sig := <-signals:
 			log.Infow("an exit signal has been received", "signal", sig.String())
@@ -702,7 +1096,13 @@ func watchTargetAndReinject(deadline time.Time, commandName string, pulseActiveD
 			go func() { signals <- sig }()
 
 			return nil
-		case <-time.After(getDuration(deadline)):
+		case <-deadlineUpdated:
+			log.Infow("deadline has been updated", "deadline", getDeadline())
+
+			if pulseActiveDuration == 0 || pulseDormantDuration == 0 {
+				pulseSleepDuration = getDuration(getDeadline()) + time.Hour
+			}
+		case <-time.After(getDuration(getDeadline())):
 			log.Infow("duration has expired")
 
 			return nil
@@ -712,7 +1112,7 @@ func watchTargetAndReinject(deadline time.Time, commandName string, pulseActiveD
 				break
 			}
 
-			actionOnPulse, err = pulse(&pulseIndexIsInjected, &pulseSleepDuration, actionOnPulse, commandName)
+			actionOnPulse, err = pulse(&injected, &pulseSleepDuration, actionOnPulse, commandName)
 			if err != nil {
 				return err
 			}
@@ -800,7 +1200,7 @@ func watchTargetAndReinject(deadline time.Time, commandName string, pulseActiveD
 
 			// if a container is in crashloop, we might fail due to not found stuff
 			// we might want to retry to retrieve pod AND changes later on instead of stopping abruptly
-			if err := reinject(commandName); err != nil {
+			if err := reinject(commandName, false); err != nil {
 				return fmt.Errorf("an error occurred during reinjection: %w", err)
 			}
 		}
@@ -809,6 +1209,10 @@ func watchTargetAndReinject(deadline time.Time, commandName string, pulseActiveD
 
 // cleanAndExit cleans the disruption with the configured injector and exits nicely
 func cleanAndExit(cmd *cobra.Command, args []string) {
+	// stop verifying the injection as it is about to be removed
+	injectionLock.Lock()
+	defer injectionLock.Unlock()
+
 	// 1 or more injectors failed to clean, we exit
 	if ok := clean(cmd.Name(), true, false); !ok {
 		os.Exit(1)
@@ -868,6 +1272,27 @@ func retryNotifyHandler(err error, delay time.Duration) {
 	log.Infof("retrying cleanup in %s", delay.String())
 }
 
+// getDeadline returns the time at which the disruption must be over by
+func getDeadline() time.Time {
+	deadlineLock.Lock()
+	defer deadlineLock.Unlock()
+
+	return deadline
+}
+
+// setDeadline updates the time at which the disruption must be over by
+// and notifies the loops waiting for the deadline
+func setDeadline(newDeadline time.Time) {
+	deadlineLock.Lock()
+	deadline = newDeadline
+	deadlineLock.Unlock()
+
+	select {
+	case deadlineUpdated <- struct{}{}:
+	default: // a notification is already pending
+	}
+}
+
 // getDuration returns the time between time.Now() and when the disruption is due to expire
 // This gives the chaos pod plenty of time to clean up before it hits activeDeadlineSeconds and becomes Failed
 func getDuration(deadline time.Time) time.Duration {
@@ -17,24 +17,72 @@ var nodeFailureCmd = &cobra.Command{
 	Run:   injectAndWait,
 	PreRun: func(cmd *cobra.Command, args []string) {
 		shutdown, _ := cmd.Flags().GetBool("shutdown")
+		kubeletAction, _ := cmd.Flags().GetString("kubelet")
+		networkIsolation, _ := cmd.Flags().GetBool("network-isolation")
+		allowedHosts, _ := cmd.Flags().GetStringSlice("allowed-hosts")
+		drain, _ := cmd.Flags().GetBool("drain")
+		gracePeriodSeconds, _ := cmd.Flags().GetInt64("grace-period-seconds")
 
-		// prepare spec
-		spec := v1beta1.NodeFailureSpec{
-			Shutdown: shutdown,
-		}
+		// recoverable node failures target the node itself, a single injector is created
+		// even if several containers of the pod hosted on the node are targeted
+		var (
+			inj injector.Injector
+			err error
+		)
+
+		switch {
+		case kubeletAction != "":
+			spec := v1beta1.NodeFailureKubeletSpec{
+				Action: v1beta1.NodeFailureKubeletAction(kubeletAction),
+			}
+
+			inj, err = injector.NewKubeletFailureInjector(spec, injector.KubeletFailureInjectorConfig{Config: configs[0]})
+		case networkIsolation:
+			spec := v1beta1.NodeFailureNetworkIsolationSpec{
+				AllowedHosts: allowedHosts,
+			}
+
+			inj, err = injector.NewNodeNetworkIsolationInjector(spec, injector.NodeNetworkIsolationInjectorConfig{Config: configs[0]})
+		case drain:
+			spec := v1beta1.NodeFailureDrainSpec{}
+
+			if gracePeriodSeconds >= 0 {
+				spec.GracePeriodSeconds = &gracePeriodSeconds
+			}
+
+			inj, err = injector.NewNodeDrainInjector(spec, injector.NodeDrainInjectorConfig{Config: configs[0]})
+		default:
+			// prepare spec
+			spec := v1beta1.NodeFailureSpec{
+				Shutdown: shutdown,
+			}
+
+			// create injector
+			for _, config := range configs {
+				inj, err := injector.NewNodeFailureInjector(spec, injector.NodeFailureInjectorConfig{Config: config})
+				if err != nil {
+					log.Fatalw("error creating the node injector", "error", err)
+				}
 
-		// create injector
-		for _, config := range configs {
-			inj, err := injector.NewNodeFailureInjector(spec, injector.NodeFailureInjectorConfig{Config: config})
-			if err != nil {
-				log.Fatalw("error creating the node injector", "error", err)
+				injectors = append(injectors, inj)
 			}
 
-			injectors = append(injectors, inj)
+			return
 		}
+
+		if err != nil {
+			log.Fatalw("error creating the node injector", "error", err)
+		}
+
+		injectors = append(injectors, inj)
 	},
 }
 
 func init() {
 	nodeFailureCmd.Flags().Bool("shutdown", false, "If specified, the host will shut down instead of reboot")
+	nodeFailureCmd.Flags().String("kubelet", "", "Freeze (freeze) or stop (stop) the kubelet of the node instead of crashing it")
+	nodeFailureCmd.Flags().Bool("network-isolation", false, "Drop all the traffic of the node except the one exchanged with the control plane instead of crashing it")
+	nodeFailureCmd.Flags().StringSlice("allowed-hosts", []string{}, "IPs or CIDRs which can still be reached when isolating the node network")
+	nodeFailureCmd.Flags().Bool("drain", false, "Cordon the node and evict its pods instead of crashing it")
+	nodeFailureCmd.Flags().Int64("grace-period-seconds", -1, "Termination grace period of the pods evicted when draining the node (the pods one if negative)")
 }
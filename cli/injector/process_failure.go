@@ -0,0 +1,57 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package main
+
+import (
+	"github.com/DataDog/chaos-controller/api/v1beta1"
+	"github.com/DataDog/chaos-controller/injector"
+	"github.com/spf13/cobra"
+)
+
+var processFailureCmd = &cobra.Command{
+	Use:   "process-failure",
+	Short: "Process failure subcommands",
+	Run:   injectAndWait,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		pattern, _ := cmd.Flags().GetString("pattern")
+		signal, _ := cmd.Flags().GetString("signal")
+		pauseDuration, _ := cmd.Flags().GetDuration("pause-duration")
+		interval, _ := cmd.Flags().GetDuration("interval")
+
+		// prepare spec
+		spec := v1beta1.ProcessFailureSpec{
+			Pattern: pattern,
+			Signal:  signal,
+		}
+
+		if pauseDuration > 0 {
+			spec.PauseDuration = v1beta1.DisruptionDuration(pauseDuration.String())
+		}
+
+		if interval > 0 {
+			spec.Interval = v1beta1.DisruptionDuration(interval.String())
+		}
+
+		// create injectors
+		for _, config := range configs {
+			inj, err := injector.NewProcessFailureInjector(spec, injector.ProcessFailureInjectorConfig{Config: config})
+			if err != nil {
+				log.Fatalw("error initializing the process failure injector", "error", err)
+			}
+
+			injectors = append(injectors, inj)
+		}
+	},
+}
+
+func init() {
+	processFailureCmd.Flags().String("pattern", "", "Regular expression matched against the name and the command line of the processes to target")
+	processFailureCmd.Flags().String("signal", "", "Signal to send to the processes (SIGTERM by default), SIGSTOP pausing them until the pause duration is over or the disruption is cleaned")
+	processFailureCmd.Flags().Duration("pause-duration", 0, "Duration of the pause of the processes when sending the SIGSTOP signal")
+	processFailureCmd.Flags().Duration("interval", 0, "Interval at which the injection is repeated")
+
+	_ = cobra.MarkFlagRequired(processFailureCmd.Flags(), "pattern")
+}
@@ -0,0 +1,374 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/DataDog/chaos-controller/api/v1beta1"
+	"github.com/DataDog/chaos-controller/cgroup"
+	clientsetv1beta1 "github.com/DataDog/chaos-controller/clientset/v1beta1"
+	"github.com/DataDog/chaos-controller/env"
+	"github.com/DataDog/chaos-controller/injector"
+	logger "github.com/DataDog/chaos-controller/log"
+	"github.com/DataDog/chaos-controller/netns"
+	"github.com/DataDog/chaos-controller/network"
+	"github.com/DataDog/chaos-controller/o11y/metrics"
+	metricstypes "github.com/DataDog/chaos-controller/o11y/metrics/types"
+	"github.com/DataDog/chaos-controller/process"
+	"github.com/hashicorp/go-multierror"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+)
+
+var (
+	sweepInterval time.Duration
+	sweepDryRun   bool
+)
+
+var sweeperCmd = &cobra.Command{
+	Use:   "sweep",
+	Short: "Revert the changes left behind by chaos pods which died before cleaning them",
+	Long: `Runs on every node (usually as a DaemonSet) and periodically reverts the changes recorded in the node journal
+by the chaos pods whose disruption or chaos pod does not exist anymore, or whose chaos pod is terminated.`,
+	Run: sweep,
+}
+
+func init() {
+	sweeperCmd.Flags().StringVar(&journalPath, "journal-path", injector.JournalDirectory, "Directory of the node journal recording the changes injected by the chaos pods")
+	sweeperCmd.Flags().DurationVar(&sweepInterval, "interval", time.Minute, "Interval between two sweeps of the journal")
+	sweeperCmd.Flags().BoolVar(&sweepDryRun, "dry-run", false, "Enable dry-run mode, logging the changes to revert without reverting them")
+}
+
+// sweep periodically reverts the orphaned journaled changes until an exit signal is received
+func sweep(cmd *cobra.Command, args []string) {
+	var err error
+
+	if log, err = logger.NewZapLogger(); err != nil {
+		fmt.Printf("error while creating logger: %v", err)
+		os.Exit(2)
+	}
+
+	// the sweeper doesn't send any metric but the sink is closed on exit
+	if ms, err = metrics.GetSink(log, metricstypes.SinkDriverNoop, metricstypes.SinkAppInjector); err != nil {
+		log.Fatalw("error while creating noop metric sink", "error", err)
+	}
+
+	if journal, err = injector.NewFileJournal(journalPath); err != nil {
+		log.Fatalw("error opening the journal", "error", err, "path", journalPath)
+	}
+
+	config, err := rest.InClusterConfig()
+	if err != nil {
+		log.Fatalw("error getting kubernetes client config", "error", err)
+	}
+
+	if clientset, err = kubernetes.NewForConfig(config); err != nil {
+		log.Fatalw("error creating kubernetes client", "error", err)
+	}
+
+	if err := v1beta1.AddToScheme(scheme.Scheme); err != nil {
+		log.Fatalw("error registering the disruption types", "error", err)
+	}
+
+	disruptionClient, err := clientsetv1beta1.NewForConfig(config)
+	if err != nil {
+		log.Fatalw("error creating disruption client", "error", err)
+	}
+
+	sweeper := injector.Sweeper{
+		Log:              log,
+		Journal:          journal,
+		Reverter:         journalReverter{log: log, dryRun: sweepDryRun, procPath: os.Getenv(env.InjectorMountProc), mountHost: os.Getenv(env.InjectorMountHost), k8sClient: clientset},
+		K8sClient:        clientset,
+		DisruptionClient: disruptionClient,
+		ProcPath:         os.Getenv(env.InjectorMountProc),
+		DryRun:           sweepDryRun,
+	}
+
+	exitSignals := make(chan os.Signal, 1)
+	signal.Notify(exitSignals, syscall.SIGINT, syscall.SIGTERM)
+
+	log.Infow("starting the sweeper", "journalPath", journalPath, "interval", sweepInterval, "dryRun", sweepDryRun)
+
+	ticker := time.NewTicker(sweepInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := sweeper.Sweep(context.Background()); err != nil {
+			log.Errorw("error sweeping the journal", "error", err)
+		}
+
+		select {
+		case sig := <-exitSignals:
+			log.Infow("an exit signal has been received, stopping the sweeper", "signal", sig.String())
+
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// journalReverter reverts journaled changes from the node, entering the namespaces
+// and cgroups of the targets still existing
+type journalReverter struct {
+	log       *zap.SugaredLogger
+	dryRun    bool
+	procPath  string
+	mountHost string
+	k8sClient kubernetes.Interface
+}
+
+// Revert reverts all the changes of the given entry
+func (r journalReverter) Revert(entry injector.JournalEntry) error {
+	for _, change := range entry.Changes {
+		var err error
+
+		switch change.Type {
+		case injector.JournalChangeTrafficControl, injector.JournalChangeIPTables:
+			err = r.revertNetwork(entry.Target, change)
+		case injector.JournalChangeCgroupFile:
+			err = r.revertCgroupFile(entry.Target, change)
+		case injector.JournalChangeService:
+			err = r.revertService(change)
+		case injector.JournalChangeProcess:
+			err = r.revertProcess(entry.Target, change)
+		case injector.JournalChangeNodeCordon:
+			err = r.revertNodeCordon(change)
+		case injector.JournalChangeFile:
+			err = r.revertFile(change)
+		default:
+			err = fmt.Errorf("unknown change type")
+		}
+
+		if err != nil {
+			return fmt.Errorf("error reverting %s change: %w", change.Type, err)
+		}
+	}
+
+	return nil
+}
+
+// revertNetwork reverts a change of the target network namespace, which can be gone with the target
+func (r journalReverter) revertNetwork(target injector.JournalTarget, change injector.JournalChange) (err error) {
+	pid := uint32(1)
+
+	if !change.HostNetwork {
+		var found bool
+
+		if pid, found = target.NetnsPID(r.procPath); !found {
+			r.log.Infow("the target network namespace does not exist anymore, nothing to revert", "type", change.Type)
+
+			return nil
+		}
+	}
+
+	netnsMgr, err := netns.NewManager(r.log, pid)
+	if err != nil {
+		return fmt.Errorf("error creating network namespace manager: %w", err)
+	}
+
+	if err := netnsMgr.Enter(); err != nil {
+		return fmt.Errorf("unable to enter the target network namespace: %w", err)
+	}
+
+	defer func() {
+		if exitErr := netnsMgr.Exit(); exitErr != nil && err == nil {
+			err = fmt.Errorf("unable to exit the target network namespace: %w", exitErr)
+		}
+	}()
+
+	if change.Type == injector.JournalChangeIPTables {
+		iptables, err := network.NewIPTablesWithRules(r.log, r.dryRun, change.Rules)
+		if err != nil {
+			return fmt.Errorf("error creating iptables client: %w", err)
+		}
+
+		return iptables.Clear()
+	}
+
+	tc := network.NewTrafficController(r.log, r.dryRun)
+
+	// only the interfaces the injector attached its tc tree to are cleared, the other ones being left
+	// untouched (e.g. pod veths created afterwards on the node)
+	for _, iface := range change.Interfaces {
+		qdiscs, err := tc.ListQdiscs(iface)
+		if err != nil {
+			r.log.Infow("unable to list the qdiscs of the interface, it may not exist anymore", "interface", iface, "error", err)
+
+			continue
+		}
+
+		// only remove the tc tree built by the injectors, identified by its root prio qdisc
+		if !strings.Contains(qdiscs, "qdisc prio 1: root") {
+			continue
+		}
+
+		if err := tc.ClearQdisc([]string{iface}); err != nil {
+			return fmt.Errorf("error clearing qdiscs of interface %s: %w", iface, err)
+		}
+	}
+
+	return nil
+}
+
+// revertCgroupFile restores a cgroup file of the target, the cgroup being gone with the target process otherwise
+func (r journalReverter) revertCgroupFile(target injector.JournalTarget, change injector.JournalChange) error {
+	if !target.Alive(r.procPath) {
+		r.log.Infow("the target process does not exist anymore, nothing to revert", "type", change.Type, "file", change.File)
+
+		return nil
+	}
+
+	cgroupMgr, err := cgroup.NewManager(r.dryRun, target.PID, cgroupMountPath(), r.log)
+	if err != nil {
+		return fmt.Errorf("error creating cgroup manager: %w", err)
+	}
+
+	if err := cgroupMgr.Write(change.Controller, change.File, change.Value); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("error restoring cgroup file %s: %w", change.File, err)
+	}
+
+	return nil
+}
+
+// revertService starts a node service again, the sweeper being the only one able to do it
+// when the chaos pod is killed with the kubelet stopped
+func (r journalReverter) revertService(change injector.JournalChange) error {
+	r.log.Infow("starting the node service", "service", change.Service)
+
+	return injector.NewNodeServiceManager(r.log, r.dryRun).Start(change.Service)
+}
+
+// revertProcess resumes the stopped processes of the target PID namespace, or of the target cgroup if journaled
+func (r journalReverter) revertProcess(target injector.JournalTarget, change injector.JournalChange) (retErr error) {
+	if !target.Alive(r.procPath) {
+		r.log.Infow("the target process does not exist anymore, nothing to revert", "type", change.Type, "pattern", change.Pattern)
+
+		return nil
+	}
+
+	pattern, err := regexp.Compile(change.Pattern)
+	if err != nil {
+		return fmt.Errorf("invalid process pattern %s: %w", change.Pattern, err)
+	}
+
+	processes, err := r.findProcesses(target, change, pattern)
+	if err != nil {
+		return err
+	}
+
+	processManager := process.NewManager(r.dryRun)
+
+	for _, info := range processes {
+		proc, err := processManager.Find(info.PID)
+		if err != nil {
+			retErr = multierror.Append(retErr, fmt.Errorf("error while finding the process %d: %w", info.PID, err))
+
+			continue
+		}
+
+		r.log.Infow("resuming a stopped process", "pid", info.PID, "name", info.Name)
+
+		if err := processManager.Signal(proc, syscall.SIGCONT); err != nil && !errors.Is(err, os.ErrProcessDone) {
+			retErr = multierror.Append(retErr, fmt.Errorf("error while resuming the process %d: %w", info.PID, err))
+		}
+	}
+
+	return retErr
+}
+
+// findProcesses returns the processes matching the pattern in the target cgroup if journaled, in the target PID namespace otherwise
+func (r journalReverter) findProcesses(target injector.JournalTarget, change injector.JournalChange, pattern *regexp.Regexp) ([]process.Info, error) {
+	finder := process.NewFinder(r.procPath)
+
+	if change.File == "" {
+		return finder.FindInNamespace(int(target.PID), pattern)
+	}
+
+	cgroupMgr, err := cgroup.NewManager(r.dryRun, target.PID, cgroupMountPath(), r.log)
+	if err != nil {
+		return nil, fmt.Errorf("error creating cgroup manager: %w", err)
+	}
+
+	procs, err := cgroupMgr.Read(change.Controller, change.File)
+	if err != nil {
+		return nil, fmt.Errorf("error reading the processes of the target cgroup: %w", err)
+	}
+
+	pids := []int{}
+
+	for _, line := range strings.Fields(procs) {
+		pid, err := strconv.Atoi(line)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing the process %s of the target cgroup: %w", line, err)
+		}
+
+		pids = append(pids, pid)
+	}
+
+	return finder.Find(pids, pattern)
+}
+
+// revertNodeCordon uncordons the node, unless it has been uncordoned or cordoned again by someone else meanwhile
+func (r journalReverter) revertNodeCordon(change injector.JournalChange) error {
+	node, err := r.k8sClient.CoreV1().Nodes().Get(context.Background(), change.Node, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			r.log.Infow("the node does not exist anymore, nothing to revert", "type", change.Type, "node", change.Node)
+
+			return nil
+		}
+
+		return fmt.Errorf("error getting the node: %w", err)
+	}
+
+	if node.Annotations[injector.NodeDrainCordonedByAnnotation] != change.Value {
+		r.log.Infow("the node is not cordoned by the disruption anymore, nothing to revert", "type", change.Type, "node", change.Node)
+
+		return nil
+	}
+
+	r.log.Infow("uncordoning the node", "node", change.Node)
+
+	if r.dryRun {
+		return nil
+	}
+
+	return injector.UncordonNode(r.k8sClient, change.Node)
+}
+
+// revertFile removes a file or directory created on the node
+func (r journalReverter) revertFile(change injector.JournalChange) error {
+	path := filepath.Join(r.mountHost, change.Path)
+
+	r.log.Infow("removing the created file", "path", change.Path)
+
+	if r.dryRun {
+		return nil
+	}
+
+	if err := os.RemoveAll(path); err != nil {
+		return fmt.Errorf("error removing %s: %w", change.Path, err)
+	}
+
+	return nil
+}
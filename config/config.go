@@ -29,6 +29,7 @@ type controllerConfig struct {
 	MetricsSink              string                          `json:"metricsSink"`
 	ExpiredDisruptionGCDelay time.Duration                   `json:"expiredDisruptionGCDelay"`
 	DefaultDuration          time.Duration                   `json:"defaultDuration"`
+	AbortProbeAllowedHosts   []string                        `json:"abortProbeAllowedHosts"`
 	DeleteOnly               bool                            `json:"deleteOnly"`
 	EnableSafeguards         bool                            `json:"enableSafeguards"`
 	EnableObserver           bool                            `json:"enableObserver"`
@@ -55,14 +56,17 @@ type safeModeConfig struct {
 }
 
 type injectorConfig struct {
-	Image             string                          `json:"image"`
-	Annotations       map[string]string               `json:"annotations"`
-	Labels            map[string]string               `json:"labels"`
-	ChaosNamespace    string                          `json:"namespace"`
-	ServiceAccount    string                          `json:"serviceAccount"`
-	DNSDisruption     injectorDNSDisruptionConfig     `json:"dnsDisruption"`
-	NetworkDisruption injectorNetworkDisruptionConfig `json:"networkDisruption"`
-	ImagePullSecrets  string                          `json:"imagePullSecrets"`
+	Image                     string                          `json:"image"`
+	Annotations               map[string]string               `json:"annotations"`
+	Labels                    map[string]string               `json:"labels"`
+	ChaosNamespace            string                          `json:"namespace"`
+	ServiceAccount            string                          `json:"serviceAccount"`
+	NodeFailureServiceAccount string                          `json:"nodeFailureServiceAccount"`
+	DNSDisruption             injectorDNSDisruptionConfig     `json:"dnsDisruption"`
+	NetworkDisruption         injectorNetworkDisruptionConfig `json:"networkDisruption"`
+	ImagePullSecrets          string                          `json:"imagePullSecrets"`
+	ControlPort               int                             `json:"controlPort"`
+	ControlTokenSecret        string                          `json:"controlTokenSecret"`
 }
 
 type injectorDNSDisruptionConfig struct {
@@ -138,6 +142,12 @@ func New(logger *zap.SugaredLogger, osArgs []string) (config, error) {
 		return cfg, err
 	}
 
+	mainFS.StringSliceVar(&cfg.Controller.AbortProbeAllowedHosts, "abort-probe-allowed-hosts", []string{}, "List of hosts abort condition and health probes can call, a host starting with a dot allowing all its subdomains (probes are disabled if empty)")
+
+	if err := viper.BindPFlag("controller.abortProbeAllowedHosts", mainFS.Lookup("abort-probe-allowed-hosts")); err != nil {
+		return cfg, err
+	}
+
 	mainFS.StringVar(&cfg.Controller.Notifiers.Common.ClusterName, "notifiers-common-clustername", "", "Cluster Name for notifiers output")
 
 	if err := viper.BindPFlag("controller.notifiers.common.clusterName", mainFS.Lookup("notifiers-common-clustername")); err != nil {
@@ -216,6 +226,12 @@ func New(logger *zap.SugaredLogger, osArgs []string) (config, error) {
 		return cfg, err
 	}
 
+	mainFS.StringVar(&cfg.Injector.NodeFailureServiceAccount, "injector-node-failure-service-account", "chaos-injector-node-failure", "Service account to use for the generated node failure injector pods, which need to cordon, drain and isolate nodes")
+
+	if err := viper.BindPFlag("injector.nodeFailureServiceAccount", mainFS.Lookup("injector-node-failure-service-account")); err != nil {
+		return cfg, err
+	}
+
 	mainFS.StringVar(&cfg.Injector.ChaosNamespace, "chaos-namespace", "chaos-engineering", "Namespace of the service account to use for the generated injector pods. Must also host the controller.")
 
 	if err := viper.BindPFlag("injector.chaosNamespace", mainFS.Lookup("chaos-namespace")); err != nil {
@@ -252,6 +268,18 @@ func New(logger *zap.SugaredLogger, osArgs []string) (config, error) {
 		return cfg, err
 	}
 
+	mainFS.IntVar(&cfg.Injector.ControlPort, "injector-control-port", 0, "Port of the injector control API exposed by the chaos pods for chaosli, the controller never calls it (0 to disable the control API)")
+
+	if err := viper.BindPFlag("injector.controlPort", mainFS.Lookup("injector-control-port")); err != nil {
+		return cfg, err
+	}
+
+	mainFS.StringVar(&cfg.Injector.ControlTokenSecret, "injector-control-token-secret", "chaos-injector-control", "Secret of the chaos pods namespace holding the token required to call the injector control API (token key)")
+
+	if err := viper.BindPFlag("injector.controlTokenSecret", mainFS.Lookup("injector-control-token-secret")); err != nil {
+		return cfg, err
+	}
+
 	mainFS.BoolVar(&cfg.Handler.Enabled, "handler-enabled", false, "Enable the chaos handler for on-init disruptions")
 
 	if err := viper.BindPFlag("handler.enabled", mainFS.Lookup("handler-enabled")); err != nil {
@@ -94,6 +94,8 @@ var _ = Describe("Config", func() {
 				Expect(v.Injector.Image).To(Equal("chaos-injector"))
 				Expect(v.Injector.ImagePullSecrets).To(BeEmpty())
 				Expect(v.Injector.ServiceAccount).To(Equal("chaos-injector"))
+				Expect(v.Injector.NodeFailureServiceAccount).To(Equal("chaos-injector-node-failure"))
+				Expect(v.Injector.ControlTokenSecret).To(Equal("chaos-injector-control"))
 				Expect(v.Injector.ChaosNamespace).To(Equal("chaos-engineering"))
 
 				By("overriding handler global values")
@@ -160,6 +162,7 @@ var _ = Describe("Config", func() {
 				Expect(v.Injector.Image).To(Equal("datadog.io/chaos-injector:not-latest"))
 				Expect(v.Injector.ImagePullSecrets).To(Equal("some-pull-secret"))
 				Expect(v.Injector.ServiceAccount).To(Equal("chaos-injector-custom-sa"))
+				Expect(v.Injector.NodeFailureServiceAccount).To(Equal("chaos-injector-node-failure-custom-sa"))
 				Expect(v.Injector.ChaosNamespace).To(Equal("chaos-engineering-custom-ns"))
 
 				By("overriding handler global values")
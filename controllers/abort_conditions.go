@@ -0,0 +1,397 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	chaosv1beta1 "github.com/DataDog/chaos-controller/api/v1beta1"
+	chaostypes "github.com/DataDog/chaos-controller/types"
+	"github.com/DataDog/chaos-controller/watchers"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// abortProbeTimeout is the maximum time a probe call can take, its result being collected on a later reconcile
+	abortProbeTimeout = 10 * time.Second
+	// abortProbeResultCheckInterval is the delay after which the result of a running probe is collected
+	abortProbeResultCheckInterval = 2 * time.Second
+)
+
+var (
+	abortProbeHTTPClient = &http.Client{Timeout: abortProbeTimeout}
+	jsonPathIndexRegex   = regexp.MustCompile(`^([^\[]*)((?:\[\d+\])*)$`)
+)
+
+// abortProbesStates holds the state of the abort condition probes of the disruptions handled by a reconciler
+// probes are called asynchronously so a slow endpoint never blocks the reconcile loop
+type abortProbesStates struct {
+	lock   sync.Mutex
+	states map[types.UID]map[int]*abortProbeState
+}
+
+// abortProbeState is the state of a single probe of a disruption
+type abortProbeState struct {
+	lastCheck time.Time
+	running   bool
+	result    *abortProbeResult
+}
+
+// abortProbeResult is the result of a completed probe call
+type abortProbeResult struct {
+	value float64
+	err   error
+}
+
+// next returns the result of the last completed call of the given probe if it has not been returned yet (nil otherwise),
+// and starts a new call when the probe interval elapsed since the previous one, the probe host being checked against the allowed ones
+// it returns the delay after which the probe must be checked again
+func (s *abortProbesStates) next(uid types.UID, index int, probe chaosv1beta1.AbortProbe, allowedHosts []string) (*abortProbeResult, time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.states == nil {
+		s.states = map[types.UID]map[int]*abortProbeState{}
+	}
+
+	if s.states[uid] == nil {
+		s.states[uid] = map[int]*abortProbeState{}
+	}
+
+	state, found := s.states[uid][index]
+	if !found {
+		state = &abortProbeState{}
+		s.states[uid][index] = state
+	}
+
+	result := state.result
+	state.result = nil
+
+	if state.running {
+		return result, abortProbeResultCheckInterval
+	}
+
+	if sinceLastCheck := time.Since(state.lastCheck); sinceLastCheck < probe.GetInterval() {
+		return result, probe.GetInterval() - sinceLastCheck
+	}
+
+	state.lastCheck = time.Now()
+	state.running = true
+
+	go func() {
+		probeValue, probeErr := getAbortProbeValue(probe, allowedHosts)
+
+		s.lock.Lock()
+		defer s.lock.Unlock()
+
+		state.running = false
+		state.result = &abortProbeResult{value: probeValue, err: probeErr}
+	}()
+
+	return result, abortProbeResultCheckInterval
+}
+
+// clear removes the probes states of the given disruption
+func (s *abortProbesStates) clear(uid types.UID) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.states, uid)
+}
+
+// checkAbortConditions evaluates the abort conditions of the given instance against its targets and probes
+// it returns the reason of the abort if any condition holds, and the delay after which the conditions must be checked again
+func (r *DisruptionReconciler) checkAbortConditions(instance *chaosv1beta1.Disruption) (string, time.Duration, error) {
+	conditions := instance.Spec.AbortConditions
+	requeueAfter := time.Duration(0)
+
+	if conditions.NotReadyTargets != nil || conditions.Restarts != nil {
+		reason, notReadyRequeueAfter, err := r.checkTargetsAbortConditions(instance)
+		if err != nil || reason != "" {
+			return reason, 0, err
+		}
+
+		requeueAfter = notReadyRequeueAfter
+	}
+
+	for i, probe := range conditions.Probes {
+		result, probeRequeueAfter := r.abortProbes.next(instance.UID, i, probe, r.AbortProbeAllowedHosts)
+		requeueAfter = minDuration(requeueAfter, probeRequeueAfter)
+
+		if result == nil {
+			continue
+		}
+
+		if result.err != nil {
+			// an unreachable probe must not abort the disruption, the error is only logged
+			r.log.Warnw("error checking abort condition probe", "probe", probe.GetName(), "error", result.err)
+
+			continue
+		}
+
+		value := result.value
+
+		crossed, err := probe.IsCrossed(value)
+		if err != nil {
+			return "", 0, err
+		}
+
+		if crossed {
+			return fmt.Sprintf("probe %s returned %g which is %s the threshold of %s", probe.GetName(), value, probe.Operator, probe.Threshold), 0, nil
+		}
+	}
+
+	return "", requeueAfter, nil
+}
+
+// checkTargetsAbortConditions evaluates the not ready targets and restarts abort conditions of the given instance
+// it stores the initial restart count of new targets in the instance status
+func (r *DisruptionReconciler) checkTargetsAbortConditions(instance *chaosv1beta1.Disruption) (string, time.Duration, error) {
+	conditions := instance.Spec.AbortConditions
+	notReadyTargets := 0
+	requeueAfter := time.Duration(0)
+
+	for targetName, injection := range instance.Status.TargetInjections {
+		health, err := r.getTargetHealth(instance, targetName)
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+
+			return "", 0, fmt.Errorf("error getting target %s health: %w", targetName, err)
+		}
+
+		if conditions.Restarts != nil {
+			if injection.InitialRestartCount == nil {
+				injection.InitialRestartCount = &health.RestartCount
+				instance.Status.TargetInjections[targetName] = injection
+			} else if restarts := health.RestartCount - *injection.InitialRestartCount; restarts > conditions.Restarts.Threshold {
+				return fmt.Sprintf("target %s restarted %d times, exceeding the threshold of %d", targetName, restarts, conditions.Restarts.Threshold), 0, nil
+			}
+		}
+
+		if conditions.NotReadyTargets == nil || health.Ready {
+			continue
+		}
+
+		if notReadyFor := time.Since(health.Since); notReadyFor >= conditions.NotReadyTargets.Duration.Duration() {
+			notReadyTargets++
+		} else {
+			requeueAfter = minDuration(requeueAfter, conditions.NotReadyTargets.Duration.Duration()-notReadyFor)
+		}
+	}
+
+	if conditions.NotReadyTargets != nil && notReadyTargets > 0 {
+		threshold, err := getScaledValueFromIntOrPercent(conditions.NotReadyTargets.Count, len(instance.Status.TargetInjections), true)
+		if err != nil {
+			return "", 0, fmt.Errorf("error getting not ready targets abort condition count: %w", err)
+		}
+
+		if notReadyTargets >= threshold {
+			return fmt.Sprintf("%d target(s) have not been ready for at least %s", notReadyTargets, conditions.NotReadyTargets.Duration.Duration()), 0, nil
+		}
+	}
+
+	return "", requeueAfter, nil
+}
+
+// getTargetHealth returns the readiness of the given target, since when it has this readiness and its total restart count
+// the health reported by the disruption target watcher is used when it has already seen the target, the target is fetched otherwise
+func (r *DisruptionReconciler) getTargetHealth(instance *chaosv1beta1.Disruption, targetName string) (watchers.TargetHealth, error) {
+	if r.TargetsHealth != nil {
+		if health, found := r.TargetsHealth.Get(instance.UID, targetName); found {
+			return health, nil
+		}
+	}
+
+	if instance.Spec.Level == chaostypes.DisruptionLevelNode {
+		node := corev1.Node{}
+		if err := r.Client.Get(context.Background(), types.NamespacedName{Name: targetName}, &node); err != nil {
+			return watchers.TargetHealth{}, err
+		}
+
+		return watchers.GetNodeHealth(&node), nil
+	}
+
+	pod := corev1.Pod{}
+	if err := r.Client.Get(context.Background(), types.NamespacedName{Namespace: instance.Namespace, Name: targetName}, &pod); err != nil {
+		return watchers.TargetHealth{}, err
+	}
+
+	return watchers.GetPodHealth(&pod), nil
+}
+
+// getAbortProbeValue calls the given probe endpoint and extracts the value to compare to its threshold
+// the probe host must be part of the given allowed hosts so disruptions can't make the controller call any url
+func getAbortProbeValue(probe chaosv1beta1.AbortProbe, allowedHosts []string) (float64, error) {
+	if err := probe.ValidateAllowedHosts(allowedHosts); err != nil {
+		return 0, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), abortProbeTimeout)
+	defer cancel()
+
+	probeURL := probe.URL
+
+	if probe.Type == chaosv1beta1.AbortProbeTypePrometheus {
+		probeURL = strings.TrimSuffix(probe.URL, "/") + "/api/v1/query?query=" + url.QueryEscape(probe.Query)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, probeURL, nil)
+	if err != nil {
+		return 0, fmt.Errorf("error building probe request: %w", err)
+	}
+
+	resp, err := abortProbeHTTPClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("error calling probe endpoint: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return 0, fmt.Errorf("probe endpoint returned an unexpected status code %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, fmt.Errorf("error reading probe response: %w", err)
+	}
+
+	var content interface{}
+	if err := json.Unmarshal(body, &content); err != nil {
+		return 0, fmt.Errorf("error parsing probe response: %w", err)
+	}
+
+	if probe.Type == chaosv1beta1.AbortProbeTypePrometheus {
+		return getPrometheusQueryValue(content)
+	}
+
+	return getJSONPathValue(content, probe.Query)
+}
+
+// getPrometheusQueryValue returns the value of the first sample of a prometheus query API response
+// both vector and scalar result types are supported
+func getPrometheusQueryValue(content interface{}) (float64, error) {
+	result, err := getJSONPathValueRaw(content, "data.result")
+	if err != nil {
+		return 0, err
+	}
+
+	results, ok := result.([]interface{})
+	if !ok || len(results) == 0 {
+		return 0, fmt.Errorf("prometheus query returned no result")
+	}
+
+	// vector results are a list of samples holding a value field, scalar results are directly the value
+	if sample, ok := results[0].(map[string]interface{}); ok {
+		return getJSONPathValue(sample, "value[1]")
+	}
+
+	return getJSONPathValue(results, "[1]")
+}
+
+// getJSONPathValue returns the numeric value located at the given JSON path (e.g. data.items[0].value)
+func getJSONPathValue(content interface{}, path string) (float64, error) {
+	value, err := getJSONPathValueRaw(content, path)
+	if err != nil {
+		return 0, err
+	}
+
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case string:
+		return strconv.ParseFloat(v, 64)
+	case bool:
+		if v {
+			return 1, nil
+		}
+
+		return 0, nil
+	}
+
+	return 0, fmt.Errorf("value at path %s is not a number: %v", path, value)
+}
+
+func getJSONPathValueRaw(content interface{}, path string) (interface{}, error) {
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	current := content
+
+	if path == "" {
+		return current, nil
+	}
+
+	for _, segment := range strings.Split(path, ".") {
+		matches := jsonPathIndexRegex.FindStringSubmatch(segment)
+		if matches == nil {
+			return nil, fmt.Errorf("invalid path segment %s", segment)
+		}
+
+		if matches[1] != "" {
+			object, ok := current.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("path segment %s is not an object", matches[1])
+			}
+
+			if current, ok = object[matches[1]]; !ok {
+				return nil, fmt.Errorf("path segment %s not found", matches[1])
+			}
+		}
+
+		for _, rawIndex := range strings.FieldsFunc(matches[2], func(r rune) bool { return r == '[' || r == ']' }) {
+			index, _ := strconv.Atoi(rawIndex)
+
+			list, ok := current.([]interface{})
+			if !ok || index >= len(list) {
+				return nil, fmt.Errorf("index %d of path segment %s not found", index, segment)
+			}
+
+			current = list[index]
+		}
+	}
+
+	return current, nil
+}
+
+// minDuration returns the lowest non-zero duration
+func minDuration(a, b time.Duration) time.Duration {
+	if a == 0 || (b != 0 && b < a) {
+		return b
+	}
+
+	return a
+}
+
+// abortDisruption records the abort reason and deletes the given instance, its chaos pods being cleaned by the usual finalizer flow
+func (r *DisruptionReconciler) abortDisruption(instance *chaosv1beta1.Disruption, reason string) error {
+	r.log.Infow("an abort condition holds, aborting the disruption", "reason", reason)
+	r.recordEventOnDisruption(instance, chaosv1beta1.EventDisruptionAborted, reason, "")
+
+	instance.Status.AbortReason = reason
+	if err := r.Client.Status().Update(context.Background(), instance); err != nil {
+		return fmt.Errorf("error updating disruption abort reason: %w", err)
+	}
+
+	r.abortProbes.clear(instance.UID)
+
+	if err := r.Client.Delete(context.Background(), instance); err != nil {
+		return fmt.Errorf("error deleting aborted disruption: %w", err)
+	}
+
+	return nil
+}
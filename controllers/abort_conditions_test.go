@@ -0,0 +1,216 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	chaosv1beta1 "github.com/DataDog/chaos-controller/api/v1beta1"
+	"github.com/DataDog/chaos-controller/watchers"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Abort conditions probes parsing", func() {
+	parse := func(raw string) interface{} {
+		var content interface{}
+		Expect(json.Unmarshal([]byte(raw), &content)).To(Succeed())
+
+		return content
+	}
+
+	Context("extracting a value from a JSON path", func() {
+		It("should return nested object and list values", func() {
+			content := parse(`{"data": {"items": [{"value": 1.5}, {"value": "2"}]}, "healthy": true}`)
+
+			Expect(getJSONPathValue(content, "data.items[0].value")).To(Equal(1.5))
+			Expect(getJSONPathValue(content, "$.data.items[1].value")).To(Equal(2.0))
+			Expect(getJSONPathValue(content, "healthy")).To(Equal(1.0))
+		})
+
+		It("should return an error for missing or non numeric values", func() {
+			content := parse(`{"data": {"items": [], "name": "foo"}}`)
+
+			_, err := getJSONPathValue(content, "data.items[0]")
+			Expect(err).To(HaveOccurred())
+			_, err = getJSONPathValue(content, "data.missing")
+			Expect(err).To(HaveOccurred())
+			_, err = getJSONPathValue(content, "data.name")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("extracting a value from a prometheus query response", func() {
+		It("should support vector results", func() {
+			content := parse(`{"status": "success", "data": {"resultType": "vector", "result": [{"metric": {}, "value": [1690000000, "0.25"]}]}}`)
+
+			Expect(getPrometheusQueryValue(content)).To(Equal(0.25))
+		})
+
+		It("should support scalar results", func() {
+			content := parse(`{"status": "success", "data": {"resultType": "scalar", "result": [1690000000, "3"]}}`)
+
+			Expect(getPrometheusQueryValue(content)).To(Equal(3.0))
+		})
+
+		It("should return an error on empty results", func() {
+			content := parse(`{"status": "success", "data": {"resultType": "vector", "result": []}}`)
+
+			_, err := getPrometheusQueryValue(content)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("computing the lowest requeue delay", func() {
+		It("should ignore zero durations", func() {
+			Expect(minDuration(0, time.Second)).To(Equal(time.Second))
+			Expect(minDuration(time.Minute, 0)).To(Equal(time.Minute))
+			Expect(minDuration(time.Minute, time.Second)).To(Equal(time.Second))
+		})
+	})
+
+	Context("calling probes asynchronously", func() {
+		var (
+			server       *httptest.Server
+			calls        atomic.Int32
+			probe        chaosv1beta1.AbortProbe
+			states       *abortProbesStates
+			allowedHosts = []string{"127.0.0.1"}
+		)
+
+		BeforeEach(func() {
+			calls.Store(0)
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls.Add(1)
+				fmt.Fprint(w, `{"value": 42}`)
+			}))
+			probe = chaosv1beta1.AbortProbe{Type: chaosv1beta1.AbortProbeTypeJSON, URL: server.URL, Query: "value", Operator: chaosv1beta1.AbortProbeOperatorAbove, Threshold: "10"}
+			states = &abortProbesStates{}
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("should return the probe result on a later call without blocking", func() {
+			result, requeueAfter := states.next("uid", 0, probe, allowedHosts)
+			Expect(result).To(BeNil())
+			Expect(requeueAfter).To(Equal(abortProbeResultCheckInterval))
+
+			Eventually(func() *abortProbeResult {
+				result, _ := states.next("uid", 0, probe, allowedHosts)
+
+				return result
+			}).Should(Equal(&abortProbeResult{value: 42}))
+		})
+
+		It("should not call the probe again before its interval elapsed", func() {
+			states.next("uid", 0, probe, allowedHosts)
+			Eventually(func() *abortProbeResult {
+				result, _ := states.next("uid", 0, probe, allowedHosts)
+
+				return result
+			}).ShouldNot(BeNil())
+
+			result, requeueAfter := states.next("uid", 0, probe, allowedHosts)
+			Expect(result).To(BeNil())
+			Expect(requeueAfter).To(BeNumerically("~", probe.GetInterval(), time.Second))
+			Expect(calls.Load()).To(BeEquivalentTo(1))
+		})
+
+		It("should not call a probe whose host is not allowed", func() {
+			states.next("uid", 0, probe, []string{"prometheus.monitoring.svc"})
+
+			var result *abortProbeResult
+			Eventually(func() *abortProbeResult {
+				result, _ = states.next("uid", 0, probe, []string{"prometheus.monitoring.svc"})
+
+				return result
+			}).ShouldNot(BeNil())
+
+			Expect(result.err).To(MatchError(ContainSubstring("not allowed")))
+			Expect(calls.Load()).To(BeZero())
+		})
+
+		It("should forget the state of a cleared disruption", func() {
+			states.next("uid", 0, probe, allowedHosts)
+			states.clear("uid")
+
+			Expect(states.states).ToNot(HaveKey(BeEquivalentTo("uid")))
+		})
+	})
+})
+
+var _ = Describe("Abort conditions targets health", func() {
+	var (
+		r          *DisruptionReconciler
+		disruption *chaosv1beta1.Disruption
+	)
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+
+		count := intstr.FromInt(1)
+		disruption = &chaosv1beta1.Disruption{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "disruption", UID: "uid"},
+			Spec: chaosv1beta1.DisruptionSpec{
+				AbortConditions: &chaosv1beta1.DisruptionAbortConditions{
+					NotReadyTargets: &chaosv1beta1.NotReadyTargetsAbortCondition{Count: &count, Duration: "1m"},
+				},
+			},
+			Status: chaosv1beta1.DisruptionStatus{
+				TargetInjections: chaosv1beta1.TargetInjections{"foo": chaosv1beta1.TargetInjection{}},
+			},
+		}
+
+		r = &DisruptionReconciler{
+			Client:        fake.NewClientBuilder().WithScheme(scheme).Build(),
+			TargetsHealth: watchers.NewTargetsHealth(),
+			log:           zap.NewNop().Sugar(),
+		}
+	})
+
+	It("should use the health reported by the target watcher", func() {
+		r.TargetsHealth.Set("uid", "foo", watchers.TargetHealth{Ready: false, Since: time.Now().Add(-2 * time.Minute)})
+
+		reason, _, err := r.checkTargetsAbortConditions(disruption)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reason).To(ContainSubstring("1 target(s) have not been ready"))
+	})
+
+	It("should requeue until a not ready target reaches the duration", func() {
+		r.TargetsHealth.Set("uid", "foo", watchers.TargetHealth{Ready: false, Since: time.Now().Add(-30 * time.Second)})
+
+		reason, requeueAfter, err := r.checkTargetsAbortConditions(disruption)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reason).To(BeEmpty())
+		Expect(requeueAfter).To(BeNumerically("~", 30*time.Second, time.Second))
+	})
+
+	It("should fetch the targets not seen by the target watcher yet", func() {
+		Expect(r.Client.Create(context.Background(), &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "foo", CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour))},
+		})).To(Succeed())
+
+		reason, _, err := r.checkTargetsAbortConditions(disruption)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reason).To(ContainSubstring("1 target(s) have not been ready"))
+	})
+})
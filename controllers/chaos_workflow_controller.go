@@ -0,0 +1,348 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	chaosv1beta1 "github.com/DataDog/chaos-controller/api/v1beta1"
+	chaostypes "github.com/DataDog/chaos-controller/types"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// ChaosWorkflowReconciler reconciles a ChaosWorkflow object
+type ChaosWorkflowReconciler struct {
+	Client                 client.Client
+	Reader                 client.Reader // Use the k8s API without the cache, so a disruption which has just been created is never considered deleted
+	BaseLog                *zap.SugaredLogger
+	Scheme                 *runtime.Scheme
+	Recorder               record.EventRecorder
+	AbortProbeAllowedHosts []string // hosts the health probes can call
+	log                    *zap.SugaredLogger
+}
+
+// +kubebuilder:rbac:groups=chaos.datadoghq.com,resources=chaosworkflows,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=chaos.datadoghq.com,resources=chaosworkflows/status,verbs=update;patch
+// +kubebuilder:rbac:groups=chaos.datadoghq.com,resources=chaosworkflows/finalizers,verbs=update
+func (r *ChaosWorkflowReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	r.log = r.BaseLog.With("chaosWorkflowName", req.Name, "chaosWorkflowNamespace", req.Namespace)
+
+	instance := &chaosv1beta1.ChaosWorkflow{}
+	if err := r.Client.Get(ctx, req.NamespacedName, instance); err != nil {
+		// created disruptions are garbage collected through their owner reference
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if !instance.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	switch instance.Status.Phase {
+	case chaosv1beta1.ChaosWorkflowPhaseSucceeded, chaosv1beta1.ChaosWorkflowPhaseFailed:
+		return ctrl.Result{}, nil
+	case "":
+		r.log.Infow("starting chaos workflow", "steps", len(instance.Spec.Steps))
+
+		instance.Status.Phase = chaosv1beta1.ChaosWorkflowPhaseRunning
+		instance.Status.StartTime = &metav1.Time{Time: time.Now()}
+	}
+
+	requeueAfter, err := r.runSteps(ctx, instance)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.Client.Status().Update(ctx, instance); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error updating chaos workflow status: %w", err)
+	}
+
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// runSteps starts the next steps of the given instance as long as the current one is completed
+// it returns the delay after which the current step must be checked again
+func (r *ChaosWorkflowReconciler) runSteps(ctx context.Context, instance *chaosv1beta1.ChaosWorkflow) (time.Duration, error) {
+	for instance.Status.Phase == chaosv1beta1.ChaosWorkflowPhaseRunning {
+		current := len(instance.Status.Steps) - 1
+
+		if current < 0 || instance.Status.Steps[current].Phase == chaosv1beta1.ChaosWorkflowPhaseSucceeded {
+			if current == len(instance.Spec.Steps)-1 {
+				r.log.Infow("all the chaos workflow steps have been completed")
+				r.recordEvent(instance, chaosv1beta1.EventChaosWorkflowSucceeded)
+
+				instance.Status.Phase = chaosv1beta1.ChaosWorkflowPhaseSucceeded
+				instance.Status.CompletionTime = &metav1.Time{Time: time.Now()}
+
+				return 0, nil
+			}
+
+			current++
+
+			if err := r.startStep(ctx, instance, current); err != nil {
+				return 0, err
+			}
+
+			continue
+		}
+
+		requeueAfter, completed, err := r.checkStep(ctx, instance, current)
+		if err != nil || !completed {
+			return requeueAfter, err
+		}
+	}
+
+	return 0, nil
+}
+
+// startStep creates the disruptions of the given step, failing the workflow if any of them is refused by the API server
+func (r *ChaosWorkflowReconciler) startStep(ctx context.Context, instance *chaosv1beta1.ChaosWorkflow, index int) error {
+	step := instance.Spec.Steps[index]
+
+	instance.Status.Steps = append(instance.Status.Steps, chaosv1beta1.ChaosWorkflowStepStatus{
+		Name:      step.Name,
+		Phase:     chaosv1beta1.ChaosWorkflowPhaseRunning,
+		StartTime: &metav1.Time{Time: time.Now()},
+	})
+	stepStatus := &instance.Status.Steps[index]
+
+	r.log.Infow("starting chaos workflow step", "step", step.Name, "disruptions", len(step.Disruptions))
+	r.recordEvent(instance, chaosv1beta1.EventChaosWorkflowStepStarted, step.Name)
+
+	for _, stepDisruption := range step.Disruptions {
+		disruption, err := r.getStepDisruption(instance, step, stepDisruption)
+		if err != nil {
+			return fmt.Errorf("error building disruption %s of step %s: %w", stepDisruption.Name, step.Name, err)
+		}
+
+		// disruptions names are deterministic so a step never creates its disruptions twice
+		if err := r.Client.Create(ctx, disruption); err != nil && !errors.IsAlreadyExists(err) {
+			// other errors (e.g. an API server timeout) are transient, the status is not updated
+			// so the step is started again on the next reconcile loop
+			if !errors.IsInvalid(err) && !errors.IsForbidden(err) && !errors.IsBadRequest(err) {
+				return fmt.Errorf("error creating disruption %s of step %s: %w", disruption.Name, step.Name, err)
+			}
+
+			// a disruption refused by the admission webhook won't be accepted on retry either
+			r.deleteStepDisruptions(ctx, instance, stepStatus)
+			r.failWorkflow(instance, stepStatus, fmt.Sprintf("error creating disruption %s: %s", disruption.Name, err))
+
+			return nil
+		}
+
+		stepStatus.Disruptions = append(stepStatus.Disruptions, chaosv1beta1.ChaosWorkflowDisruptionStatus{Name: disruption.Name})
+	}
+
+	return nil
+}
+
+// checkStep checks whether the given running step is completed, failing the workflow if its timeout is over
+// it returns the delay after which the step must be checked again if it is not completed yet
+func (r *ChaosWorkflowReconciler) checkStep(ctx context.Context, instance *chaosv1beta1.ChaosWorkflow, index int) (time.Duration, bool, error) {
+	step := instance.Spec.Steps[index]
+	stepStatus := &instance.Status.Steps[index]
+	requeueAfter := time.Duration(0)
+
+	if stepStatus.ConditionsMetTime == nil {
+		if timeout := step.Timeout.Duration(); timeout > 0 {
+			remaining := time.Until(stepStatus.StartTime.Add(timeout))
+			if remaining <= 0 {
+				r.deleteStepDisruptions(ctx, instance, stepStatus)
+				r.failWorkflow(instance, stepStatus, fmt.Sprintf("the step conditions have not been met within its %s timeout, %s", timeout, stepStatus.Message))
+
+				return 0, false, nil
+			}
+
+			requeueAfter = remaining
+		}
+
+		message, conditionsRequeueAfter, err := r.checkStepConditions(ctx, instance, index)
+		if err != nil {
+			return 0, false, err
+		}
+
+		if message != "" {
+			stepStatus.Message = message
+
+			return minDuration(requeueAfter, conditionsRequeueAfter), false, nil
+		}
+
+		r.log.Infow("chaos workflow step conditions met", "step", step.Name)
+
+		stepStatus.ConditionsMetTime = &metav1.Time{Time: time.Now()}
+	}
+
+	if remaining := time.Until(stepStatus.ConditionsMetTime.Add(step.Wait.Duration())); remaining > 0 {
+		stepStatus.Message = fmt.Sprintf("waiting %s before completing the step", step.Wait.Duration())
+
+		return remaining, false, nil
+	}
+
+	r.log.Infow("chaos workflow step completed", "step", step.Name)
+	r.recordEvent(instance, chaosv1beta1.EventChaosWorkflowStepCompleted, step.Name)
+
+	stepStatus.Phase = chaosv1beta1.ChaosWorkflowPhaseSucceeded
+	stepStatus.CompletionTime = &metav1.Time{Time: time.Now()}
+	stepStatus.Message = ""
+
+	return 0, true, nil
+}
+
+// checkStepConditions updates the status of the disruptions of the given step and checks its health probes
+// it returns a message describing what the step is still waiting for, empty once all its conditions are met,
+// and the delay after which the conditions must be checked again
+func (r *ChaosWorkflowReconciler) checkStepConditions(ctx context.Context, instance *chaosv1beta1.ChaosWorkflow, index int) (string, time.Duration, error) {
+	step := instance.Spec.Steps[index]
+	stepStatus := &instance.Status.Steps[index]
+	requeueAfter := time.Duration(0)
+	waitingDisruptions := []string{}
+
+	for i := range stepStatus.Disruptions {
+		disruptionStatus := &stepStatus.Disruptions[i]
+
+		if !disruptionStatus.Finished {
+			disruption := chaosv1beta1.Disruption{}
+
+			err := r.Reader.Get(ctx, types.NamespacedName{Namespace: instance.Namespace, Name: disruptionStatus.Name}, &disruption)
+			if err != nil && !errors.IsNotFound(err) {
+				return "", 0, fmt.Errorf("error getting disruption %s: %w", disruptionStatus.Name, err)
+			}
+
+			// a deleted disruption (e.g. aborted) is considered finished
+			if errors.IsNotFound(err) || !disruption.DeletionTimestamp.IsZero() {
+				disruptionStatus.Finished = true
+			} else {
+				disruptionStatus.InjectionStatus = string(disruption.Status.InjectionStatus)
+
+				remaining := calculateRemainingDuration(disruption)
+				disruptionStatus.Finished = remaining <= 0
+
+				if !disruptionStatus.Finished && step.GetWaitFor() == chaosv1beta1.ChaosWorkflowWaitForFinished {
+					requeueAfter = minDuration(requeueAfter, remaining)
+				}
+			}
+		}
+
+		// a disruption finished before being injected is not waited for anymore
+		if !disruptionStatus.Finished && (step.GetWaitFor() == chaosv1beta1.ChaosWorkflowWaitForFinished || disruptionStatus.InjectionStatus != string(chaostypes.DisruptionInjectionStatusInjected)) {
+			waitingDisruptions = append(waitingDisruptions, disruptionStatus.Name)
+		}
+	}
+
+	if len(waitingDisruptions) > 0 {
+		return fmt.Sprintf("waiting for disruptions %s to be %s", strings.Join(waitingDisruptions, ", "), strings.ToLower(string(step.GetWaitFor()))), requeueAfter, nil
+	}
+
+	for _, probe := range step.HealthProbes {
+		value, err := getAbortProbeValue(probe, r.AbortProbeAllowedHosts)
+		if err != nil {
+			r.log.Warnw("error checking chaos workflow step health probe", "step", step.Name, "probe", probe.GetName(), "error", err)
+
+			return fmt.Sprintf("waiting for the health probe %s to be reachable: %s", probe.GetName(), err), probe.GetInterval(), nil
+		}
+
+		crossed, err := probe.IsCrossed(value)
+		if err != nil {
+			return "", 0, err
+		}
+
+		if crossed {
+			return fmt.Sprintf("waiting for the health probe %s value %g not to be %s the threshold of %s", probe.GetName(), value, probe.Operator, probe.Threshold), probe.GetInterval(), nil
+		}
+	}
+
+	return "", 0, nil
+}
+
+// failWorkflow marks the given step and its workflow as failed for the given reason
+func (r *ChaosWorkflowReconciler) failWorkflow(instance *chaosv1beta1.ChaosWorkflow, stepStatus *chaosv1beta1.ChaosWorkflowStepStatus, reason string) {
+	r.log.Warnw("chaos workflow step failed", "step", stepStatus.Name, "reason", reason)
+	r.recordEvent(instance, chaosv1beta1.EventChaosWorkflowFailed, stepStatus.Name, reason)
+
+	now := &metav1.Time{Time: time.Now()}
+
+	stepStatus.Phase = chaosv1beta1.ChaosWorkflowPhaseFailed
+	stepStatus.CompletionTime = now
+	stepStatus.Message = reason
+	instance.Status.Phase = chaosv1beta1.ChaosWorkflowPhaseFailed
+	instance.Status.CompletionTime = now
+}
+
+// deleteStepDisruptions deletes the not finished disruptions of the given step so a failed workflow stops disrupting its targets
+func (r *ChaosWorkflowReconciler) deleteStepDisruptions(ctx context.Context, instance *chaosv1beta1.ChaosWorkflow, stepStatus *chaosv1beta1.ChaosWorkflowStepStatus) {
+	for _, disruptionStatus := range stepStatus.Disruptions {
+		if disruptionStatus.Finished {
+			continue
+		}
+
+		disruption := &chaosv1beta1.Disruption{ObjectMeta: metav1.ObjectMeta{Name: disruptionStatus.Name, Namespace: instance.Namespace}}
+
+		r.log.Infow("deleting chaos workflow step disruption", "step", stepStatus.Name, "disruptionName", disruption.Name)
+
+		if err := r.Client.Delete(ctx, disruption); client.IgnoreNotFound(err) != nil {
+			r.log.Errorw("error deleting chaos workflow step disruption", "disruptionName", disruption.Name, "error", err)
+		}
+	}
+}
+
+// getStepDisruption builds the disruption to create for the given step from its spec
+// it inherits the instance labels and annotations (e.g. the safemode environment annotation)
+func (r *ChaosWorkflowReconciler) getStepDisruption(instance *chaosv1beta1.ChaosWorkflow, step chaosv1beta1.ChaosWorkflowStep, stepDisruption chaosv1beta1.ChaosWorkflowDisruption) (*chaosv1beta1.Disruption, error) {
+	disruption := &chaosv1beta1.Disruption{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        chaosv1beta1.GetChaosWorkflowDisruptionName(instance.Name, step.Name, stepDisruption.Name),
+			Namespace:   instance.Namespace,
+			Labels:      map[string]string{},
+			Annotations: map[string]string{},
+		},
+		Spec: *stepDisruption.Spec.DeepCopy(),
+	}
+
+	for key, value := range instance.Labels {
+		disruption.Labels[key] = value
+	}
+
+	for key, value := range instance.Annotations {
+		if key != corev1.LastAppliedConfigAnnotation {
+			disruption.Annotations[key] = value
+		}
+	}
+
+	disruption.Labels[chaostypes.ChaosWorkflowNameLabel] = instance.Name
+	disruption.Labels[chaostypes.ChaosWorkflowStepLabel] = step.Name
+
+	if err := controllerutil.SetControllerReference(instance, disruption, r.Scheme); err != nil {
+		return nil, err
+	}
+
+	return disruption, nil
+}
+
+// recordEvent records the given event on the instance
+func (r *ChaosWorkflowReconciler) recordEvent(instance *chaosv1beta1.ChaosWorkflow, reason chaosv1beta1.DisruptionEventReason, args ...interface{}) {
+	event := chaosv1beta1.Events[reason]
+
+	r.Recorder.Eventf(instance, event.Type, string(reason), event.OnDisruptionTemplateMessage, args...)
+}
+
+// SetupWithManager setups the current reconciler with the given manager
+func (r *ChaosWorkflowReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&chaosv1beta1.ChaosWorkflow{}).
+		Owns(&chaosv1beta1.Disruption{}).
+		Complete(r)
+}
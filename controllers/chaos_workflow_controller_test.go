@@ -0,0 +1,385 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	chaosv1beta1 "github.com/DataDog/chaos-controller/api/v1beta1"
+	chaostypes "github.com/DataDog/chaos-controller/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// statusConflictClient fails the status updates with a conflict, like a concurrent update of the object
+type statusConflictClient struct {
+	client.Client
+}
+
+func (c statusConflictClient) Status() client.SubResourceWriter {
+	return statusConflictWriter{c.Client.Status()}
+}
+
+type statusConflictWriter struct {
+	client.SubResourceWriter
+}
+
+func (w statusConflictWriter) Update(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) error {
+	return k8serrors.NewConflict(schema.GroupResource{Group: chaosv1beta1.GroupVersion.Group, Resource: "chaosworkflows"}, obj.GetName(), errors.New("the object has been modified"))
+}
+
+// creationTimestampClient sets the creation timestamp of the created objects like the API server does, unlike the fake client
+type creationTimestampClient struct {
+	client.Client
+}
+
+func (c creationTimestampClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	obj.SetCreationTimestamp(metav1.Now())
+
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+var _ = Describe("Chaos workflow reconciler", func() {
+	var (
+		workflow   *chaosv1beta1.ChaosWorkflow
+		disruption *chaosv1beta1.Disruption
+		objects    []client.Object
+		k8sClient  client.Client
+		apiClient  client.Client
+		recorder   *record.FakeRecorder
+		r          *ChaosWorkflowReconciler
+	)
+
+	disruptionName := chaosv1beta1.GetChaosWorkflowDisruptionName("workflow", "first", "foo")
+
+	reconcile := func() (ctrl.Result, error) {
+		return r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "workflow"}})
+	}
+
+	getWorkflow := func() *chaosv1beta1.ChaosWorkflow {
+		instance := &chaosv1beta1.ChaosWorkflow{}
+		ExpectWithOffset(1, k8sClient.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "workflow"}, instance)).To(Succeed())
+
+		return instance
+	}
+
+	// updateDisruption applies the given change to the disruption created by the first step
+	updateDisruption := func(update func(*chaosv1beta1.Disruption)) {
+		instance := &chaosv1beta1.Disruption{}
+		ExpectWithOffset(1, k8sClient.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: disruptionName}, instance)).To(Succeed())
+		update(instance)
+		ExpectWithOffset(1, k8sClient.Update(context.Background(), instance)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		workflow = &chaosv1beta1.ChaosWorkflow{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "workflow",
+				Labels:    map[string]string{"team": "chaos"},
+			},
+			Spec: chaosv1beta1.ChaosWorkflowSpec{
+				Steps: []chaosv1beta1.ChaosWorkflowStep{
+					{
+						Name:    "first",
+						WaitFor: chaosv1beta1.ChaosWorkflowWaitForInjected,
+						Timeout: "10m",
+						Disruptions: []chaosv1beta1.ChaosWorkflowDisruption{
+							{
+								Name: "foo",
+								Spec: chaosv1beta1.DisruptionSpec{
+									Selector:         map[string]string{"app": "foo"},
+									Duration:         "1h",
+									ContainerFailure: &chaosv1beta1.ContainerFailureSpec{},
+								},
+							},
+						},
+					},
+					{
+						Name: "second",
+					},
+				},
+			},
+		}
+
+		disruption = &chaosv1beta1.Disruption{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         "default",
+				Name:              disruptionName,
+				CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Minute)),
+			},
+			Spec: workflow.Spec.Steps[0].Disruptions[0].Spec,
+		}
+		objects = []client.Object{disruption}
+	})
+
+	JustBeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(chaosv1beta1.AddToScheme(scheme)).To(Succeed())
+
+		k8sClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(objects, workflow)...).Build()
+		apiClient = creationTimestampClient{k8sClient}
+
+		recorder = record.NewFakeRecorder(10)
+		r = &ChaosWorkflowReconciler{
+			Client:   apiClient,
+			Reader:   k8sClient,
+			BaseLog:  zap.NewNop().Sugar(),
+			Scheme:   scheme,
+			Recorder: recorder,
+		}
+	})
+
+	Context("running the steps", func() {
+		It("should start the first step and wait for its disruptions to be injected", func() {
+			_, err := reconcile()
+			Expect(err).ToNot(HaveOccurred())
+
+			instance := getWorkflow()
+			Expect(instance.Status.Phase).To(Equal(chaosv1beta1.ChaosWorkflowPhaseRunning))
+			Expect(instance.Status.Steps).To(HaveLen(1))
+			Expect(instance.Status.Steps[0].Phase).To(Equal(chaosv1beta1.ChaosWorkflowPhaseRunning))
+			Expect(instance.Status.Steps[0].Disruptions).To(ConsistOf(HaveField("Name", disruptionName)))
+			Expect(instance.Status.Steps[0].Message).To(Equal(fmt.Sprintf("waiting for disruptions %s to be injected", disruptionName)))
+			Expect(recorder.Events).To(Receive(ContainSubstring(string(chaosv1beta1.EventChaosWorkflowStepStarted))))
+		})
+
+		It("should complete the steps one after the other once the disruptions are injected", func() {
+			_, err := reconcile()
+			Expect(err).ToNot(HaveOccurred())
+
+			updateDisruption(func(d *chaosv1beta1.Disruption) {
+				d.Status.InjectionStatus = chaostypes.DisruptionInjectionStatusInjected
+			})
+
+			_, err = reconcile()
+			Expect(err).ToNot(HaveOccurred())
+
+			instance := getWorkflow()
+			Expect(instance.Status.Phase).To(Equal(chaosv1beta1.ChaosWorkflowPhaseSucceeded))
+			Expect(instance.Status.CompletionTime).ToNot(BeNil())
+			Expect(instance.Status.Steps).To(HaveLen(2))
+			Expect(instance.Status.Steps).To(HaveEach(HaveField("Phase", chaosv1beta1.ChaosWorkflowPhaseSucceeded)))
+			Expect(instance.Status.Steps[0].Disruptions[0].InjectionStatus).To(Equal(string(chaostypes.DisruptionInjectionStatusInjected)))
+		})
+
+		Context("with a step waiting for its disruptions to be finished", func() {
+			BeforeEach(func() {
+				workflow.Spec.Steps[0].WaitFor = chaosv1beta1.ChaosWorkflowWaitForFinished
+				workflow.Spec.Steps[0].Timeout = ""
+				disruption.Status.InjectionStatus = chaostypes.DisruptionInjectionStatusInjected
+			})
+
+			It("should not complete the step while the injected disruptions are running", func() {
+				result, err := reconcile()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.RequeueAfter).To(BeNumerically("~", 59*time.Minute, time.Second))
+
+				instance := getWorkflow()
+				Expect(instance.Status.Steps).To(HaveLen(1))
+				Expect(instance.Status.Steps[0].Message).To(Equal(fmt.Sprintf("waiting for disruptions %s to be finished", disruptionName)))
+			})
+
+			It("should complete the step once the disruptions are deleted", func() {
+				_, err := reconcile()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(k8sClient.Delete(context.Background(), disruption)).To(Succeed())
+
+				_, err = reconcile()
+				Expect(err).ToNot(HaveOccurred())
+
+				instance := getWorkflow()
+				Expect(instance.Status.Phase).To(Equal(chaosv1beta1.ChaosWorkflowPhaseSucceeded))
+				Expect(instance.Status.Steps[0].Disruptions[0].Finished).To(BeTrue())
+			})
+		})
+
+		Context("with a step waiting before completing", func() {
+			BeforeEach(func() {
+				workflow.Spec.Steps[0].Wait = "5m"
+				disruption.Status.InjectionStatus = chaostypes.DisruptionInjectionStatusInjected
+			})
+
+			It("should requeue the step until the wait is over", func() {
+				result, err := reconcile()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.RequeueAfter).To(BeNumerically("~", 5*time.Minute, time.Second))
+
+				instance := getWorkflow()
+				Expect(instance.Status.Steps[0].Phase).To(Equal(chaosv1beta1.ChaosWorkflowPhaseRunning))
+				Expect(instance.Status.Steps[0].ConditionsMetTime).ToNot(BeNil())
+			})
+		})
+	})
+
+	Context("with a step timeout over", func() {
+		BeforeEach(func() {
+			workflow.Status = chaosv1beta1.ChaosWorkflowStatus{
+				Phase:     chaosv1beta1.ChaosWorkflowPhaseRunning,
+				StartTime: &metav1.Time{Time: time.Now().Add(-time.Hour)},
+				Steps: []chaosv1beta1.ChaosWorkflowStepStatus{
+					{
+						Name:        "first",
+						Phase:       chaosv1beta1.ChaosWorkflowPhaseRunning,
+						StartTime:   &metav1.Time{Time: time.Now().Add(-time.Hour)},
+						Disruptions: []chaosv1beta1.ChaosWorkflowDisruptionStatus{{Name: disruptionName}},
+					},
+				},
+			}
+		})
+
+		It("should fail the workflow and delete the step disruptions", func() {
+			_, err := reconcile()
+			Expect(err).ToNot(HaveOccurred())
+
+			instance := getWorkflow()
+			Expect(instance.Status.Phase).To(Equal(chaosv1beta1.ChaosWorkflowPhaseFailed))
+			Expect(instance.Status.Steps[0].Phase).To(Equal(chaosv1beta1.ChaosWorkflowPhaseFailed))
+			Expect(instance.Status.Steps[0].Message).To(ContainSubstring("have not been met within its 10m0s timeout"))
+			Expect(recorder.Events).To(Receive(ContainSubstring(string(chaosv1beta1.EventChaosWorkflowFailed))))
+
+			err = k8sClient.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: disruptionName}, &chaosv1beta1.Disruption{})
+			Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	Context("with health probes", func() {
+		var (
+			server *httptest.Server
+			value  atomic.Int32
+		)
+
+		BeforeEach(func() {
+			value.Store(42)
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprintf(w, `{"value": %d}`, value.Load())
+			}))
+
+			disruption.Status.InjectionStatus = chaostypes.DisruptionInjectionStatusInjected
+			workflow.Spec.Steps[0].HealthProbes = []chaosv1beta1.AbortProbe{
+				{Name: "errors", Type: chaosv1beta1.AbortProbeTypeJSON, URL: server.URL, Query: "value", Operator: chaosv1beta1.AbortProbeOperatorAbove, Threshold: "10"},
+			}
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		JustBeforeEach(func() {
+			r.AbortProbeAllowedHosts = []string{"127.0.0.1"}
+		})
+
+		It("should only complete the step once the probes do not cross their threshold", func() {
+			_, err := reconcile()
+			Expect(err).ToNot(HaveOccurred())
+
+			instance := getWorkflow()
+			Expect(instance.Status.Steps).To(HaveLen(1))
+			Expect(instance.Status.Steps[0].Message).To(Equal("waiting for the health probe errors value 42 not to be above the threshold of 10"))
+
+			value.Store(5)
+
+			_, err = reconcile()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(getWorkflow().Status.Phase).To(Equal(chaosv1beta1.ChaosWorkflowPhaseSucceeded))
+		})
+
+		It("should keep waiting when the probe host is not allowed", func() {
+			r.AbortProbeAllowedHosts = nil
+
+			_, err := reconcile()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(getWorkflow().Status.Steps[0].Message).To(ContainSubstring("waiting for the health probe errors to be reachable"))
+		})
+	})
+
+	Context("creating the step disruptions", func() {
+		BeforeEach(func() {
+			objects = []client.Object{}
+		})
+
+		It("should create the disruptions with the workflow labels and owner reference", func() {
+			_, err := reconcile()
+			Expect(err).ToNot(HaveOccurred())
+
+			created := &chaosv1beta1.Disruption{}
+			Expect(k8sClient.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: disruptionName}, created)).To(Succeed())
+			Expect(created.Labels).To(HaveKeyWithValue("team", "chaos"))
+			Expect(created.Labels).To(HaveKeyWithValue(chaostypes.ChaosWorkflowNameLabel, "workflow"))
+			Expect(created.Labels).To(HaveKeyWithValue(chaostypes.ChaosWorkflowStepLabel, "first"))
+			Expect(created.OwnerReferences).To(ConsistOf(HaveField("Name", "workflow")))
+		})
+
+		It("should fail the workflow when a disruption is refused by the admission webhook", func() {
+			r.Client = createErrorClient{Client: apiClient, err: k8serrors.NewForbidden(schema.GroupResource{Group: chaosv1beta1.GroupVersion.Group, Resource: "disruptions"}, disruptionName, errors.New("denied by the webhook"))}
+
+			_, err := reconcile()
+			Expect(err).ToNot(HaveOccurred())
+
+			instance := getWorkflow()
+			Expect(instance.Status.Phase).To(Equal(chaosv1beta1.ChaosWorkflowPhaseFailed))
+			Expect(instance.Status.Steps[0].Message).To(ContainSubstring("denied by the webhook"))
+		})
+
+		It("should retry the step on transient errors", func() {
+			r.Client = createErrorClient{Client: apiClient, err: k8serrors.NewServerTimeout(schema.GroupResource{Group: chaosv1beta1.GroupVersion.Group, Resource: "disruptions"}, "create", 1)}
+
+			_, err := reconcile()
+			Expect(err).To(HaveOccurred())
+			Expect(getWorkflow().Status.Phase).To(BeEmpty())
+
+			r.Client = apiClient
+
+			_, err = reconcile()
+			Expect(err).ToNot(HaveOccurred())
+
+			instance := getWorkflow()
+			Expect(instance.Status.Phase).To(Equal(chaosv1beta1.ChaosWorkflowPhaseRunning))
+			Expect(instance.Status.Steps[0].Disruptions).To(ConsistOf(HaveField("Name", disruptionName)))
+		})
+
+		It("should replay the step without creating its disruptions twice after a status update conflict", func() {
+			r.Client = statusConflictClient{apiClient}
+
+			_, err := reconcile()
+			Expect(k8serrors.IsConflict(errors.Unwrap(err))).To(BeTrue())
+			Expect(getWorkflow().Status.Phase).To(BeEmpty())
+			Expect(k8sClient.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: disruptionName}, &chaosv1beta1.Disruption{})).To(Succeed())
+
+			r.Client = apiClient
+
+			_, err = reconcile()
+			Expect(err).ToNot(HaveOccurred())
+
+			disruptions := chaosv1beta1.DisruptionList{}
+			Expect(k8sClient.List(context.Background(), &disruptions)).To(Succeed())
+			Expect(disruptions.Items).To(HaveLen(1))
+
+			instance := getWorkflow()
+			Expect(instance.Status.Phase).To(Equal(chaosv1beta1.ChaosWorkflowPhaseRunning))
+			Expect(instance.Status.Steps).To(HaveLen(1))
+			Expect(instance.Status.Steps[0].Disruptions).To(ConsistOf(HaveField("Name", disruptionName)))
+		})
+	})
+})
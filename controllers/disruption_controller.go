@@ -11,7 +11,9 @@ import (
 	"math"
 	"math/rand"
 	"reflect"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	chaosapi "github.com/DataDog/chaos-controller/api"
@@ -58,6 +60,7 @@ type DisruptionReconciler struct {
 	InjectorAnnotations                   map[string]string
 	InjectorLabels                        map[string]string
 	InjectorServiceAccount                string
+	InjectorNodeFailureServiceAccount     string
 	InjectorImage                         string
 	ImagePullSecrets                      string
 	log                                   *zap.SugaredLogger
@@ -65,6 +68,9 @@ type DisruptionReconciler struct {
 	InjectorDNSDisruptionDNSServer        string
 	InjectorDNSDisruptionKubeDNS          string
 	InjectorNetworkDisruptionAllowedHosts []string
+	InjectorControlPort                   int
+	InjectorControlTokenSecret            string
+	AbortProbeAllowedHosts                []string // hosts the abort condition probes can call
 	SafetyNets                            []safemode.Safemode
 	ExpiredDisruptionGCDelay              *time.Duration
 	CacheContextStore                     map[string]CtxTuple
@@ -73,6 +79,9 @@ type DisruptionReconciler struct {
 	EnableObserver                        bool          // Enable Observer on targets update with dynamic targeting
 	CloudServicesProvidersManager         *cloudservice.CloudServicesProvidersManager
 	DisruptionsWatchersManager            watchers.DisruptionsWatchersManager
+	TargetsHealth                         *watchers.TargetsHealth // health of the targets seen by the disruption target watchers
+	abortProbes                           abortProbesStates       // state of the abort condition probes of the handled disruptions
+	podDisruptionBudgetRefusals           sync.Map                // targets refused by the last pod disruption budget event recorded per disruption
 }
 
 type CtxTuple struct {
@@ -89,6 +98,8 @@ type CtxTuple struct {
 // +kubebuilder:rbac:groups=core,resources=pods/status,verbs=update;patch
 // +kubebuilder:rbac:groups=core,resources=nodes,verbs=list;watch
 // +kubebuilder:rbac:groups=core,resources=services,verbs=list;watch
+// +kubebuilder:rbac:groups=apps,resources=replicasets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch
 func (r *DisruptionReconciler) Reconcile(_ context.Context, req ctrl.Request) (res ctrl.Result, err error) {
 	instance := &chaosv1beta1.Disruption{}
 	tsStart := time.Now()
@@ -196,6 +207,13 @@ func (r *DisruptionReconciler) Reconcile(_ context.Context, req ctrl.Request) (r
 			r.recordEventOnDisruption(instance, chaosv1beta1.EventDisruptionFinished, "", "")
 
 			r.DisruptionsWatchersManager.RemoveAllWatchers(instance)
+			r.abortProbes.clear(instance.UID)
+			r.podDisruptionBudgetRefusals.Delete(instance.UID)
+
+			if r.TargetsHealth != nil {
+				r.TargetsHealth.Clear(instance.UID)
+			}
+
 			controllerutil.RemoveFinalizer(instance, chaostypes.DisruptionFinalizer)
 
 			if err := r.Client.Update(context.Background(), instance); err != nil {
@@ -267,6 +285,13 @@ func (r *DisruptionReconciler) Reconcile(_ context.Context, req ctrl.Request) (r
 			return ctrl.Result{Requeue: false}, nil
 		}
 
+		// clean all chaos pods while the disruption is paused, and resume it otherwise
+		if instance.Spec.Paused {
+			return r.pauseDisruption(instance)
+		} else if instance.Status.PausedSince != nil {
+			r.resumeDisruption(instance)
+		}
+
 		// check if we have reached trigger.createPods. If not, skip the rest of reconciliation.
 		requeueAfter := time.Until(TimeToCreatePods(instance.Spec.Triggers, instance.CreationTimestamp.Time))
 		if requeueAfter > (time.Second * 5) {
@@ -276,6 +301,34 @@ func (r *DisruptionReconciler) Reconcile(_ context.Context, req ctrl.Request) (r
 			return ctrl.Result{RequeueAfter: requeueAfter}, nil
 		}
 
+		// abort the disruption if any of its abort conditions holds once the injection started
+		abortRequeueAfter := time.Duration(0)
+
+		if instance.Spec.AbortConditions != nil && time.Now().After(TimeToInject(instance.Spec.Triggers, instance.CreationTimestamp.Time)) {
+			reason, requeueAfter, err := r.checkAbortConditions(instance)
+			if err != nil {
+				return ctrl.Result{}, fmt.Errorf("error checking abort conditions: %w", err)
+			}
+
+			if reason != "" {
+				return ctrl.Result{}, r.abortDisruption(instance, reason)
+			}
+
+			abortRequeueAfter = requeueAfter
+		}
+
+		// move to the next rollout stage once the current one is over, the targets being widened below
+		rolloutRequeueAfter := time.Duration(0)
+
+		if instance.Spec.Rollout != nil {
+			requeueAfter, err := r.progressRollout(instance)
+			if err != nil {
+				return ctrl.Result{}, fmt.Errorf("error progressing disruption rollout: %w", err)
+			}
+
+			rolloutRequeueAfter = requeueAfter
+		}
+
 		// retrieve targets from label selector
 		if err := r.selectTargets(instance); err != nil {
 			return ctrl.Result{}, fmt.Errorf("error selecting targets: %w", err)
@@ -311,7 +364,7 @@ func (r *DisruptionReconciler) Reconcile(_ context.Context, req ctrl.Request) (r
 
 		return ctrl.Result{
 				Requeue:      true,
-				RequeueAfter: disruptionEndAt,
+				RequeueAfter: minDuration(minDuration(disruptionEndAt, abortRequeueAfter), rolloutRequeueAfter),
 			},
 			r.Client.Update(context.Background(), instance)
 	}
@@ -351,6 +404,7 @@ func (r *DisruptionReconciler) updateInjectionStatus(instance *chaosv1beta1.Disr
 		case
 			chaostypes.DisruptionInjectionStatusInjected,
 			chaostypes.DisruptionInjectionStatusPausedInjected,
+			chaostypes.DisruptionInjectionStatusPaused,
 			chaostypes.DisruptionInjectionStatusPreviouslyInjected:
 			status = chaostypes.DisruptionInjectionStatusPausedInjected
 			if terminationStatus == tsDefinitivelyTerminated {
@@ -385,6 +439,8 @@ func (r *DisruptionReconciler) updateInjectionStatus(instance *chaosv1beta1.Disr
 		for _, chaosPod := range chaosPods {
 			podReady := false
 
+			r.updateTargetInjectionResult(instance, chaosPod)
+
 			// search for the "Ready" condition in the pod conditions
 			for _, cond := range chaosPod.Status.Conditions {
 				if cond.Type == corev1.PodReady {
@@ -403,6 +459,11 @@ func (r *DisruptionReconciler) updateInjectionStatus(instance *chaosv1beta1.Disr
 			// consider the disruption as not fully injected if at least one not ready pod is found
 			if !podReady {
 				r.log.Debugw("chaos pod is not ready yet", "chaosPod", chaosPod.Name)
+
+				// a running chaos pod not ready anymore after injecting the target reports its injection drifted
+				if targetInjection := instance.Status.TargetInjections[chaosPod.Labels[chaostypes.TargetLabel]]; targetInjection.InjectionStatus == chaostypes.DisruptionTargetInjectionStatusInjected && targetInjection.InjectorPodName == chaosPod.Name && chaosPod.DeletionTimestamp.IsZero() {
+					r.updateTargetInjectionStatus(instance, chaosPod, chaostypes.DisruptionTargetInjectionStatusDrifted, metav1.Now())
+				}
 			}
 		}
 
@@ -414,6 +475,15 @@ func (r *DisruptionReconciler) updateInjectionStatus(instance *chaosv1beta1.Disr
 		}
 	}
 
+	// count the targets with a failed injection or cleanup, results being kept once their chaos pods are gone
+	instance.Status.FailedTargetsCount = 0
+
+	for _, targetInjection := range instance.Status.TargetInjections {
+		if targetInjection.HasFailedResult() {
+			instance.Status.FailedTargetsCount++
+		}
+	}
+
 	// update instance status
 	r.log.Infof("from status %s to %s, terminationStatus is %d, readyPodCount is %d, desired targets count is %d", instance.Status.InjectionStatus, status, terminationStatus, readyPodsCount, instance.Status.DesiredTargetsCount)
 	instance.Status.InjectionStatus = status
@@ -449,10 +519,18 @@ func (r *DisruptionReconciler) startInjection(instance *chaosv1beta1.Disruption)
 		chaosPodsMap[targetName] = make(map[string]bool)
 	}
 
+	// kinds whose tunable fields have been updated, to record a single event per kind
+	tunedKinds := map[string]struct{}{}
+
 	for _, chaosPod := range chaosPods {
 		if !instance.Status.HasTarget(chaosPod.Labels[chaostypes.TargetLabel]) {
 			r.deleteChaosPod(instance, chaosPod)
 		} else {
+			if err := r.replaceOutdatedChaosPod(instance, chaosPod, tunedKinds); err != nil {
+				return err
+			}
+
+			// a replaced chaos pod is created again once the old one is gone, so both injectors don't overlap
 			chaosPodsMap[chaosPod.Labels[chaostypes.TargetLabel]][chaosPod.Labels[chaostypes.DisruptionKindLabel]] = true
 		}
 	}
@@ -492,6 +570,37 @@ func (r *DisruptionReconciler) startInjection(instance *chaosv1beta1.Disruption)
 	return nil
 }
 
+// replaceOutdatedChaosPod deletes the given chaos pod if the tunable fields of its disruption kind have been updated since it was created,
+// the injector cleaning the disruption before a new chaos pod injects the new values
+func (r *DisruptionReconciler) replaceOutdatedChaosPod(instance *chaosv1beta1.Disruption, chaosPod corev1.Pod, tunedKinds map[string]struct{}) error {
+	injectedTunables, found := chaosPod.Annotations[chaostypes.TunablesAnnotation]
+	if !found || !chaosPod.DeletionTimestamp.IsZero() {
+		return nil
+	}
+
+	kind := chaosPod.Labels[chaostypes.DisruptionKindLabel]
+
+	tunables, err := instance.Spec.Tunables(chaostypes.DisruptionKindName(kind))
+	if err != nil {
+		return fmt.Errorf("error getting the tunable fields of the disruption: %w", err)
+	}
+
+	if tunables == injectedTunables {
+		return nil
+	}
+
+	if _, recorded := tunedKinds[kind]; !recorded {
+		r.log.Infow("disruption tunable fields updated, replacing its chaos pods", "kind", kind, "oldTunables", injectedTunables, "newTunables", tunables)
+		r.recordEventOnDisruption(instance, chaosv1beta1.EventDisruptionTuned, fmt.Sprintf("%s from %s to %s", kind, injectedTunables, tunables), "")
+
+		tunedKinds[kind] = struct{}{}
+	}
+
+	r.deleteChaosPod(instance, chaosPod)
+
+	return nil
+}
+
 // createChaosPods attempts to create all the chaos pods for a given target. If a given chaos pod already exists, it is not recreated.
 func (r *DisruptionReconciler) createChaosPods(instance *chaosv1beta1.Disruption, target string) error {
 	var err error
@@ -762,7 +871,7 @@ func (r *DisruptionReconciler) handleChaosPodTermination(instance *chaosv1beta1.
 
 	// It is always safe to remove some chaos pods. It is usually hard to tell if these chaos pods have
 	// succeeded or not, but they have no possibility of leaving side effects, so we choose to always remove the finalizer.
-	if chaosv1beta1.DisruptionHasNoSideEffects(chaosPod.Labels[chaostypes.DisruptionKindLabel]) {
+	if instance.Spec.HasNoSideEffects(chaosPod.Labels[chaostypes.DisruptionKindLabel]) {
 		removeFinalizer = true
 		ignoreStatus = true
 	}
@@ -832,6 +941,35 @@ func (r *DisruptionReconciler) handleChaosPodTermination(instance *chaosv1beta1.
 	}
 }
 
+// updateTargetInjectionResult records the injection result reported by the given chaos pod in the target injections of the given instance
+func (r *DisruptionReconciler) updateTargetInjectionResult(instance *chaosv1beta1.Disruption, chaosPod corev1.Pod) {
+	result, err := chaosv1beta1.GetInjectionResult(chaosPod.Annotations)
+	if err != nil {
+		r.log.Warnw("unable to read the injection result reported by the chaos pod", "chaosPod", chaosPod.Name, "error", err)
+
+		return
+	}
+
+	// the injector did not report any result yet
+	if result == nil {
+		return
+	}
+
+	target := chaosPod.Labels[chaostypes.TargetLabel]
+
+	targetInjection, found := instance.Status.TargetInjections[target]
+	if !found {
+		return
+	}
+
+	if targetInjection.Results == nil {
+		targetInjection.Results = map[chaostypes.DisruptionKindName]chaosv1beta1.InjectionResult{}
+	}
+
+	targetInjection.Results[chaostypes.DisruptionKindName(chaosPod.Labels[chaostypes.DisruptionKindLabel])] = *result
+	instance.Status.TargetInjections[target] = targetInjection
+}
+
 func (r *DisruptionReconciler) updateTargetInjectionStatus(instance *chaosv1beta1.Disruption, chaosPod corev1.Pod, status chaostypes.DisruptionTargetInjectionStatus, since metav1.Time) {
 	targetInjection := instance.Status.TargetInjections[chaosPod.Labels[chaostypes.TargetLabel]]
 	targetInjection.InjectionStatus = status
@@ -868,10 +1006,12 @@ func (r *DisruptionReconciler) selectTargets(instance *chaosv1beta1.Disruption)
 
 	instance.Status.RemoveDeadTargets(matchingTargets)
 
-	// instance.Spec.Count is a string that either represents a percentage or a value, we do the translation here
-	targetsCount, err := getScaledValueFromIntOrPercent(instance.Spec.Count, len(matchingTargets), true)
+	// the count (or the one of the current rollout stage) is a string that either represents a percentage or a value, we do the translation here
+	count := instance.GetCurrentCount()
+
+	targetsCount, err := getScaledValueFromIntOrPercent(count, len(matchingTargets), true)
 	if err != nil {
-		targetsCount = instance.Spec.Count.IntValue()
+		targetsCount = count.IntValue()
 	}
 
 	// filter matching targets to only get eligible ones
@@ -880,6 +1020,36 @@ func (r *DisruptionReconciler) selectTargets(instance *chaosv1beta1.Disruption)
 		return fmt.Errorf("error getting eligible targets: %w", err)
 	}
 
+	// group current and eligible targets according to the targeting strategy if any
+	var desiredTargetsCountPerGroup map[string]int
+
+	if instance.Spec.Targeting != nil {
+		targetsGroups, err := r.getTargetsGroups(instance, matchingTargets)
+		if err != nil {
+			return fmt.Errorf("error getting targets groups: %w", err)
+		}
+
+		for target, group := range targetsGroups {
+			if injection, found := instance.Status.TargetInjections[target]; found {
+				injection.Group = group
+				instance.Status.TargetInjections[target] = injection
+			} else if injection, found := eligibleTargets[target]; found {
+				injection.Group = group
+				eligibleTargets[target] = injection
+			}
+		}
+
+		// the perOwner strategy applies the count to each owner
+		if instance.Spec.Targeting.Strategy == chaosv1beta1.TargetingStrategyPerOwner {
+			desiredTargetsCountPerGroup = getDesiredTargetsCountPerGroup(instance, targetsGroups)
+
+			targetsCount = 0
+			for _, count := range desiredTargetsCountPerGroup {
+				targetsCount += count
+			}
+		}
+	}
+
 	instance.Status.DesiredTargetsCount = targetsCount
 	// if the asked targets count is greater than the amount of found targets, we take all of them
 	targetsCount = int(math.Min(float64(targetsCount), float64(len(instance.Status.TargetInjections)+len(eligibleTargets))))
@@ -898,7 +1068,12 @@ func (r *DisruptionReconciler) selectTargets(instance *chaosv1beta1.Disruption)
 	cTargetsCount := len(instance.Status.TargetInjections)
 	dTargetsCount := targetsCount
 
-	if cTargetsCount < dTargetsCount {
+	previousTargets := instance.Status.TargetInjections.GetTargetNames()
+
+	if instance.Spec.Targeting != nil {
+		// pick or remove targets following the targeting strategy
+		instance.Status.ApplyTargetingStrategy(*instance.Spec.Targeting, dTargetsCount, desiredTargetsCountPerGroup, eligibleTargets)
+	} else if cTargetsCount < dTargetsCount {
 		// not enough targets: pick more targets from eligibleTargets
 		instance.Status.AddTargets(dTargetsCount-cTargetsCount, eligibleTargets)
 	} else if cTargetsCount > dTargetsCount {
@@ -906,6 +1081,17 @@ func (r *DisruptionReconciler) selectTargets(instance *chaosv1beta1.Disruption)
 		instance.Status.RemoveTargets(cTargetsCount - dTargetsCount)
 	}
 
+	// ensure new targets won't violate any pod disruption budget
+	if instance.Spec.ShouldRespectPodDisruptionBudgets() {
+		if err := r.enforcePodDisruptionBudgets(instance, previousTargets); err != nil {
+			return fmt.Errorf("error enforcing pod disruption budgets: %w", err)
+		}
+
+		if instance.Spec.Targeting != nil {
+			instance.Status.TargetGroups = instance.Status.TargetInjections.CountPerGroup()
+		}
+	}
+
 	r.log.Debugw("updating instance status with targets selected for injection", "selectedTargets", instance.Status.TargetInjections.GetTargetNames())
 
 	instance.Status.SelectedTargetsCount = len(instance.Status.TargetInjections)
@@ -995,11 +1181,17 @@ func (r *DisruptionReconciler) generatePod(instance *chaosv1beta1.Disruption, ta
 		return pod, false
 	}
 
+	// node failure injectors are the only ones allowed to cordon, drain and isolate nodes
+	serviceAccount := r.InjectorServiceAccount
+	if kind == chaostypes.DisruptionKindNodeFailure && r.InjectorNodeFailureServiceAccount != "" {
+		serviceAccount = r.InjectorNodeFailureServiceAccount
+	}
+
 	podSpec := corev1.PodSpec{
 		HostPID:                       true,                      // enable host pid
 		RestartPolicy:                 corev1.RestartPolicyNever, // do not restart the pod on fail or completion
 		NodeName:                      targetNodeName,            // specify node name to schedule the pod
-		ServiceAccountName:            r.InjectorServiceAccount,  // service account to use
+		ServiceAccountName:            serviceAccount,            // service account to use
 		TerminationGracePeriodSeconds: &terminationGracePeriod,
 		ActiveDeadlineSeconds:         &activeDeadlineSeconds,
 		Containers: []corev1.Container{
@@ -1159,6 +1351,28 @@ func (r *DisruptionReconciler) generatePod(instance *chaosv1beta1.Disruption, ta
 		},
 	}
 
+	// expose the injector control API when enabled, protected by the token of the control token secret
+	// it is only called by chaosli to debug chaos pods, the controller does not rely on it
+	if r.InjectorControlPort > 0 {
+		podSpec.Containers[0].Args = append(podSpec.Containers[0].Args, "--control-port", strconv.Itoa(r.InjectorControlPort))
+		podSpec.Containers[0].Env = append(podSpec.Containers[0].Env, corev1.EnvVar{
+			Name: env.InjectorControlToken,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: r.InjectorControlTokenSecret},
+					Key:                  chaostypes.InjectorControlTokenSecretKey,
+				},
+			},
+		})
+		podSpec.Containers[0].Ports = []corev1.ContainerPort{
+			{
+				Name:          chaostypes.InjectorControlPortName,
+				ContainerPort: int32(r.InjectorControlPort),
+				Protocol:      corev1.ProtocolTCP,
+			},
+		}
+	}
+
 	if r.ImagePullSecrets != "" {
 		podSpec.ImagePullSecrets = []corev1.LocalObjectReference{
 			{
@@ -1172,6 +1386,11 @@ func (r *DisruptionReconciler) generatePod(instance *chaosv1beta1.Disruption, ta
 		podLabels[k] = v
 	}
 
+	podAnnotations := make(map[string]string)
+	for k, v := range r.InjectorAnnotations {
+		podAnnotations[k] = v
+	}
+
 	podLabels[chaostypes.TargetLabel] = targetName                      // target name label
 	podLabels[chaostypes.DisruptionKindLabel] = string(kind)            // disruption kind label
 	podLabels[chaostypes.DisruptionNameLabel] = instance.Name           // disruption name label, used to determine ownership
@@ -1182,7 +1401,7 @@ func (r *DisruptionReconciler) generatePod(instance *chaosv1beta1.Disruption, ta
 		ObjectMeta: metav1.ObjectMeta{
 			GenerateName: fmt.Sprintf("chaos-%s-", instance.Name), // generate the pod name automatically with a prefix
 			Namespace:    r.ChaosNamespace,                        // chaos pods need to be in the same namespace as their service account to run
-			Annotations:  r.InjectorAnnotations,                   // add extra annotations passed to the controller
+			Annotations:  podAnnotations,                          // add extra annotations passed to the controller
 			Labels:       podLabels,                               // add default and extra podLabels passed to the controller
 		},
 		Spec: podSpec,
@@ -1246,14 +1465,32 @@ func (r *DisruptionReconciler) generateChaosPods(instance *chaosv1beta1.Disrupti
 			continue
 		}
 
+		notInjectedBefore := TimeToInject(instance.Spec.Triggers, instance.CreationTimestamp.Time)
+
 		pulseActiveDuration, pulseDormantDuration, pulseInitialDelay := time.Duration(0), time.Duration(0), time.Duration(0)
+		pulseMaxActiveDuration, pulseMaxDormantDuration := time.Duration(0), time.Duration(0)
+
+		var (
+			pulseSeed   *int64
+			pulseAnchor time.Time
+		)
+
 		if instance.Spec.Pulse != nil {
 			pulseInitialDelay = instance.Spec.Pulse.InitialDelay.Duration()
 			pulseActiveDuration = instance.Spec.Pulse.ActiveDuration.Duration()
 			pulseDormantDuration = instance.Spec.Pulse.DormantDuration.Duration()
-		}
+			pulseMaxActiveDuration = instance.Spec.Pulse.MaxActiveDuration.Duration()
+			pulseMaxDormantDuration = instance.Spec.Pulse.MaxDormantDuration.Duration()
+			pulseSeed = instance.Spec.Pulse.Seed
 
-		notInjectedBefore := TimeToInject(instance.Spec.Triggers, instance.CreationTimestamp.Time)
+			// all the chaos pods pulse from the same anchor with the same seed so every target flips state at the same instant
+			pulseAnchor = notInjectedBefore.Add(pulseInitialDelay)
+
+			if pulseSeed == nil && instance.Spec.Pulse.IsRandom() {
+				seed := pulseSeedFromUID(instance.UID)
+				pulseSeed = &seed
+			}
+		}
 
 		allowedHosts := r.InjectorNetworkDisruptionAllowedHosts
 
@@ -1275,25 +1512,30 @@ func (r *DisruptionReconciler) generateChaosPods(instance *chaosv1beta1.Disrupti
 		}
 
 		xargs := chaosapi.DisruptionArgs{
-			Level:                instance.Spec.Level,
-			Kind:                 kind,
-			TargetContainers:     targetContainers,
-			TargetName:           targetName,
-			TargetNodeName:       targetNodeName,
-			TargetPodIP:          targetPodIP,
-			DryRun:               instance.Spec.DryRun,
-			DisruptionName:       instance.Name,
-			DisruptionNamespace:  instance.Namespace,
-			OnInit:               instance.Spec.OnInit,
-			PulseInitialDelay:    pulseInitialDelay,
-			PulseActiveDuration:  pulseActiveDuration,
-			PulseDormantDuration: pulseDormantDuration,
-			NotInjectedBefore:    notInjectedBefore,
-			MetricsSink:          r.MetricsSink.GetSinkName(),
-			AllowedHosts:         allowedHosts,
-			DNSServer:            r.InjectorDNSDisruptionDNSServer,
-			KubeDNS:              r.InjectorDNSDisruptionKubeDNS,
-			ChaosNamespace:       r.ChaosNamespace,
+			Level:                   instance.Spec.Level,
+			Kind:                    kind,
+			TargetContainers:        targetContainers,
+			TargetName:              targetName,
+			TargetNodeName:          targetNodeName,
+			TargetPodIP:             targetPodIP,
+			DryRun:                  instance.Spec.DryRun,
+			DisruptionName:          instance.Name,
+			DisruptionNamespace:     instance.Namespace,
+			OnInit:                  instance.Spec.OnInit,
+			AllowRootDiskFill:       instance.Spec.AllowsRootDiskFill(),
+			PulseInitialDelay:       pulseInitialDelay,
+			PulseActiveDuration:     pulseActiveDuration,
+			PulseDormantDuration:    pulseDormantDuration,
+			PulseMaxActiveDuration:  pulseMaxActiveDuration,
+			PulseMaxDormantDuration: pulseMaxDormantDuration,
+			PulseSeed:               pulseSeed,
+			PulseAnchor:             pulseAnchor,
+			NotInjectedBefore:       notInjectedBefore,
+			MetricsSink:             r.MetricsSink.GetSinkName(),
+			AllowedHosts:            allowedHosts,
+			DNSServer:               r.InjectorDNSDisruptionDNSServer,
+			KubeDNS:                 r.InjectorDNSDisruptionKubeDNS,
+			ChaosNamespace:          r.ChaosNamespace,
 		}
 
 		// generate args for pod
@@ -1302,6 +1544,16 @@ func (r *DisruptionReconciler) generateChaosPods(instance *chaosv1beta1.Disrupti
 		// append pod to chaos pods
 		pod, shouldCreatePod := r.generatePod(instance, targetName, targetNodeName, args, kind)
 		if shouldCreatePod {
+			// keep track of the injected tunable fields values so the chaos pod is replaced once they are updated
+			tunables, err := instance.Spec.Tunables(kind)
+			if err != nil {
+				return nil, err
+			}
+
+			if tunables != "" {
+				pod.Annotations[chaostypes.TunablesAnnotation] = tunables
+			}
+
 			pods = append(pods, pod)
 		}
 	}
@@ -0,0 +1,314 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	chaosv1beta1 "github.com/DataDog/chaos-controller/api/v1beta1"
+	"github.com/DataDog/chaos-controller/targetselector"
+	chaostypes "github.com/DataDog/chaos-controller/types"
+	"github.com/DataDog/chaos-controller/utils"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// maxMissedSchedules is the maximum number of missed schedules looked up to find the latest one
+// older missed schedules are ignored, only the latest one being run
+const maxMissedSchedules = 1000
+
+// DisruptionCronReconciler reconciles a DisruptionCron object
+type DisruptionCronReconciler struct {
+	Client         client.Client
+	BaseLog        *zap.SugaredLogger
+	Scheme         *runtime.Scheme
+	Recorder       record.EventRecorder
+	TargetSelector targetselector.TargetSelector
+	log            *zap.SugaredLogger
+}
+
+// +kubebuilder:rbac:groups=chaos.datadoghq.com,resources=disruptioncrons,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=chaos.datadoghq.com,resources=disruptioncrons/status,verbs=update;patch
+// +kubebuilder:rbac:groups=chaos.datadoghq.com,resources=disruptioncrons/finalizers,verbs=update
+func (r *DisruptionCronReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	r.log = r.BaseLog.With("disruptionCronName", req.Name, "disruptionCronNamespace", req.Namespace)
+
+	instance := &chaosv1beta1.DisruptionCron{}
+	if err := r.Client.Get(ctx, req.NamespacedName, instance); err != nil {
+		// created disruptions are garbage collected through their owner reference
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if !instance.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	active, finished, err := r.getChildDisruptions(ctx, instance)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("error listing disruptions created by the disruption cron: %w", err)
+	}
+
+	r.cleanHistory(ctx, instance, finished)
+
+	instance.Status.Active = []corev1.ObjectReference{}
+	for _, disruption := range active {
+		instance.Status.Active = append(instance.Status.Active, getDisruptionReference(disruption))
+	}
+
+	if instance.Spec.Suspend {
+		r.log.Debugw("disruption cron is suspended, skipping")
+
+		return ctrl.Result{}, r.Client.Status().Update(ctx, instance)
+	}
+
+	schedule, err := instance.Spec.ParseSchedule()
+	if err != nil {
+		// the schedule is validated by the admission webhook, there is no point in retrying
+		r.log.Errorw("invalid disruption cron schedule", "schedule", instance.Spec.Schedule, "error", err)
+
+		return ctrl.Result{}, nil
+	}
+
+	now := time.Now()
+	lastScheduleTime := instance.CreationTimestamp.Time
+
+	if instance.Status.LastScheduleTime != nil {
+		lastScheduleTime = instance.Status.LastScheduleTime.Time
+	}
+
+	// look for the latest missed schedule
+	scheduledTime := time.Time{}
+	for i, next := 0, schedule.Next(lastScheduleTime); i < maxMissedSchedules && !next.IsZero() && !next.After(now); i, next = i+1, schedule.Next(next) {
+		scheduledTime = next
+	}
+
+	if !scheduledTime.IsZero() {
+		if err := r.runSchedule(ctx, instance, active, scheduledTime); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if err := r.Client.Status().Update(ctx, instance); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error updating disruption cron status: %w", err)
+	}
+
+	next := schedule.Next(now)
+	if next.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	r.log.Debugw("requeuing disruption cron for its next schedule", "nextScheduleTime", next)
+
+	return ctrl.Result{RequeueAfter: time.Until(next)}, nil
+}
+
+// runSchedule creates the disruption of the given schedule time unless its concurrency policy
+// or its unhealthy targets prevent it, recording why it has been skipped in the instance status
+func (r *DisruptionCronReconciler) runSchedule(ctx context.Context, instance *chaosv1beta1.DisruptionCron, active []chaosv1beta1.Disruption, scheduledTime time.Time) error {
+	instance.Status.LastScheduleTime = &metav1.Time{Time: scheduledTime}
+	instance.Status.LastSkipReason = ""
+
+	if len(active) > 0 {
+		switch instance.Spec.GetConcurrencyPolicy() {
+		case chaosv1beta1.DisruptionCronConcurrencyPolicyForbid:
+			r.skipSchedule(instance, fmt.Sprintf("the previous disruption %s is still running", active[len(active)-1].Name))
+
+			return nil
+		case chaosv1beta1.DisruptionCronConcurrencyPolicyReplace:
+			for i := range active {
+				r.log.Infow("replacing running disruption", "disruptionName", active[i].Name)
+
+				if err := r.Client.Delete(ctx, &active[i]); client.IgnoreNotFound(err) != nil {
+					return fmt.Errorf("error deleting running disruption %s: %w", active[i].Name, err)
+				}
+			}
+
+			instance.Status.Active = []corev1.ObjectReference{}
+		case chaosv1beta1.DisruptionCronConcurrencyPolicyAllow:
+		}
+	}
+
+	disruption, err := r.getScheduledDisruption(instance, scheduledTime)
+	if err != nil {
+		return fmt.Errorf("error building scheduled disruption: %w", err)
+	}
+
+	if instance.Spec.SkipIfTargetUnhealthy {
+		unhealthyTargets, err := r.getUnhealthyTargets(disruption)
+		if err != nil {
+			return fmt.Errorf("error checking targets health: %w", err)
+		}
+
+		if len(unhealthyTargets) > 0 {
+			r.skipSchedule(instance, fmt.Sprintf("some targets are not healthy: %v", unhealthyTargets))
+
+			return nil
+		}
+	}
+
+	if err := r.Client.Create(ctx, disruption); err != nil {
+		if errors.IsAlreadyExists(err) {
+			return nil
+		}
+
+		// a disruption refused by the admission webhook is skipped rather than retried until the next schedule
+		r.skipSchedule(instance, fmt.Sprintf("error creating disruption: %s", err))
+
+		return nil
+	}
+
+	r.log.Infow("scheduled disruption created", "disruptionName", disruption.Name, "scheduledTime", scheduledTime)
+	r.Recorder.Event(instance, chaosv1beta1.Events[chaosv1beta1.EventDisruptionCronScheduled].Type, string(chaosv1beta1.EventDisruptionCronScheduled), fmt.Sprintf(chaosv1beta1.Events[chaosv1beta1.EventDisruptionCronScheduled].OnDisruptionTemplateMessage, disruption.Name))
+
+	instance.Status.Active = append(instance.Status.Active, getDisruptionReference(*disruption))
+
+	return nil
+}
+
+// skipSchedule records the reason why the current schedule has been skipped
+func (r *DisruptionCronReconciler) skipSchedule(instance *chaosv1beta1.DisruptionCron, reason string) {
+	r.log.Infow("scheduled disruption skipped", "reason", reason)
+	r.Recorder.Event(instance, chaosv1beta1.Events[chaosv1beta1.EventDisruptionCronSkipped].Type, string(chaosv1beta1.EventDisruptionCronSkipped), fmt.Sprintf(chaosv1beta1.Events[chaosv1beta1.EventDisruptionCronSkipped].OnDisruptionTemplateMessage, reason))
+
+	instance.Status.LastSkipReason = reason
+}
+
+// getScheduledDisruption builds the disruption to create for the given schedule time from the instance template
+// it inherits the instance labels and annotations (e.g. the safemode environment annotation)
+func (r *DisruptionCronReconciler) getScheduledDisruption(instance *chaosv1beta1.DisruptionCron, scheduledTime time.Time) (*chaosv1beta1.Disruption, error) {
+	disruption := &chaosv1beta1.Disruption{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        getScheduledDisruptionName(instance.Name, scheduledTime),
+			Namespace:   instance.Namespace,
+			Labels:      map[string]string{},
+			Annotations: map[string]string{},
+		},
+		Spec: *instance.Spec.DisruptionTemplate.DeepCopy(),
+	}
+
+	for key, value := range instance.Labels {
+		disruption.Labels[key] = value
+	}
+
+	for key, value := range instance.Annotations {
+		if key != corev1.LastAppliedConfigAnnotation {
+			disruption.Annotations[key] = value
+		}
+	}
+
+	disruption.Labels[chaostypes.DisruptionCronNameLabel] = instance.Name
+
+	if err := controllerutil.SetControllerReference(instance, disruption, r.Scheme); err != nil {
+		return nil, err
+	}
+
+	return disruption, nil
+}
+
+// getChildDisruptions returns the running and the finished disruptions created by the given instance, sorted by creation time
+func (r *DisruptionCronReconciler) getChildDisruptions(ctx context.Context, instance *chaosv1beta1.DisruptionCron) (active []chaosv1beta1.Disruption, finished []chaosv1beta1.Disruption, err error) {
+	disruptions := chaosv1beta1.DisruptionList{}
+	if err := r.Client.List(ctx, &disruptions, client.InNamespace(instance.Namespace), client.MatchingLabels{chaostypes.DisruptionCronNameLabel: instance.Name}); err != nil {
+		return nil, nil, err
+	}
+
+	sort.Slice(disruptions.Items, func(i, j int) bool {
+		return disruptions.Items[i].CreationTimestamp.Before(&disruptions.Items[j].CreationTimestamp)
+	})
+
+	for _, disruption := range disruptions.Items {
+		if !disruption.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		if calculateRemainingDuration(disruption) <= 0 {
+			finished = append(finished, disruption)
+		} else {
+			active = append(active, disruption)
+		}
+	}
+
+	return active, finished, nil
+}
+
+// cleanHistory deletes the oldest finished disruptions exceeding the instance history limit
+func (r *DisruptionCronReconciler) cleanHistory(ctx context.Context, instance *chaosv1beta1.DisruptionCron, finished []chaosv1beta1.Disruption) {
+	for i := 0; i < len(finished)-instance.Spec.GetHistoryLimit(); i++ {
+		r.log.Debugw("deleting finished disruption exceeding the history limit", "disruptionName", finished[i].Name)
+
+		if err := r.Client.Delete(ctx, &finished[i]); client.IgnoreNotFound(err) != nil {
+			r.log.Errorw("error deleting finished disruption", "disruptionName", finished[i].Name, "error", err)
+		}
+	}
+}
+
+// getUnhealthyTargets returns the targets matching the given disruption selector which are not ready
+func (r *DisruptionCronReconciler) getUnhealthyTargets(disruption *chaosv1beta1.Disruption) ([]string, error) {
+	unhealthyTargets := []string{}
+
+	if disruption.Spec.Level == chaostypes.DisruptionLevelNode {
+		nodes, _, err := r.TargetSelector.GetMatchingNodesOverTotalNodes(r.Client, disruption)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range nodes.Items {
+			if !utils.IsNodeReady(&nodes.Items[i]) {
+				unhealthyTargets = append(unhealthyTargets, nodes.Items[i].Name)
+			}
+		}
+
+		return unhealthyTargets, nil
+	}
+
+	pods, _, err := r.TargetSelector.GetMatchingPodsOverTotalPods(r.Client, disruption)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range pods.Items {
+		if !utils.IsPodReady(&pods.Items[i]) {
+			unhealthyTargets = append(unhealthyTargets, pods.Items[i].Name)
+		}
+	}
+
+	return unhealthyTargets, nil
+}
+
+// getScheduledDisruptionName returns a deterministic disruption name for the given schedule time
+// so a schedule can never create more than one disruption
+func getScheduledDisruptionName(cronName string, scheduledTime time.Time) string {
+	return fmt.Sprintf("%s-%d", cronName, scheduledTime.Unix()/60)
+}
+
+// getDisruptionReference returns an object reference to the given disruption
+func getDisruptionReference(disruption chaosv1beta1.Disruption) corev1.ObjectReference {
+	return corev1.ObjectReference{
+		APIVersion: chaosv1beta1.GroupVersion.String(),
+		Kind:       chaosv1beta1.DisruptionKind,
+		Name:       disruption.Name,
+		Namespace:  disruption.Namespace,
+		UID:        disruption.UID,
+	}
+}
+
+// SetupWithManager setups the current reconciler with the given manager
+func (r *DisruptionCronReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&chaosv1beta1.DisruptionCron{}).
+		Owns(&chaosv1beta1.Disruption{}).
+		Complete(r)
+}
@@ -0,0 +1,257 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	chaosv1beta1 "github.com/DataDog/chaos-controller/api/v1beta1"
+	"github.com/DataDog/chaos-controller/targetselector"
+	chaostypes "github.com/DataDog/chaos-controller/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// createErrorClient fails every creation with the given error, like an admission webhook refusing the created objects
+type createErrorClient struct {
+	client.Client
+	err error
+}
+
+func (c createErrorClient) Create(_ context.Context, _ client.Object, _ ...client.CreateOption) error {
+	return c.err
+}
+
+var _ = Describe("Disruption cron reconciler", func() {
+	var (
+		cron           *chaosv1beta1.DisruptionCron
+		objects        []client.Object
+		k8sClient      client.Client
+		createErr      error
+		targetSelector *targetselector.TargetSelectorMock
+		recorder       *record.FakeRecorder
+		r              *DisruptionCronReconciler
+		result         ctrl.Result
+		reconcileErr   error
+	)
+
+	// the schedule fires at the beginning of each hour, the latest missed schedule being the current hour
+	latestSchedule := func() time.Time {
+		return time.Now().Truncate(time.Hour)
+	}
+
+	newChildDisruption := func(name string, age time.Duration, duration chaosv1beta1.DisruptionDuration) *chaosv1beta1.Disruption {
+		return &chaosv1beta1.Disruption{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         "default",
+				Name:              name,
+				CreationTimestamp: metav1.NewTime(time.Now().Add(-age)),
+				Labels:            map[string]string{chaostypes.DisruptionCronNameLabel: "cron"},
+			},
+			Spec: chaosv1beta1.DisruptionSpec{Duration: duration},
+		}
+	}
+
+	listDisruptions := func() []string {
+		disruptions := chaosv1beta1.DisruptionList{}
+		ExpectWithOffset(1, k8sClient.List(context.Background(), &disruptions)).To(Succeed())
+
+		names := []string{}
+		for _, disruption := range disruptions.Items {
+			names = append(names, disruption.Name)
+		}
+
+		return names
+	}
+
+	getCron := func() *chaosv1beta1.DisruptionCron {
+		instance := &chaosv1beta1.DisruptionCron{}
+		ExpectWithOffset(1, k8sClient.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "cron"}, instance)).To(Succeed())
+
+		return instance
+	}
+
+	BeforeEach(func() {
+		cron = &chaosv1beta1.DisruptionCron{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         "default",
+				Name:              "cron",
+				CreationTimestamp: metav1.NewTime(time.Now().Add(-3*time.Hour - 10*time.Minute)),
+			},
+			Spec: chaosv1beta1.DisruptionCronSpec{
+				Schedule: "0 * * * *",
+				TimeZone: "UTC",
+				DisruptionTemplate: chaosv1beta1.DisruptionSpec{
+					Selector:         map[string]string{"app": "foo"},
+					Duration:         "30m",
+					ContainerFailure: &chaosv1beta1.ContainerFailureSpec{},
+				},
+			},
+		}
+		objects = []client.Object{}
+		targetSelector = targetselector.NewTargetSelectorMock(GinkgoT())
+		createErr = nil
+	})
+
+	JustBeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(chaosv1beta1.AddToScheme(scheme)).To(Succeed())
+
+		k8sClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(objects, cron)...).Build()
+
+		recorder = record.NewFakeRecorder(10)
+		r = &DisruptionCronReconciler{
+			Client:         k8sClient,
+			BaseLog:        zap.NewNop().Sugar(),
+			Scheme:         scheme,
+			Recorder:       recorder,
+			TargetSelector: targetSelector,
+		}
+
+		if createErr != nil {
+			r.Client = createErrorClient{Client: k8sClient, err: createErr}
+		}
+
+		result, reconcileErr = r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "cron"}})
+	})
+
+	Context("with missed schedules", func() {
+		It("should only create the disruption of the latest missed schedule", func() {
+			Expect(reconcileErr).ToNot(HaveOccurred())
+			Expect(listDisruptions()).To(ConsistOf(getScheduledDisruptionName("cron", latestSchedule())))
+
+			instance := getCron()
+			Expect(instance.Status.LastScheduleTime.Time).To(BeTemporally("==", latestSchedule()))
+			Expect(instance.Status.LastSkipReason).To(BeEmpty())
+			Expect(instance.Status.Active).To(HaveLen(1))
+			Expect(recorder.Events).To(Receive(ContainSubstring(string(chaosv1beta1.EventDisruptionCronScheduled))))
+		})
+
+		It("should requeue for the next schedule", func() {
+			Expect(result.RequeueAfter).To(BeNumerically("~", time.Until(latestSchedule().Add(time.Hour)), time.Second))
+		})
+	})
+
+	Context("with the latest schedule already run", func() {
+		BeforeEach(func() {
+			cron.Status.LastScheduleTime = &metav1.Time{Time: latestSchedule()}
+		})
+
+		It("should not create any disruption", func() {
+			Expect(reconcileErr).ToNot(HaveOccurred())
+			Expect(listDisruptions()).To(BeEmpty())
+		})
+	})
+
+	Context("with a running disruption", func() {
+		BeforeEach(func() {
+			objects = append(objects, newChildDisruption("running", 10*time.Minute, "1h"))
+		})
+
+		It("should skip the schedule with the Forbid concurrency policy", func() {
+			Expect(reconcileErr).ToNot(HaveOccurred())
+			Expect(listDisruptions()).To(ConsistOf("running"))
+
+			instance := getCron()
+			Expect(instance.Status.LastSkipReason).To(ContainSubstring("the previous disruption running is still running"))
+			Expect(instance.Status.LastScheduleTime.Time).To(BeTemporally("==", latestSchedule()))
+			Expect(recorder.Events).To(Receive(ContainSubstring(string(chaosv1beta1.EventDisruptionCronSkipped))))
+		})
+
+		Context("with the Replace concurrency policy", func() {
+			BeforeEach(func() {
+				cron.Spec.ConcurrencyPolicy = chaosv1beta1.DisruptionCronConcurrencyPolicyReplace
+			})
+
+			It("should delete the running disruption and create the new one", func() {
+				Expect(reconcileErr).ToNot(HaveOccurred())
+				Expect(listDisruptions()).To(ConsistOf(getScheduledDisruptionName("cron", latestSchedule())))
+				Expect(getCron().Status.Active).To(HaveLen(1))
+			})
+		})
+
+		Context("with the Allow concurrency policy", func() {
+			BeforeEach(func() {
+				cron.Spec.ConcurrencyPolicy = chaosv1beta1.DisruptionCronConcurrencyPolicyAllow
+			})
+
+			It("should create the new disruption next to the running one", func() {
+				Expect(reconcileErr).ToNot(HaveOccurred())
+				Expect(listDisruptions()).To(ConsistOf("running", getScheduledDisruptionName("cron", latestSchedule())))
+				Expect(getCron().Status.Active).To(HaveLen(2))
+			})
+		})
+	})
+
+	Context("with finished disruptions", func() {
+		BeforeEach(func() {
+			historyLimit := 1
+			cron.Spec.HistoryLimit = &historyLimit
+			cron.Status.LastScheduleTime = &metav1.Time{Time: latestSchedule()}
+			objects = append(objects,
+				newChildDisruption("oldest", 5*time.Hour, "10m"),
+				newChildDisruption("older", 4*time.Hour, "10m"),
+				newChildDisruption("newest", 3*time.Hour, "10m"),
+				newChildDisruption("running", 10*time.Minute, "1h"),
+			)
+		})
+
+		It("should only keep the newest finished disruptions within the history limit", func() {
+			Expect(reconcileErr).ToNot(HaveOccurred())
+			Expect(listDisruptions()).To(ConsistOf("newest", "running"))
+			Expect(getCron().Status.Active).To(ConsistOf(HaveField("Name", "running")))
+		})
+	})
+
+	Context("skipping unhealthy targets", func() {
+		BeforeEach(func() {
+			cron.Spec.SkipIfTargetUnhealthy = true
+			targetSelector.EXPECT().GetMatchingPodsOverTotalPods(mock.Anything, mock.Anything).Return(&corev1.PodList{
+				Items: []corev1.Pod{
+					{ObjectMeta: metav1.ObjectMeta{Name: "ready"}, Status: corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}}},
+					{ObjectMeta: metav1.ObjectMeta{Name: "not-ready"}, Status: corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionFalse}}}},
+				},
+			}, 2, nil).Once()
+		})
+
+		It("should skip the schedule when a target is not ready", func() {
+			Expect(reconcileErr).ToNot(HaveOccurred())
+			Expect(listDisruptions()).To(BeEmpty())
+			Expect(getCron().Status.LastSkipReason).To(ContainSubstring("some targets are not healthy: [not-ready]"))
+		})
+	})
+
+	Context("with a disruption refused by the admission webhook", func() {
+		BeforeEach(func() {
+			createErr = k8serrors.NewForbidden(schema.GroupResource{Group: chaosv1beta1.GroupVersion.Group, Resource: "disruptions"}, "cron", errors.New("denied by the webhook"))
+		})
+
+		It("should skip the schedule instead of retrying it", func() {
+			Expect(reconcileErr).ToNot(HaveOccurred())
+			Expect(listDisruptions()).To(BeEmpty())
+
+			instance := getCron()
+			Expect(instance.Status.LastSkipReason).To(ContainSubstring("denied by the webhook"))
+			Expect(instance.Status.LastScheduleTime.Time).To(BeTemporally("==", latestSchedule()))
+		})
+	})
+})
@@ -7,6 +7,7 @@ package controllers
 
 import (
 	"fmt"
+	"hash/fnv"
 	"math"
 	"regexp"
 	"strings"
@@ -19,6 +20,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
@@ -97,8 +99,15 @@ func disruptionTerminationStatus(instance chaosv1beta1.Disruption, chaosPods []c
 }
 
 func calculateRemainingDuration(instance chaosv1beta1.Disruption) time.Duration {
+	duration := instance.Spec.Duration.Duration()
+
+	// the time spent paused does not count in the disruption duration if configured so
+	if instance.Spec.ExcludePausedTime {
+		duration += instance.Status.GetPausedDuration()
+	}
+
 	return calculateDeadline(
-		instance.Spec.Duration.Duration(),
+		duration,
 		TimeToInject(instance.Spec.Triggers, instance.ObjectMeta.CreationTimestamp.Time),
 	)
 }
@@ -289,3 +298,12 @@ NB: you can specify "spec.allowDisruptedTargets: true" to allow a new disruption
 
 	return eligibleTargets, nil
 }
+
+// pulseSeedFromUID returns the seed of the random pulse durations of a disruption not specifying one,
+// derived from its UID so all its chaos pods share the same random durations
+func pulseSeedFromUID(uid types.UID) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(uid))
+
+	return int64(h.Sum64())
+}
@@ -150,6 +150,16 @@ var _ = Describe("Inject and CreatePods Trigger tests", func() {
 	})
 })
 
+var _ = Describe("pulseSeedFromUID", func() {
+	It("should return the same seed for the same disruption", func() {
+		Expect(pulseSeedFromUID("0b2f8c0e-5c1d-4a57-9c1f-0e4a9d6d2b1a")).To(Equal(pulseSeedFromUID("0b2f8c0e-5c1d-4a57-9c1f-0e4a9d6d2b1a")))
+	})
+
+	It("should return different seeds for different disruptions", func() {
+		Expect(pulseSeedFromUID("0b2f8c0e-5c1d-4a57-9c1f-0e4a9d6d2b1a")).ToNot(Equal(pulseSeedFromUID("7d3e6a1f-2b4c-4e8d-a1f0-3c5b7e9d1f2a")))
+	})
+})
+
 var _ = DescribeTable(
 	"disruptionTerminationStatus",
 	func(disruption *disruptionBuilder, pods podsBuilder, expectTerminationStatus terminationStatus) {
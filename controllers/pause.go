@@ -0,0 +1,73 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	chaosv1beta1 "github.com/DataDog/chaos-controller/api/v1beta1"
+	chaostypes "github.com/DataDog/chaos-controller/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// pauseDisruption deletes all the chaos pods of the given instance so injectors clean the disruption, and marks it as paused
+// the instance is requeued once its duration is over so it can expire while paused
+func (r *DisruptionReconciler) pauseDisruption(instance *chaosv1beta1.Disruption) (ctrl.Result, error) {
+	if instance.Status.PausedSince == nil {
+		r.log.Infow("pausing disruption, chaos pods will be cleaned until it is resumed")
+		r.recordEventOnDisruption(instance, chaosv1beta1.EventDisruptionPaused, "", "")
+
+		now := metav1.Now()
+		instance.Status.PausedSince = &now
+	}
+
+	chaosPods, err := r.getChaosPods(instance, nil)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("error getting chaos pods to clean: %w", err)
+	}
+
+	for _, chaosPod := range chaosPods {
+		r.deleteChaosPod(instance, chaosPod)
+	}
+
+	for targetName, injection := range instance.Status.TargetInjections {
+		injection.InjectionStatus = chaostypes.DisruptionTargetInjectionStatusNotInjected
+		instance.Status.TargetInjections[targetName] = injection
+	}
+
+	instance.Status.InjectionStatus = chaostypes.DisruptionInjectionStatusPaused
+	instance.Status.InjectedTargetsCount = 0
+
+	if err := r.Client.Status().Update(context.Background(), instance); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error updating paused disruption status: %w", err)
+	}
+
+	// the remaining duration does not decrease while paused if the paused time is excluded from it
+	if instance.Spec.ExcludePausedTime {
+		return ctrl.Result{}, nil
+	}
+
+	return ctrl.Result{
+		Requeue:      true,
+		RequeueAfter: calculateRemainingDuration(*instance) + time.Second,
+	}, nil
+}
+
+// resumeDisruption adds the ended pause to the total paused duration of the given instance
+// the disruption is then re-injected by the usual reconcile flow
+func (r *DisruptionReconciler) resumeDisruption(instance *chaosv1beta1.Disruption) {
+	pausedFor := time.Since(instance.Status.PausedSince.Time).Round(time.Second)
+
+	r.log.Infow("resuming disruption", "pausedFor", pausedFor)
+	r.recordEventOnDisruption(instance, chaosv1beta1.EventDisruptionResumed, pausedFor.String(), "")
+
+	instance.Status.PausedDuration = chaosv1beta1.DisruptionDuration((instance.Status.PausedDuration.Duration() + pausedFor).String())
+	instance.Status.PausedSince = nil
+	instance.Status.InjectionStatus = chaostypes.DisruptionInjectionStatusNotInjected
+}
@@ -0,0 +1,135 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	chaosv1beta1 "github.com/DataDog/chaos-controller/api/v1beta1"
+	chaostypes "github.com/DataDog/chaos-controller/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Pause and resume", func() {
+	var (
+		disruption *chaosv1beta1.Disruption
+		chaosPod   *corev1.Pod
+		recorder   *record.FakeRecorder
+		r          *DisruptionReconciler
+	)
+
+	BeforeEach(func() {
+		disruption = &chaosv1beta1.Disruption{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         "default",
+				Name:              "disruption",
+				CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Minute)),
+			},
+			Spec: chaosv1beta1.DisruptionSpec{
+				Duration: "1h",
+				Paused:   true,
+			},
+			Status: chaosv1beta1.DisruptionStatus{
+				InjectionStatus:      chaostypes.DisruptionInjectionStatusInjected,
+				InjectedTargetsCount: 1,
+				TargetInjections: chaosv1beta1.TargetInjections{
+					"target": {InjectorPodName: "chaos-pod", InjectionStatus: chaostypes.DisruptionTargetInjectionStatusInjected},
+				},
+			},
+		}
+		chaosPod = &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "chaos-engineering",
+				Name:      "chaos-pod",
+				Labels: map[string]string{
+					chaostypes.DisruptionNameLabel:      disruption.Name,
+					chaostypes.DisruptionNamespaceLabel: disruption.Namespace,
+					chaostypes.TargetLabel:              "target",
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(chaosv1beta1.AddToScheme(scheme)).To(Succeed())
+
+		recorder = record.NewFakeRecorder(10)
+		r = &DisruptionReconciler{
+			Client:         fake.NewClientBuilder().WithScheme(scheme).WithObjects(disruption, chaosPod).Build(),
+			Recorder:       recorder,
+			ChaosNamespace: "chaos-engineering",
+			log:            zap.NewNop().Sugar(),
+		}
+	})
+
+	Context("pausing a disruption", func() {
+		It("should delete its chaos pods and mark it as paused", func() {
+			result, err := r.pauseDisruption(disruption)
+			Expect(err).ToNot(HaveOccurred())
+
+			pods := corev1.PodList{}
+			Expect(r.Client.List(context.Background(), &pods)).To(Succeed())
+			Expect(pods.Items).To(BeEmpty())
+
+			Expect(disruption.Status.PausedSince).ToNot(BeNil())
+			Expect(disruption.Status.InjectionStatus).To(Equal(chaostypes.DisruptionInjectionStatusPaused))
+			Expect(disruption.Status.InjectedTargetsCount).To(BeZero())
+			Expect(disruption.Status.TargetInjections["target"].InjectionStatus).To(Equal(chaostypes.DisruptionTargetInjectionStatusNotInjected))
+			Expect(recorder.Events).To(HaveLen(1))
+
+			// requeued once the duration is over so the disruption can expire while paused
+			Expect(result.RequeueAfter).To(BeNumerically("~", 59*time.Minute+time.Second, time.Second))
+		})
+
+		It("should keep the initial pause time on the next reconcile loops", func() {
+			pausedSince := metav1.NewTime(time.Now().Add(-10 * time.Minute).Truncate(time.Second))
+			disruption.Status.PausedSince = &pausedSince
+
+			_, err := r.pauseDisruption(disruption)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(disruption.Status.PausedSince.Time).To(BeTemporally("==", pausedSince.Time))
+			Expect(recorder.Events).To(BeEmpty())
+		})
+
+		It("should not requeue when the paused time is excluded from the duration", func() {
+			disruption.Spec.ExcludePausedTime = true
+
+			result, err := r.pauseDisruption(disruption)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeZero())
+		})
+	})
+
+	Context("resuming a disruption", func() {
+		BeforeEach(func() {
+			pausedSince := metav1.NewTime(time.Now().Add(-10 * time.Minute))
+			disruption.Spec.Paused = false
+			disruption.Status.PausedSince = &pausedSince
+			disruption.Status.PausedDuration = "5m"
+			disruption.Status.InjectionStatus = chaostypes.DisruptionInjectionStatusPaused
+		})
+
+		It("should accumulate the paused duration and let the disruption be injected again", func() {
+			r.resumeDisruption(disruption)
+
+			Expect(disruption.Status.PausedSince).To(BeNil())
+			Expect(disruption.Status.PausedDuration.Duration()).To(Equal(15 * time.Minute))
+			Expect(disruption.Status.InjectionStatus).To(Equal(chaostypes.DisruptionInjectionStatusNotInjected))
+			Expect(recorder.Events).To(HaveLen(1))
+		})
+	})
+})
@@ -0,0 +1,200 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	chaosv1beta1 "github.com/DataDog/chaos-controller/api/v1beta1"
+	chaostypes "github.com/DataDog/chaos-controller/types"
+	"github.com/DataDog/chaos-controller/utils"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// enforcePodDisruptionBudgets removes the newly selected targets (the ones not part of previousTargets) which would
+// violate a pod disruption budget once disrupted, either by shrinking the new targets list or refusing all of them
+// depending on the disruption configuration, and records an event explaining why when the refused targets change
+func (r *DisruptionReconciler) enforcePodDisruptionBudgets(instance *chaosv1beta1.Disruption, previousTargets []string) error {
+	newTargets := []string{}
+
+	for _, target := range instance.Status.TargetInjections.GetTargetNames() {
+		if !utils.Contains(previousTargets, target) {
+			newTargets = append(newTargets, target)
+		}
+	}
+
+	if len(newTargets) == 0 {
+		r.podDisruptionBudgetRefusals.Delete(instance.UID)
+
+		return nil
+	}
+
+	sort.Strings(newTargets)
+
+	// pod disruption budgets are only listed in the disruption namespace at the pod level
+	// while a node target can affect pods living in any namespace
+	listOptions := []client.ListOption{}
+	if instance.Spec.Level == chaostypes.DisruptionLevelPod {
+		listOptions = append(listOptions, client.InNamespace(instance.Namespace))
+	}
+
+	pdbs := policyv1.PodDisruptionBudgetList{}
+	if err := r.Client.List(context.Background(), &pdbs, listOptions...); err != nil {
+		return fmt.Errorf("error listing pod disruption budgets: %w", err)
+	}
+
+	if len(pdbs.Items) == 0 {
+		r.podDisruptionBudgetRefusals.Delete(instance.UID)
+
+		return nil
+	}
+
+	// remaining disruptions allowed per pod disruption budget
+	budgets := map[string]int32{}
+	for _, pdb := range pdbs.Items {
+		budgets[pdb.Namespace+"/"+pdb.Name] = pdb.Status.DisruptionsAllowed
+	}
+
+	affectedPods, err := r.getPodsAffectedByTargets(instance, newTargets)
+	if err != nil {
+		return fmt.Errorf("error getting pods affected by the new targets: %w", err)
+	}
+
+	violations := []string{}
+	refusedTargets := []string{}
+
+	for _, target := range newTargets {
+		pods := affectedPods[target]
+
+		// count the number of ready pods each pod disruption budget would lose
+		needs := map[string]int32{}
+
+		for i, pod := range pods {
+			if !utils.IsPodReady(&pods[i]) {
+				continue
+			}
+
+			for _, pdb := range pdbs.Items {
+				if podDisruptionBudgetMatchesPod(pdb, pod) {
+					needs[pdb.Namespace+"/"+pdb.Name]++
+				}
+			}
+		}
+
+		violatedPDBs := []string{}
+
+		for pdb, need := range needs {
+			if need > budgets[pdb] {
+				violatedPDBs = append(violatedPDBs, pdb)
+			}
+		}
+
+		if len(violatedPDBs) > 0 {
+			sort.Strings(violatedPDBs)
+			violations = append(violations, fmt.Sprintf("%s (%s)", target, strings.Join(violatedPDBs, ", ")))
+			refusedTargets = append(refusedTargets, target)
+
+			delete(instance.Status.TargetInjections, target)
+
+			continue
+		}
+
+		for pdb, need := range needs {
+			budgets[pdb] -= need
+		}
+	}
+
+	if len(violations) == 0 {
+		r.podDisruptionBudgetRefusals.Delete(instance.UID)
+
+		return nil
+	}
+
+	message := strings.Join(violations, ", ")
+
+	// refuse all new targets, keeping only the previously selected ones
+	if instance.Spec.PodDisruptionBudgetAction() == chaosv1beta1.PodDisruptionBudgetActionRefuse {
+		for _, target := range newTargets {
+			delete(instance.Status.TargetInjections, target)
+		}
+
+		refusedTargets = newTargets
+		message += "; all new targets have been refused"
+	}
+
+	// the refused targets are selected again on the next reconcile loops, the event is only recorded when they change
+	refused := strings.Join(refusedTargets, ",")
+	if previous, found := r.podDisruptionBudgetRefusals.Load(instance.UID); found && previous.(string) == refused {
+		return nil
+	}
+
+	r.podDisruptionBudgetRefusals.Store(instance.UID, refused)
+
+	r.log.Infow("some targets would violate a pod disruption budget and have been removed from the targets list", "violations", violations, "action", instance.Spec.PodDisruptionBudgetAction())
+	r.recordEventOnDisruption(instance, chaosv1beta1.EventDisruptionPodDisruptionBudget, message, "")
+
+	return nil
+}
+
+// getPodsAffectedByTargets returns the pods made unavailable by disrupting each of the given targets:
+// the target pod itself at the pod level, or all the pods running on the target node at the node level
+// pods are listed once for all the node targets
+func (r *DisruptionReconciler) getPodsAffectedByTargets(instance *chaosv1beta1.Disruption, targets []string) (map[string][]corev1.Pod, error) {
+	affectedPods := map[string][]corev1.Pod{}
+
+	if instance.Spec.Level == chaostypes.DisruptionLevelPod {
+		for _, target := range targets {
+			pod := corev1.Pod{}
+			if err := r.Client.Get(context.Background(), types.NamespacedName{Namespace: instance.Namespace, Name: target}, &pod); err != nil {
+				if k8serrors.IsNotFound(err) {
+					continue
+				}
+
+				return nil, fmt.Errorf("error getting target %s: %w", target, err)
+			}
+
+			affectedPods[target] = []corev1.Pod{pod}
+		}
+
+		return affectedPods, nil
+	}
+
+	pods := corev1.PodList{}
+	if err := r.Client.List(context.Background(), &pods); err != nil {
+		return nil, fmt.Errorf("error listing pods: %w", err)
+	}
+
+	for _, pod := range pods.Items {
+		if utils.Contains(targets, pod.Spec.NodeName) {
+			affectedPods[pod.Spec.NodeName] = append(affectedPods[pod.Spec.NodeName], pod)
+		}
+	}
+
+	return affectedPods, nil
+}
+
+// podDisruptionBudgetMatchesPod returns true if the given pod disruption budget covers the given pod
+func podDisruptionBudgetMatchesPod(pdb policyv1.PodDisruptionBudget, pod corev1.Pod) bool {
+	if pdb.Namespace != pod.Namespace || pdb.Spec.Selector == nil {
+		return false
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+	if err != nil || selector.Empty() {
+		return false
+	}
+
+	return selector.Matches(labels.Set(pod.Labels))
+}
@@ -0,0 +1,217 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package controllers
+
+import (
+	chaosv1beta1 "github.com/DataDog/chaos-controller/api/v1beta1"
+	chaostypes "github.com/DataDog/chaos-controller/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Pod disruption budgets enforcement", func() {
+	newPod := func(name, node string, ready bool, podLabels map[string]string) *corev1.Pod {
+		status := corev1.ConditionFalse
+		if ready {
+			status = corev1.ConditionTrue
+		}
+
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, Labels: podLabels},
+			Spec:       corev1.PodSpec{NodeName: node},
+			Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+			},
+		}
+	}
+
+	newPDB := func(name string, matchLabels map[string]string, disruptionsAllowed int32) *policyv1.PodDisruptionBudget {
+		return &policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Spec:       policyv1.PodDisruptionBudgetSpec{Selector: &metav1.LabelSelector{MatchLabels: matchLabels}},
+			Status:     policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: disruptionsAllowed},
+		}
+	}
+
+	newDisruption := func(level chaostypes.DisruptionLevel, action string, targets ...string) *chaosv1beta1.Disruption {
+		disruption := &chaosv1beta1.Disruption{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "disruption"},
+			Spec: chaosv1beta1.DisruptionSpec{
+				Level:            level,
+				ContainerFailure: &chaosv1beta1.ContainerFailureSpec{},
+			},
+			Status: chaosv1beta1.DisruptionStatus{TargetInjections: chaosv1beta1.TargetInjections{}},
+		}
+
+		if action != "" {
+			disruption.Spec.Unsafemode = &chaosv1beta1.UnsafemodeSpec{
+				Config: &chaosv1beta1.Config{PodDisruptionBudget: &chaosv1beta1.PodDisruptionBudgetConfig{Action: action}},
+			}
+		}
+
+		for _, target := range targets {
+			disruption.Status.TargetInjections[target] = chaosv1beta1.TargetInjection{}
+		}
+
+		return disruption
+	}
+
+	newReconciler := func(objects ...client.Object) (*DisruptionReconciler, *record.FakeRecorder) {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+
+		recorder := record.NewFakeRecorder(10)
+
+		return &DisruptionReconciler{
+			Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(),
+			Recorder: recorder,
+			log:      zap.NewNop().Sugar(),
+		}, recorder
+	}
+
+	Context("matching pods with a pod disruption budget", func() {
+		It("should match pods of the same namespace selected by the budget", func() {
+			pdb := newPDB("pdb", map[string]string{"app": "foo"}, 1)
+
+			Expect(podDisruptionBudgetMatchesPod(*pdb, *newPod("foo", "", true, map[string]string{"app": "foo", "tier": "web"}))).To(BeTrue())
+			Expect(podDisruptionBudgetMatchesPod(*pdb, *newPod("bar", "", true, map[string]string{"app": "bar"}))).To(BeFalse())
+		})
+
+		It("should not match pods of another namespace", func() {
+			pod := newPod("foo", "", true, map[string]string{"app": "foo"})
+			pod.Namespace = "other"
+
+			Expect(podDisruptionBudgetMatchesPod(*newPDB("pdb", map[string]string{"app": "foo"}, 1), *pod)).To(BeFalse())
+		})
+
+		It("should not match anything with an empty or missing selector", func() {
+			pod := newPod("foo", "", true, map[string]string{"app": "foo"})
+			pdb := newPDB("pdb", nil, 1)
+
+			Expect(podDisruptionBudgetMatchesPod(*pdb, *pod)).To(BeFalse())
+
+			pdb.Spec.Selector = nil
+			Expect(podDisruptionBudgetMatchesPod(*pdb, *pod)).To(BeFalse())
+		})
+	})
+
+	Context("enforcing the budgets on new targets", func() {
+		It("should keep targets within the remaining budget", func() {
+			disruption := newDisruption(chaostypes.DisruptionLevelPod, "", "foo-1", "foo-2")
+			r, recorder := newReconciler(
+				newPod("foo-1", "node-1", true, map[string]string{"app": "foo"}),
+				newPod("foo-2", "node-1", true, map[string]string{"app": "foo"}),
+				newPDB("pdb", map[string]string{"app": "foo"}, 2),
+			)
+
+			Expect(r.enforcePodDisruptionBudgets(disruption, nil)).To(Succeed())
+			Expect(disruption.Status.TargetInjections).To(HaveLen(2))
+			Expect(recorder.Events).To(BeEmpty())
+		})
+
+		It("should shrink the targets once the budget is exhausted", func() {
+			disruption := newDisruption(chaostypes.DisruptionLevelPod, "", "foo-1", "foo-2", "foo-3")
+			r, recorder := newReconciler(
+				newPod("foo-1", "node-1", true, map[string]string{"app": "foo"}),
+				newPod("foo-2", "node-1", true, map[string]string{"app": "foo"}),
+				newPod("foo-3", "node-1", true, map[string]string{"app": "foo"}),
+				newPDB("pdb", map[string]string{"app": "foo"}, 1),
+			)
+
+			Expect(r.enforcePodDisruptionBudgets(disruption, nil)).To(Succeed())
+			Expect(disruption.Status.TargetInjections.GetTargetNames()).To(ConsistOf("foo-1"))
+			Expect(recorder.Events).To(HaveLen(1))
+		})
+
+		It("should not count unready pods against the budget", func() {
+			disruption := newDisruption(chaostypes.DisruptionLevelPod, "", "foo-1", "foo-2")
+			r, _ := newReconciler(
+				newPod("foo-1", "node-1", false, map[string]string{"app": "foo"}),
+				newPod("foo-2", "node-1", true, map[string]string{"app": "foo"}),
+				newPDB("pdb", map[string]string{"app": "foo"}, 1),
+			)
+
+			Expect(r.enforcePodDisruptionBudgets(disruption, nil)).To(Succeed())
+			Expect(disruption.Status.TargetInjections).To(HaveLen(2))
+		})
+
+		It("should only consider newly selected targets", func() {
+			disruption := newDisruption(chaostypes.DisruptionLevelPod, "", "foo-1", "foo-2")
+			r, _ := newReconciler(
+				newPod("foo-1", "node-1", true, map[string]string{"app": "foo"}),
+				newPod("foo-2", "node-1", true, map[string]string{"app": "foo"}),
+				newPDB("pdb", map[string]string{"app": "foo"}, 0),
+			)
+
+			Expect(r.enforcePodDisruptionBudgets(disruption, []string{"foo-1"})).To(Succeed())
+			Expect(disruption.Status.TargetInjections.GetTargetNames()).To(ConsistOf("foo-1"))
+		})
+
+		It("should refuse all new targets when configured to", func() {
+			disruption := newDisruption(chaostypes.DisruptionLevelPod, chaosv1beta1.PodDisruptionBudgetActionRefuse, "foo-1", "foo-2", "bar-1")
+			r, _ := newReconciler(
+				newPod("foo-1", "node-1", true, map[string]string{"app": "foo"}),
+				newPod("foo-2", "node-1", true, map[string]string{"app": "foo"}),
+				newPod("bar-1", "node-1", true, map[string]string{"app": "bar"}),
+				newPDB("pdb", map[string]string{"app": "foo"}, 1),
+			)
+
+			Expect(r.enforcePodDisruptionBudgets(disruption, nil)).To(Succeed())
+			Expect(disruption.Status.TargetInjections).To(BeEmpty())
+		})
+
+		It("should only record the violation event when the refused targets change", func() {
+			newFooDisruption := func(targets ...string) *chaosv1beta1.Disruption {
+				disruption := newDisruption(chaostypes.DisruptionLevelPod, "", targets...)
+				disruption.UID = "disruption-uid"
+
+				return disruption
+			}
+
+			r, recorder := newReconciler(
+				newPod("foo-1", "node-1", true, map[string]string{"app": "foo"}),
+				newPod("foo-2", "node-1", true, map[string]string{"app": "foo"}),
+				newPod("foo-3", "node-1", true, map[string]string{"app": "foo"}),
+				newPDB("pdb", map[string]string{"app": "foo"}, 1),
+			)
+
+			By("recording the first refusal")
+			Expect(r.enforcePodDisruptionBudgets(newFooDisruption("foo-1", "foo-2"), nil)).To(Succeed())
+			Expect(recorder.Events).To(HaveLen(1))
+			<-recorder.Events
+
+			By("not recording the same refusal on the next reconcile loops")
+			Expect(r.enforcePodDisruptionBudgets(newFooDisruption("foo-1", "foo-2"), nil)).To(Succeed())
+			Expect(recorder.Events).To(BeEmpty())
+
+			By("recording the refusal again once the refused targets change")
+			Expect(r.enforcePodDisruptionBudgets(newFooDisruption("foo-1", "foo-2", "foo-3"), nil)).To(Succeed())
+			Expect(recorder.Events).To(HaveLen(1))
+		})
+
+		It("should count all the pods running on a node target", func() {
+			disruption := newDisruption(chaostypes.DisruptionLevelNode, "", "node-1", "node-2")
+			r, _ := newReconciler(
+				newPod("foo-1", "node-1", true, map[string]string{"app": "foo"}),
+				newPod("foo-2", "node-1", true, map[string]string{"app": "foo"}),
+				newPod("foo-3", "node-2", true, map[string]string{"app": "foo"}),
+				newPDB("pdb", map[string]string{"app": "foo"}, 1),
+			)
+
+			Expect(r.enforcePodDisruptionBudgets(disruption, nil)).To(Succeed())
+			Expect(disruption.Status.TargetInjections.GetTargetNames()).To(ConsistOf("node-2"))
+		})
+	})
+})
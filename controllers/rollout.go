@@ -0,0 +1,118 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	chaosv1beta1 "github.com/DataDog/chaos-controller/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// progressRollout moves the given instance to its next rollout stage once the dwell time of the current one is over,
+// the targets being widened by the usual dynamic targeting flow, unless a target warning has been observed during the stage,
+// in which case the rollout is halted and the current targets are kept
+// it returns the delay after which the rollout must be checked again
+func (r *DisruptionReconciler) progressRollout(instance *chaosv1beta1.Disruption) (time.Duration, error) {
+	if !instance.IsRollingOut() {
+		return 0, nil
+	}
+
+	stages := instance.Spec.Rollout.Stages
+
+	// the first stage starts with the injection
+	if instance.Status.RolloutStageSince == nil {
+		since := TimeToInject(instance.Spec.Triggers, instance.CreationTimestamp.Time)
+		if since.Before(time.Now()) {
+			since = time.Now()
+		}
+
+		instance.Status.RolloutStageSince = &metav1.Time{Time: since}
+		instance.Status.RolloutStagePausedDuration = chaosv1beta1.DisruptionDuration(instance.Status.GetPausedDuration().String())
+
+		r.log.Infow("starting disruption rollout", "stage", 1, "count", instance.GetCurrentCount().String())
+		r.recordEventOnDisruption(instance, chaosv1beta1.EventDisruptionRolloutStage, fmt.Sprintf("1/%d targeting %s targets", len(stages)+1, instance.GetCurrentCount().String()), "")
+
+		return stages[0].Dwell.Duration(), r.Client.Status().Update(context.Background(), instance)
+	}
+
+	if remaining := getRolloutStageRemainingDwell(instance); remaining > 0 {
+		return remaining, nil
+	}
+
+	// abort conditions are checked beforehand and abort the whole disruption when they hold
+	warning, err := r.getTargetWarningSince(instance, instance.Status.RolloutStageSince.Time)
+	if err != nil {
+		return 0, fmt.Errorf("error looking for target warnings: %w", err)
+	}
+
+	if warning != "" {
+		reason := fmt.Sprintf("a %s target warning has been observed during stage %d/%d", warning, instance.Status.RolloutStage+1, len(stages)+1)
+
+		r.log.Warnw("halting disruption rollout", "reason", reason)
+		r.recordEventOnDisruption(instance, chaosv1beta1.EventDisruptionRolloutHalted, reason, "")
+
+		instance.Status.RolloutHaltReason = reason
+
+		return 0, r.Client.Status().Update(context.Background(), instance)
+	}
+
+	instance.Status.RolloutStage++
+	instance.Status.RolloutStageSince = &metav1.Time{Time: time.Now()}
+	instance.Status.RolloutStagePausedDuration = chaosv1beta1.DisruptionDuration(instance.Status.GetPausedDuration().String())
+
+	r.log.Infow("widening disruption targets to the next rollout stage", "stage", instance.Status.RolloutStage+1, "count", instance.GetCurrentCount().String())
+	r.recordEventOnDisruption(instance, chaosv1beta1.EventDisruptionRolloutStage, fmt.Sprintf("%d/%d targeting %s targets", instance.Status.RolloutStage+1, len(stages)+1, instance.GetCurrentCount().String()), "")
+
+	requeueAfter := time.Duration(0)
+	if instance.IsRollingOut() {
+		requeueAfter = stages[instance.Status.RolloutStage].Dwell.Duration()
+	}
+
+	return requeueAfter, r.Client.Status().Update(context.Background(), instance)
+}
+
+// getRolloutStageRemainingDwell returns the remaining dwell time of the current rollout stage of the given instance
+// the disruption is not injected while paused, so the time spent paused during the stage does not count in its dwell
+func getRolloutStageRemainingDwell(instance *chaosv1beta1.Disruption) time.Duration {
+	dwell := instance.Spec.Rollout.Stages[instance.Status.RolloutStage].Dwell.Duration()
+	pausedDuringStage := instance.Status.GetPausedDuration() - instance.Status.RolloutStagePausedDuration.Duration()
+
+	return time.Until(instance.Status.RolloutStageSince.Add(dwell + pausedDuringStage))
+}
+
+// getTargetWarningSince returns the reason of a target warning event recorded on the given instance since the given time, empty if none
+func (r *DisruptionReconciler) getTargetWarningSince(instance *chaosv1beta1.Disruption, since time.Time) (string, error) {
+	events := &corev1.EventList{}
+	fieldSelector := fields.Set{
+		"involvedObject.kind": chaosv1beta1.DisruptionKind,
+		"involvedObject.name": instance.Name,
+	}
+
+	if err := r.Reader.List(context.Background(), events, &client.ListOptions{
+		FieldSelector: fieldSelector.AsSelector(),
+		Namespace:     instance.Namespace,
+	}); err != nil {
+		return "", err
+	}
+
+	for _, event := range events.Items {
+		if event.Type != corev1.EventTypeWarning || event.LastTimestamp.Time.Before(since) {
+			continue
+		}
+
+		if disruptionEvent, found := chaosv1beta1.Events[chaosv1beta1.DisruptionEventReason(event.Reason)]; found && disruptionEvent.Category == chaosv1beta1.TargetEvent {
+			return event.Reason, nil
+		}
+	}
+
+	return "", nil
+}
@@ -0,0 +1,249 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	chaosv1beta1 "github.com/DataDog/chaos-controller/api/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// eventsReader filters the listed events with the field selector, the fake client only supporting selectors on a single indexed field
+type eventsReader struct {
+	client.Reader
+}
+
+func (r eventsReader) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	listOpts := &client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+
+	fieldSelector := listOpts.FieldSelector
+	listOpts.FieldSelector = nil
+
+	if err := r.Reader.List(ctx, list, listOpts); err != nil {
+		return err
+	}
+
+	events, ok := list.(*corev1.EventList)
+	if !ok || fieldSelector == nil {
+		return nil
+	}
+
+	items := []corev1.Event{}
+
+	for _, event := range events.Items {
+		if fieldSelector.Matches(fields.Set{"involvedObject.kind": event.InvolvedObject.Kind, "involvedObject.name": event.InvolvedObject.Name}) {
+			items = append(items, event)
+		}
+	}
+
+	events.Items = items
+
+	return nil
+}
+
+var _ = Describe("Disruption rollout", func() {
+	var (
+		disruption *chaosv1beta1.Disruption
+		events     []client.Object
+		recorder   *record.FakeRecorder
+		r          *DisruptionReconciler
+	)
+
+	newEvent := func(name, involvedObjectName string, reason chaosv1beta1.DisruptionEventReason, eventType string, age time.Duration) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Namespace: "default", Name: name},
+			InvolvedObject: corev1.ObjectReference{Kind: chaosv1beta1.DisruptionKind, Namespace: "default", Name: involvedObjectName},
+			Reason:         string(reason),
+			Type:           eventType,
+			LastTimestamp:  metav1.NewTime(time.Now().Add(-age)),
+		}
+	}
+
+	BeforeEach(func() {
+		count := intstr.FromInt(3)
+		one := intstr.FromInt(1)
+		two := intstr.FromInt(2)
+		disruption = &chaosv1beta1.Disruption{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         "default",
+				Name:              "disruption",
+				CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour)),
+			},
+			Spec: chaosv1beta1.DisruptionSpec{
+				Count:    &count,
+				Duration: "2h",
+				Rollout: &chaosv1beta1.DisruptionRollout{
+					Stages: []chaosv1beta1.DisruptionRolloutStage{
+						{Count: &one, Dwell: "10m"},
+						{Count: &two, Dwell: "20m"},
+					},
+				},
+			},
+		}
+		events = []client.Object{}
+	})
+
+	JustBeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(chaosv1beta1.AddToScheme(scheme)).To(Succeed())
+
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(events, disruption)...).Build()
+
+		recorder = record.NewFakeRecorder(10)
+		r = &DisruptionReconciler{
+			Client:   k8sClient,
+			Reader:   eventsReader{k8sClient},
+			Recorder: recorder,
+			log:      zap.NewNop().Sugar(),
+		}
+	})
+
+	Context("starting the rollout", func() {
+		It("should start the first stage with the injection", func() {
+			requeueAfter, err := r.progressRollout(disruption)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requeueAfter).To(Equal(10 * time.Minute))
+			Expect(disruption.Status.RolloutStage).To(Equal(0))
+			Expect(disruption.Status.RolloutStageSince.Time).To(BeTemporally("~", time.Now(), time.Second))
+			Expect(recorder.Events).To(Receive(ContainSubstring("1/3 targeting 1 targets")))
+		})
+	})
+
+	Context("with a stage in progress", func() {
+		BeforeEach(func() {
+			disruption.Status.RolloutStageSince = &metav1.Time{Time: time.Now().Add(-4 * time.Minute)}
+		})
+
+		It("should wait for the end of the stage dwell", func() {
+			requeueAfter, err := r.progressRollout(disruption)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requeueAfter).To(BeNumerically("~", 6*time.Minute, time.Second))
+			Expect(disruption.Status.RolloutStage).To(Equal(0))
+		})
+	})
+
+	Context("with a stage dwell over", func() {
+		BeforeEach(func() {
+			disruption.Status.RolloutStageSince = &metav1.Time{Time: time.Now().Add(-15 * time.Minute)}
+		})
+
+		It("should widen the targets to the next stage", func() {
+			requeueAfter, err := r.progressRollout(disruption)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requeueAfter).To(Equal(20 * time.Minute))
+			Expect(disruption.Status.RolloutStage).To(Equal(1))
+			Expect(disruption.Status.RolloutStageSince.Time).To(BeTemporally("~", time.Now(), time.Second))
+			Expect(disruption.GetCurrentCount().IntValue()).To(Equal(2))
+			Expect(recorder.Events).To(Receive(ContainSubstring("2/3 targeting 2 targets")))
+		})
+
+		Context("on the last stage", func() {
+			BeforeEach(func() {
+				disruption.Status.RolloutStage = 1
+				disruption.Status.RolloutStageSince = &metav1.Time{Time: time.Now().Add(-30 * time.Minute)}
+			})
+
+			It("should reach the disruption count and stop rolling out", func() {
+				requeueAfter, err := r.progressRollout(disruption)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(requeueAfter).To(BeZero())
+				Expect(disruption.IsRollingOut()).To(BeFalse())
+				Expect(disruption.GetCurrentCount().IntValue()).To(Equal(3))
+			})
+		})
+
+		Context("with a target warning observed during the stage", func() {
+			BeforeEach(func() {
+				events = append(events, newEvent("warning", "disruption", chaosv1beta1.EventTargetPodWarningState, corev1.EventTypeWarning, 5*time.Minute))
+			})
+
+			It("should halt the rollout and keep the current stage", func() {
+				requeueAfter, err := r.progressRollout(disruption)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(requeueAfter).To(BeZero())
+				Expect(disruption.Status.RolloutStage).To(Equal(0))
+				Expect(disruption.Status.RolloutHaltReason).To(ContainSubstring(string(chaosv1beta1.EventTargetPodWarningState)))
+				Expect(disruption.IsRollingOut()).To(BeFalse())
+				Expect(recorder.Events).To(Receive(ContainSubstring(string(chaosv1beta1.EventDisruptionRolloutHalted))))
+			})
+		})
+
+		Context("with a target warning observed before the stage", func() {
+			BeforeEach(func() {
+				events = append(events, newEvent("warning", "disruption", chaosv1beta1.EventTargetPodWarningState, corev1.EventTypeWarning, 30*time.Minute))
+			})
+
+			It("should widen the targets to the next stage", func() {
+				_, err := r.progressRollout(disruption)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(disruption.Status.RolloutStage).To(Equal(1))
+			})
+		})
+	})
+
+	Context("with a disruption paused during the stage", func() {
+		BeforeEach(func() {
+			disruption.Status.RolloutStageSince = &metav1.Time{Time: time.Now().Add(-15 * time.Minute)}
+			disruption.Status.PausedDuration = "8m"
+		})
+
+		It("should not count the paused time in the stage dwell", func() {
+			requeueAfter, err := r.progressRollout(disruption)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requeueAfter).To(BeNumerically("~", 3*time.Minute, time.Second))
+			Expect(disruption.Status.RolloutStage).To(Equal(0))
+		})
+
+		Context("with the pause happening before the stage", func() {
+			BeforeEach(func() {
+				disruption.Status.RolloutStagePausedDuration = "8m"
+			})
+
+			It("should widen the targets to the next stage", func() {
+				_, err := r.progressRollout(disruption)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(disruption.Status.RolloutStage).To(Equal(1))
+				Expect(disruption.Status.RolloutStagePausedDuration.Duration()).To(Equal(8 * time.Minute))
+			})
+		})
+	})
+
+	Describe("looking for target warnings", func() {
+		BeforeEach(func() {
+			events = append(events,
+				newEvent("normal", "disruption", chaosv1beta1.EventDisruptionRolloutStage, corev1.EventTypeNormal, time.Minute),
+				newEvent("disruption-warning", "disruption", chaosv1beta1.EventDisruptionRolloutHalted, corev1.EventTypeWarning, time.Minute),
+				newEvent("other-disruption", "other", chaosv1beta1.EventTargetPodWarningState, corev1.EventTypeWarning, time.Minute),
+			)
+		})
+
+		It("should ignore normal events, non target events and events of other disruptions", func() {
+			Expect(r.getTargetWarningSince(disruption, time.Now().Add(-time.Hour))).To(BeEmpty())
+		})
+
+		It("should return the reason of a target warning recorded since the given time", func() {
+			Expect(r.Client.Create(context.Background(), newEvent("warning", "disruption", chaosv1beta1.EventTargetPodWarningState, corev1.EventTypeWarning, time.Minute))).To(Succeed())
+
+			Expect(r.getTargetWarningSince(disruption, time.Now().Add(-time.Hour))).To(Equal(string(chaosv1beta1.EventTargetPodWarningState)))
+			Expect(r.getTargetWarningSince(disruption, time.Now())).To(BeEmpty())
+		})
+	})
+})
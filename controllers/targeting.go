@@ -0,0 +1,136 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	chaosv1beta1 "github.com/DataDog/chaos-controller/api/v1beta1"
+	chaostypes "github.com/DataDog/chaos-controller/types"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// getTargetsGroups returns the group each of the given targets belongs to according to the disruption targeting strategy
+// - for the spread and concentrate strategies, the group is the value of the topology key label of the target node
+// - for the perOwner strategy, the group is the top-level controller owning the target pod (e.g. Deployment/foo)
+func (r *DisruptionReconciler) getTargetsGroups(instance *chaosv1beta1.Disruption, targets []string) (map[string]string, error) {
+	groups := make(map[string]string, len(targets))
+	nodesGroups := map[string]string{}
+
+	for _, target := range targets {
+		var (
+			group string
+			err   error
+		)
+
+		switch {
+		case instance.Spec.Level == chaostypes.DisruptionLevelNode:
+			group, err = r.getNodeTopologyDomain(target, instance.Spec.Targeting.GetTopologyKey(), nodesGroups)
+		case instance.Spec.Targeting.Strategy == chaosv1beta1.TargetingStrategyPerOwner:
+			group, err = r.getPodOwner(instance.Namespace, target)
+		default:
+			pod := corev1.Pod{}
+
+			if err = r.Client.Get(context.Background(), types.NamespacedName{Namespace: instance.Namespace, Name: target}, &pod); err == nil {
+				group, err = r.getNodeTopologyDomain(pod.Spec.NodeName, instance.Spec.Targeting.GetTopologyKey(), nodesGroups)
+			}
+		}
+
+		if err != nil {
+			if !k8serrors.IsNotFound(err) {
+				return nil, fmt.Errorf("error getting the targeting group of target %s: %w", target, err)
+			}
+
+			group = chaosv1beta1.TargetGroupUnknown
+		}
+
+		groups[target] = group
+	}
+
+	return groups, nil
+}
+
+// getNodeTopologyDomain returns the value of the given topology key label of the given node, caching it in the given map
+func (r *DisruptionReconciler) getNodeTopologyDomain(nodeName string, topologyKey string, cache map[string]string) (string, error) {
+	if domain, found := cache[nodeName]; found {
+		return domain, nil
+	}
+
+	if nodeName == "" {
+		return chaosv1beta1.TargetGroupUnknown, nil
+	}
+
+	node := corev1.Node{}
+	if err := r.Client.Get(context.Background(), types.NamespacedName{Name: nodeName}, &node); err != nil {
+		return "", err
+	}
+
+	domain, found := node.Labels[topologyKey]
+	if !found || domain == "" {
+		domain = chaosv1beta1.TargetGroupUnknown
+	}
+
+	cache[nodeName] = domain
+
+	return domain, nil
+}
+
+// getPodOwner returns the top-level controller owning the given pod, formatted as <kind>/<name>
+// pods owned by a ReplicaSet are grouped by the Deployment owning the ReplicaSet if any
+func (r *DisruptionReconciler) getPodOwner(namespace, podName string) (string, error) {
+	pod := corev1.Pod{}
+	if err := r.Client.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: podName}, &pod); err != nil {
+		return "", err
+	}
+
+	owner := metav1.GetControllerOf(&pod)
+	if owner == nil {
+		return fmt.Sprintf("Pod/%s", pod.Name), nil
+	}
+
+	if owner.Kind == "ReplicaSet" {
+		rs := appsv1.ReplicaSet{}
+		if err := r.Client.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: owner.Name}, &rs); err != nil {
+			if !k8serrors.IsNotFound(err) {
+				return "", err
+			}
+		} else if rsOwner := metav1.GetControllerOf(&rs); rsOwner != nil {
+			owner = rsOwner
+		}
+	}
+
+	return fmt.Sprintf("%s/%s", owner.Kind, owner.Name), nil
+}
+
+// getDesiredTargetsCountPerGroup returns the desired targets count for each group, applying the disruption current count to each of them
+func getDesiredTargetsCountPerGroup(instance *chaosv1beta1.Disruption, groups map[string]string) map[string]int {
+	groupsSizes := map[string]int{}
+	for _, group := range groups {
+		groupsSizes[group]++
+	}
+
+	desiredCountPerGroup := make(map[string]int, len(groupsSizes))
+
+	for group, size := range groupsSizes {
+		count, err := getScaledValueFromIntOrPercent(instance.GetCurrentCount(), size, true)
+		if err != nil {
+			count = instance.GetCurrentCount().IntValue()
+		}
+
+		if count > size {
+			count = size
+		}
+
+		desiredCountPerGroup[group] = count
+	}
+
+	return desiredCountPerGroup
+}
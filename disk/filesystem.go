@@ -0,0 +1,111 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package disk
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// fillChunkSize is the size of the chunks written when the filesystem does not support fallocate
+const fillChunkSize = 1 << 20
+
+// Usage represents the space usage of a filesystem in bytes
+type Usage struct {
+	Total     uint64
+	Available uint64
+}
+
+// Filesystem gives information about the filesystem containing a given path
+// and allows to allocate space on it
+type Filesystem interface {
+	// Usage returns the space usage of the filesystem containing the given path
+	Usage(path string) (Usage, error)
+	// Device returns the identifier of the device containing the given path
+	Device(path string) (uint64, error)
+	// Allocate creates the given file with the given size, actually reserving the blocks on the filesystem
+	Allocate(path string, size uint64) error
+}
+
+type filesystem struct {
+	dryRun bool
+}
+
+// NewFilesystem creates a new filesystem
+func NewFilesystem(dryRun bool) Filesystem {
+	return filesystem{
+		dryRun: dryRun,
+	}
+}
+
+func (f filesystem) Usage(path string) (Usage, error) {
+	stat := unix.Statfs_t{}
+
+	if err := unix.Statfs(path, &stat); err != nil {
+		return Usage{}, fmt.Errorf("error getting filesystem statistics of %s: %w", path, err)
+	}
+
+	return Usage{
+		Total:     stat.Blocks * uint64(stat.Bsize),
+		Available: stat.Bavail * uint64(stat.Bsize),
+	}, nil
+}
+
+func (f filesystem) Device(path string) (uint64, error) {
+	stat := unix.Stat_t{}
+
+	if err := unix.Stat(path, &stat); err != nil {
+		return 0, fmt.Errorf("error getting file statistics of %s: %w", path, err)
+	}
+
+	return uint64(stat.Dev), nil
+}
+
+func (f filesystem) Allocate(path string, size uint64) error {
+	// early exit if dry-run mode is enabled
+	if f.dryRun {
+		return nil
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	// fallocate reserves the blocks without writing them, which is way faster than writing the file
+	err = unix.Fallocate(int(file.Fd()), 0, 0, int64(size))
+	if err == nil {
+		return file.Sync()
+	}
+
+	if !errors.Is(err, unix.EOPNOTSUPP) {
+		return fmt.Errorf("error allocating %d bytes to %s: %w", size, path, err)
+	}
+
+	// fallback to writing zeros when fallocate is not supported by the filesystem
+	chunk := make([]byte, fillChunkSize)
+
+	for written := uint64(0); written < size; {
+		toWrite := size - written
+		if toWrite > fillChunkSize {
+			toWrite = fillChunkSize
+		}
+
+		n, err := file.Write(chunk[:toWrite])
+		if err != nil {
+			return fmt.Errorf("error writing %d bytes to %s: %w", size, path, err)
+		}
+
+		written += uint64(n)
+	}
+
+	return file.Sync()
+}
@@ -6,9 +6,32 @@
 // +build ignore
 #include "injection.bpf.h"
 
-const volatile pid_t target_pid = 0;
+// The target container is identified by its cgroup ID (cgroups v2) or by its mount namespace (cgroups v1)
+// so every process of the container is disrupted, whatever its ancestry. Both are 0 at the node level.
+const volatile u64 target_cgroup_id = 0;
+const volatile u32 target_mnt_ns = 0;
 const volatile pid_t exclude_pid;
-const volatile char filter_path[61];
+// Number of patterns stored in the patterns map by the loader.
+const volatile u32 patterns_count = 0;
+
+// Must be a power of 2 to bound the accesses to the path buffer.
+#define MAX_PATH_LEN 256
+#define MAX_PATTERNS 16
+
+#define MATCH_PREFIX 0
+#define MATCH_EXACT 1
+#define MATCH_SUFFIX 2
+
+struct pattern_t {
+    u32 len;
+    u32 match;
+    char value[MAX_PATH_LEN];
+};
+
+struct path_t {
+    u32 len;
+    char value[MAX_PATH_LEN];
+};
 
 struct data_t {
     u32 ppid;
@@ -25,6 +48,52 @@ struct {
     __type(value, u32);
 } events SEC(".maps");
 
+// Patterns of the paths to disrupt, filled by the loader.
+struct {
+    __uint(type, BPF_MAP_TYPE_ARRAY);
+    __uint(max_entries, MAX_PATTERNS);
+    __type(key, u32);
+    __type(value, struct pattern_t);
+} patterns SEC(".maps");
+
+// Buffer holding the opened path, too large to live on the stack.
+struct {
+    __uint(type, BPF_MAP_TYPE_PERCPU_ARRAY);
+    __uint(max_entries, 1);
+    __type(key, u32);
+    __type(value, struct path_t);
+} path_buffer SEC(".maps");
+
+static __always_inline bool match_pattern(struct path_t *path, struct pattern_t *pattern)
+{
+    u32 len = pattern->len;
+    u32 offset = 0;
+
+    if (len == 0 || len > path->len || len >= MAX_PATH_LEN) {
+        return false;
+    }
+
+    if (pattern->match == MATCH_EXACT && len != path->len) {
+        return false;
+    }
+
+    if (pattern->match == MATCH_SUFFIX) {
+        offset = path->len - len;
+    }
+
+    for (u32 i = 0; i < MAX_PATH_LEN; ++i) {
+        if (i >= len) {
+            break;
+        }
+
+        if (path->value[(offset + i) & (MAX_PATH_LEN - 1)] != pattern->value[i]) {
+            return false;
+        }
+    }
+
+    return true;
+}
+
 SEC("kprobe/sys_openat")
 int injection_disk_failure(struct pt_regs *ctx)
 {
@@ -39,18 +108,22 @@ int injection_disk_failure(struct pt_regs *ctx)
     u32 tid = bpf_get_current_pid_tgid() >> 32;
     u32 gid = bpf_get_current_uid_gid();
 
+    struct task_struct *task = (struct task_struct *)bpf_get_current_task();
+
+    // Allow only the processes of the target container.
+    if (target_cgroup_id != 0 && bpf_get_current_cgroup_id() != target_cgroup_id) {
+        return 0;
+    }
+
+    if (target_mnt_ns != 0 && BPF_CORE_READ(task, nsproxy, mnt_ns, ns.inum) != target_mnt_ns) {
+        return 0;
+    }
+
     if (pid != 1) {
         // Get parent pid
-        struct task_struct *task;
         struct task_struct *real_parent;
-        task = (struct task_struct *)bpf_get_current_task();
         bpf_probe_read(&real_parent, sizeof(real_parent), &task->real_parent);
         bpf_probe_read(&ppid, sizeof(ppid), &real_parent->tgid);
-
-        // Allow only children and parent process.
-        if (target_pid != 0 && ppid != target_pid && pid != target_pid) {
-          return 0;
-        }
     }
 
     if (ppid == exclude_pid || tid == exclude_pid) {
@@ -60,23 +133,43 @@ int injection_disk_failure(struct pt_regs *ctx)
 // Exclude this part of code if the following variables are not defined.
 // It allows the go program to compile without error.
 #if defined(__TARGET_ARCH_arm64) || defined(__TARGET_ARCH_x86)
-    // Allow only file with the desired prefix.
+    // Allow only files matching one of the patterns.
     struct pt_regs *real_regs = (struct pt_regs *)PT_REGS_PARM1(ctx);
-    char *path = (char *)PT_REGS_PARM2_CORE(real_regs);
-    char cmp_path_name[62];
-    bpf_probe_read(&cmp_path_name, sizeof(cmp_path_name), path);
-    char cmp_expected_path[62];
-    bpf_probe_read(cmp_expected_path, sizeof(cmp_expected_path), filter_path);
-    int filter_len = (int) (sizeof(filter_path) / sizeof(filter_path[0])) - 1;
-   
-    if (filter_len > 62) {
+    char *filename = (char *)PT_REGS_PARM2_CORE(real_regs);
+
+    u32 zero = 0;
+    struct path_t *path = bpf_map_lookup_elem(&path_buffer, &zero);
+    if (!path) {
         return 0;
     }
 
-    for (int i = 0; i < filter_len; ++i) {
-      if (cmp_expected_path[i] == NULL)
-        break;
-      if (cmp_path_name[i] != cmp_expected_path[i])
+    long read = bpf_probe_read_user_str(path->value, sizeof(path->value), filename);
+    if (read <= 0) {
+        return 0;
+    }
+
+    // Do not count the trailing null byte.
+    path->len = read - 1;
+
+    bool matched = false;
+
+    for (u32 i = 0; i < MAX_PATTERNS; ++i) {
+        if (i >= patterns_count) {
+            break;
+        }
+
+        struct pattern_t *pattern = bpf_map_lookup_elem(&patterns, &i);
+        if (!pattern) {
+            break;
+        }
+
+        if (match_pattern(path, pattern)) {
+            matched = true;
+            break;
+        }
+    }
+
+    if (!matched) {
         return 0;
     }
 #endif
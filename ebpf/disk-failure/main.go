@@ -13,17 +13,58 @@ import (
 	"bytes"
 	"encoding/binary"
 	"flag"
+	"fmt"
+	"github.com/DataDog/chaos-controller/cgroup"
 	"github.com/DataDog/chaos-controller/ebpf"
+	"github.com/DataDog/chaos-controller/env"
 	"github.com/DataDog/chaos-controller/log"
 	bpf "github.com/aquasecurity/libbpfgo"
 	"github.com/aquasecurity/libbpfgo/helpers"
 	"go.uber.org/zap"
+	"golang.org/x/sys/unix"
 	"os"
 	"os/signal"
+	"strings"
+	"unsafe"
 )
 
+// Those values must be kept in sync with the eBPF program
+const (
+	maxPathLen  = 256
+	maxPatterns = 16
+	matchPrefix = 0
+	matchExact  = 1
+	matchSuffix = 2
+)
+
+// pattern is the value of the patterns map of the eBPF program
+type pattern struct {
+	Len   uint32
+	Match uint32
+	Value [maxPathLen]byte
+}
+
+// patternsFlag is a repeatable flag holding path patterns
+type patternsFlag []string
+
+func (p *patternsFlag) String() string {
+	return strings.Join(*p, ",")
+}
+
+func (p *patternsFlag) Set(value string) error {
+	*p = append(*p, value)
+
+	return nil
+}
+
 var nFlag = flag.Uint64("p", 0, "Process to disrupt")
-var nPath = flag.String("f", "/", "Filter path")
+var prefixPaths, exactPaths, suffixPaths patternsFlag
+
+func init() {
+	flag.Var(&prefixPaths, "f", "Filter path prefix (can be repeated, defaults to /)")
+	flag.Var(&exactPaths, "e", "Filter exact path (can be repeated)")
+	flag.Var(&suffixPaths, "s", "Filter path suffix (can be repeated)")
+}
 
 var logger *zap.SugaredLogger
 
@@ -46,6 +87,8 @@ func main() {
 	err = bpfModule.BPFLoadObject()
 	must(err)
 
+	initPatterns(bpfModule)
+
 	// reads data from the trace pipe that bpf_trace_printk() writes to,
 	// (/sys/kernel/debug/tracing/trace_pipe).
 	go helpers.TracePipeListen()
@@ -91,15 +134,19 @@ func printEvent(data []byte) {
 func initGlobalVariables(bpfModule *bpf.Module) {
 	flag.Parse()
 
-	// Set the PID
-	var pid uint32
-	pid = uint32(*nFlag)
-	if err := bpfModule.InitGlobalVariable("target_pid", pid); err != nil {
+	// Set the target container scope from the PID
+	cgroupID, mntNS, err := getTargetScope(uint32(*nFlag))
+	must(err)
+
+	if err := bpfModule.InitGlobalVariable("target_cgroup_id", cgroupID); err != nil {
+		must(err)
+	}
+
+	if err := bpfModule.InitGlobalVariable("target_mnt_ns", mntNS); err != nil {
 		must(err)
 	}
 
-	path := []byte(*nPath)
-	if err := bpfModule.InitGlobalVariable("filter_path", path); err != nil {
+	if err := bpfModule.InitGlobalVariable("patterns_count", uint32(len(getPatterns()))); err != nil {
 		must(err)
 	}
 
@@ -109,6 +156,93 @@ func initGlobalVariables(bpfModule *bpf.Module) {
 	}
 }
 
+// getPatterns returns the patterns given in flags, matching all the paths by default
+func getPatterns() []pattern {
+	patterns := []pattern{}
+
+	if len(prefixPaths) == 0 && len(exactPaths) == 0 && len(suffixPaths) == 0 {
+		prefixPaths = patternsFlag{"/"}
+	}
+
+	for match, paths := range [][]string{matchPrefix: prefixPaths, matchExact: exactPaths, matchSuffix: suffixPaths} {
+		for _, path := range paths {
+			if len(path) == 0 || len(path) >= maxPathLen {
+				must(fmt.Errorf("the path %s must contain between 1 and %d characters", path, maxPathLen-1))
+			}
+
+			p := pattern{
+				Len:   uint32(len(path)),
+				Match: uint32(match),
+			}
+			copy(p.Value[:], path)
+
+			patterns = append(patterns, p)
+		}
+	}
+
+	if len(patterns) > maxPatterns {
+		must(fmt.Errorf("the number of paths must not exceed %d", maxPatterns))
+	}
+
+	return patterns
+}
+
+// initPatterns stores the patterns in the map shared with the loaded BPF application
+func initPatterns(bpfModule *bpf.Module) {
+	patternsMap, err := bpfModule.GetMap("patterns")
+	must(err)
+
+	for i, p := range getPatterns() {
+		key := uint32(i)
+		value := p
+
+		must(patternsMap.Update(unsafe.Pointer(&key), unsafe.Pointer(&value)))
+
+		logger.Infow("disrupting the paths matching the pattern", "pattern", string(p.Value[:p.Len]), "match", p.Match)
+	}
+}
+
+// getTargetScope returns the cgroup ID (cgroups v2) or the mount namespace inode (cgroups v1) of the container running the given PID
+// so the BPF program covers all the container processes, and not only the PID and its direct children
+// both are 0 when no PID is given (node level disruption)
+func getTargetScope(pid uint32) (cgroupID uint64, mntNS uint32, err error) {
+	if pid == 0 {
+		return 0, 0, nil
+	}
+
+	// retrieve cgroup mount path set in env or fallback to the default value
+	cgroupMount, exists := os.LookupEnv(env.InjectorMountCgroup)
+	if !exists {
+		cgroupMount = "/proc/1/root/sys/fs/cgroup"
+	}
+
+	cgroupMgr, err := cgroup.NewManager(false, pid, cgroupMount, logger)
+	if err != nil {
+		return 0, 0, fmt.Errorf("error creating cgroup manager: %w", err)
+	}
+
+	if cgroupMgr.IsCgroupV2() {
+		cgroupID, err = cgroupMgr.ID()
+		if err != nil {
+			return 0, 0, err
+		}
+
+		logger.Infow("disrupting the target cgroup", "pid", pid, "cgroupID", cgroupID)
+
+		return cgroupID, 0, nil
+	}
+
+	// cgroup IDs returned by the BPF helper are not reliable with cgroups v1, fallback on the mount namespace
+	stat := unix.Stat_t{}
+	if err := unix.Stat(fmt.Sprintf("/proc/%d/ns/mnt", pid), &stat); err != nil {
+		return 0, 0, fmt.Errorf("error getting the mount namespace of pid %d: %w", pid, err)
+	}
+
+	logger.Infow("disrupting the target mount namespace", "pid", pid, "mntNS", stat.Ino)
+
+	return 0, uint32(stat.Ino), nil
+}
+
 func must(err error) {
 	if err != nil {
 		panic(err)
@@ -6,7 +6,10 @@
 // Copyright 2023 Datadog, Inc.
 package injector
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	v1beta1 "github.com/DataDog/chaos-controller/api/v1beta1"
+	mock "github.com/stretchr/testify/mock"
+)
 
 // BPFDiskFailureCommandMock is an autogenerated mock type for the BPFDiskFailureCommand type
 type BPFDiskFailureCommandMock struct {
@@ -21,13 +24,13 @@ func (_m *BPFDiskFailureCommandMock) EXPECT() *BPFDiskFailureCommandMock_Expecte
 	return &BPFDiskFailureCommandMock_Expecter{mock: &_m.Mock}
 }
 
-// Run provides a mock function with given fields: pid, path
-func (_m *BPFDiskFailureCommandMock) Run(pid int, path string) error {
-	ret := _m.Called(pid, path)
+// Run provides a mock function with given fields: pid, paths
+func (_m *BPFDiskFailureCommandMock) Run(pid int, paths []v1beta1.DiskFailurePathSpec) error {
+	ret := _m.Called(pid, paths)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int, string) error); ok {
-		r0 = rf(pid, path)
+	if rf, ok := ret.Get(0).(func(int, []v1beta1.DiskFailurePathSpec) error); ok {
+		r0 = rf(pid, paths)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -42,14 +45,14 @@ type BPFDiskFailureCommandMock_Run_Call struct {
 
 // Run is a helper method to define mock.On call
 //   - pid int
-//   - path string
-func (_e *BPFDiskFailureCommandMock_Expecter) Run(pid interface{}, path interface{}) *BPFDiskFailureCommandMock_Run_Call {
-	return &BPFDiskFailureCommandMock_Run_Call{Call: _e.mock.On("Run", pid, path)}
+//   - paths []v1beta1.DiskFailurePathSpec
+func (_e *BPFDiskFailureCommandMock_Expecter) Run(pid interface{}, paths interface{}) *BPFDiskFailureCommandMock_Run_Call {
+	return &BPFDiskFailureCommandMock_Run_Call{Call: _e.mock.On("Run", pid, paths)}
 }
 
-func (_c *BPFDiskFailureCommandMock_Run_Call) Run(run func(pid int, path string)) *BPFDiskFailureCommandMock_Run_Call {
+func (_c *BPFDiskFailureCommandMock_Run_Call) Run(run func(pid int, paths []v1beta1.DiskFailurePathSpec)) *BPFDiskFailureCommandMock_Run_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(int), args[1].(string))
+		run(args[0].(int), args[1].([]v1beta1.DiskFailurePathSpec))
 	})
 	return _c
 }
@@ -59,7 +62,7 @@ func (_c *BPFDiskFailureCommandMock_Run_Call) Return(_a0 error) *BPFDiskFailureC
 	return _c
 }
 
-func (_c *BPFDiskFailureCommandMock_Run_Call) RunAndReturn(run func(int, string) error) *BPFDiskFailureCommandMock_Run_Call {
+func (_c *BPFDiskFailureCommandMock_Run_Call) RunAndReturn(run func(int, []v1beta1.DiskFailurePathSpec) error) *BPFDiskFailureCommandMock_Run_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,108 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package injector
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/DataDog/chaos-controller/api/v1beta1"
+	"github.com/DataDog/chaos-controller/types"
+)
+
+const (
+	containerFreezeV1ControllerName = "freezer"
+	containerFreezeV1Filename       = "freezer.state"
+	containerFreezeV1Frozen         = "FROZEN"
+	containerFreezeV1Thawed         = "THAWED"
+	containerFreezeV2Filename       = "cgroup.freeze"
+	containerFreezeV2Frozen         = "1"
+	containerFreezeV2Thawed         = "0"
+)
+
+// containerFreezeInjector describes a container freeze injector
+type containerFreezeInjector struct {
+	spec   v1beta1.ContainerFreezeSpec
+	config ContainerFreezeInjectorConfig
+}
+
+// ContainerFreezeInjectorConfig contains needed drivers to
+// create a ContainerFreezeInjector
+type ContainerFreezeInjectorConfig struct {
+	Config
+}
+
+// NewContainerFreezeInjector creates a ContainerFreezeInjector object with the given config
+func NewContainerFreezeInjector(spec v1beta1.ContainerFreezeSpec, config ContainerFreezeInjectorConfig) Injector {
+	return &containerFreezeInjector{
+		spec:   spec,
+		config: config,
+	}
+}
+
+func (i *containerFreezeInjector) GetDisruptionKind() types.DisruptionKindName {
+	return types.DisruptionKindContainerFreeze
+}
+
+// Inject freezes all the processes of the container through the cgroup freezer
+func (i *containerFreezeInjector) Inject() error {
+	i.config.Log.Infow("freezing the container", "container", i.config.TargetName())
+
+	if err := i.writeState(true); err != nil {
+		return fmt.Errorf("error freezing the container: %w", err)
+	}
+
+	return nil
+}
+
+func (i *containerFreezeInjector) UpdateConfig(config Config) {
+	i.config.Config = config
+}
+
+// Clean unfreezes the container, even if it was frozen by a previous injector
+func (i *containerFreezeInjector) Clean() error {
+	i.config.Log.Infow("unfreezing the container", "container", i.config.TargetName())
+
+	// the cgroup is gone with the container, there is nothing left to unfreeze
+	if err := i.writeState(false); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("error unfreezing the container: %w", err)
+	}
+
+	return nil
+}
+
+// JournalChanges returns the freezer state file of the target and its thawed state
+func (i *containerFreezeInjector) JournalChanges() []JournalChange {
+	if i.config.Cgroup.IsCgroupV2() {
+		return []JournalChange{
+			{Type: JournalChangeCgroupFile, File: containerFreezeV2Filename, Value: containerFreezeV2Thawed},
+		}
+	}
+
+	return []JournalChange{
+		{Type: JournalChangeCgroupFile, Controller: containerFreezeV1ControllerName, File: containerFreezeV1Filename, Value: containerFreezeV1Thawed},
+	}
+}
+
+// writeState writes the given freezer state to the container cgroup
+func (i *containerFreezeInjector) writeState(frozen bool) error {
+	if i.config.Cgroup.IsCgroupV2() {
+		state := containerFreezeV2Thawed
+		if frozen {
+			state = containerFreezeV2Frozen
+		}
+
+		return i.config.Cgroup.Write("", containerFreezeV2Filename, state)
+	}
+
+	state := containerFreezeV1Thawed
+	if frozen {
+		state = containerFreezeV1Frozen
+	}
+
+	return i.config.Cgroup.Write(containerFreezeV1ControllerName, containerFreezeV1Filename, state)
+}
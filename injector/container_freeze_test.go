@@ -0,0 +1,104 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package injector_test
+
+import (
+	"fmt"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/DataDog/chaos-controller/api/v1beta1"
+	"github.com/DataDog/chaos-controller/cgroup"
+	. "github.com/DataDog/chaos-controller/injector"
+)
+
+var _ = Describe("Container freeze", func() {
+	var (
+		config        ContainerFreezeInjectorConfig
+		cgroupManager *cgroup.ManagerMock
+		inj           Injector
+	)
+
+	BeforeEach(func() {
+		// cgroup
+		cgroupManager = cgroup.NewManagerMock(GinkgoT())
+
+		// config
+		config = ContainerFreezeInjectorConfig{
+			Config: Config{
+				Log:         log,
+				MetricsSink: ms,
+				Cgroup:      cgroupManager,
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		inj = NewContainerFreezeInjector(v1beta1.ContainerFreezeSpec{}, config)
+	})
+
+	Context("with cgroups v2", func() {
+		BeforeEach(func() {
+			cgroupManager.EXPECT().IsCgroupV2().Return(true)
+		})
+
+		It("should freeze the container on inject and unfreeze it on clean", func() {
+			cgroupManager.EXPECT().Write("", "cgroup.freeze", "1").Return(nil).Once()
+			Expect(inj.Inject()).To(Succeed())
+
+			cgroupManager.EXPECT().Write("", "cgroup.freeze", "0").Return(nil).Once()
+			Expect(inj.Clean()).To(Succeed())
+		})
+
+		It("should freeze the container again on each pulse", func() {
+			cgroupManager.EXPECT().Write("", "cgroup.freeze", "1").Return(nil).Twice()
+			cgroupManager.EXPECT().Write("", "cgroup.freeze", "0").Return(nil).Twice()
+
+			for n := 0; n < 2; n++ {
+				Expect(inj.Inject()).To(Succeed())
+				Expect(inj.Clean()).To(Succeed())
+			}
+		})
+
+		It("should not fail to clean when the container cgroup is gone", func() {
+			cgroupManager.EXPECT().Write("", "cgroup.freeze", "0").Return(fmt.Errorf("open cgroup.freeze: %w", os.ErrNotExist)).Once()
+			Expect(inj.Clean()).To(Succeed())
+		})
+
+		It("should return an error when the container can't be unfrozen", func() {
+			cgroupManager.EXPECT().Write("", "cgroup.freeze", "0").Return(os.ErrPermission).Once()
+			Expect(inj.Clean()).ToNot(Succeed())
+		})
+
+		It("should journal the unfrozen state to restore", func() {
+			Expect(inj.(Journaled).JournalChanges()).To(Equal([]JournalChange{
+				{Type: JournalChangeCgroupFile, File: "cgroup.freeze", Value: "0"},
+			}))
+		})
+	})
+
+	Context("with cgroups v1", func() {
+		BeforeEach(func() {
+			cgroupManager.EXPECT().IsCgroupV2().Return(false)
+		})
+
+		It("should freeze the container on inject and thaw it on clean", func() {
+			cgroupManager.EXPECT().Write("freezer", "freezer.state", "FROZEN").Return(nil).Once()
+			Expect(inj.Inject()).To(Succeed())
+
+			cgroupManager.EXPECT().Write("freezer", "freezer.state", "THAWED").Return(nil).Once()
+			Expect(inj.Clean()).To(Succeed())
+		})
+
+		It("should journal the thawed state to restore", func() {
+			Expect(inj.(Journaled).JournalChanges()).To(Equal([]JournalChange{
+				{Type: JournalChangeCgroupFile, Controller: "freezer", File: "freezer.state", Value: "THAWED"},
+			}))
+		})
+	})
+})
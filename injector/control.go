@@ -0,0 +1,143 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package injector
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/DataDog/chaos-controller/api/v1beta1"
+	"github.com/DataDog/chaos-controller/types"
+)
+
+// paths of the injector control API
+const (
+	ControlStatePath    = "/state"
+	ControlCleanPath    = "/clean"
+	ControlReinjectPath = "/reinject"
+	ControlDeadlinePath = "/deadline"
+	ControlDeadlineKey  = "deadline"
+	ControlTokenHeader  = "X-Chaos-Injector-Control-Token"
+)
+
+// pulse phases of a pulsing disruption
+const (
+	PulsePhaseInjected = "injected"
+	PulsePhaseDormant  = "dormant"
+)
+
+// ControlState is the current state of an injector process, as exposed by the control API
+type ControlState struct {
+	Kind       types.DisruptionKindName `json:"kind"`
+	Level      types.DisruptionLevel    `json:"level"`
+	TargetName string                   `json:"targetName"`
+	DryRun     bool                     `json:"dryRun"`
+	Injected   bool                     `json:"injected"`
+	PulsePhase string                   `json:"pulsePhase,omitempty"`
+	Deadline   time.Time                `json:"deadline"`
+	Injectors  []ControlInjectorState   `json:"injectors"`
+	Result     v1beta1.InjectionResult  `json:"result"`
+}
+
+// ControlInjectorState is the state of one of the injectors of an injector process
+type ControlInjectorState struct {
+	Kind   types.DisruptionKindName `json:"kind"`
+	Target string                   `json:"target"`
+	Config map[string]string        `json:"config,omitempty"`
+}
+
+// Controllable is an injector process which can be inspected and operated through the control API
+type Controllable interface {
+	// State returns the current state of the injector process
+	State() ControlState
+	// Clean cleans the injected disruptions, leaving the injector process running
+	Clean() error
+	// Reinject cleans and injects the disruptions again
+	Reinject() error
+	// UpdateDeadline moves the time at which the disruption must be over by
+	UpdateDeadline(deadline time.Time) error
+}
+
+// NewControlHandler returns the HTTP handler of the control API operating the given injector process,
+// only serving the requests carrying the given token in their control token header
+func NewControlHandler(controllable Controllable, token string) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc(ControlStatePath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, fmt.Sprintf("method %s not allowed", r.Method), http.StatusMethodNotAllowed)
+
+			return
+		}
+
+		writeControlState(w, controllable.State())
+	})
+
+	mux.HandleFunc(ControlCleanPath, controlOperation(controllable, controllable.Clean))
+	mux.HandleFunc(ControlReinjectPath, controlOperation(controllable, controllable.Reinject))
+	mux.HandleFunc(ControlDeadlinePath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, fmt.Sprintf("method %s not allowed", r.Method), http.StatusMethodNotAllowed)
+
+			return
+		}
+
+		deadline, err := time.Parse(time.RFC3339, r.URL.Query().Get(ControlDeadlineKey))
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid %s parameter, expected a RFC3339 time: %s", ControlDeadlineKey, err), http.StatusBadRequest)
+
+			return
+		}
+
+		if err := controllable.UpdateDeadline(deadline); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		writeControlState(w, controllable.State())
+	})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token == "" || subtle.ConstantTimeCompare([]byte(r.Header.Get(ControlTokenHeader)), []byte(token)) != 1 {
+			http.Error(w, "missing or invalid control token", http.StatusUnauthorized)
+
+			return
+		}
+
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// controlOperation returns an HTTP handler func running the given operation and replying with the resulting state
+func controlOperation(controllable Controllable, operation func() error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, fmt.Sprintf("method %s not allowed", r.Method), http.StatusMethodNotAllowed)
+
+			return
+		}
+
+		if err := operation(); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		writeControlState(w, controllable.State())
+	}
+}
+
+func writeControlState(w http.ResponseWriter, state ControlState) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(state); err != nil {
+		http.Error(w, fmt.Sprintf("error encoding the injector state: %s", err), http.StatusInternalServerError)
+	}
+}
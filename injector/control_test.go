@@ -0,0 +1,227 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package injector_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/DataDog/chaos-controller/injector"
+	"github.com/DataDog/chaos-controller/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ = Describe("Control handler", func() {
+	var (
+		controllable *ControllableMock
+		state        ControlState
+		method       string
+		path         string
+		recorder     *httptest.ResponseRecorder
+		token        string
+	)
+
+	const controlToken = "s3cr3t"
+
+	BeforeEach(func() {
+		state = ControlState{
+			Kind:       types.DisruptionKindNetworkDisruption,
+			Level:      types.DisruptionLevelPod,
+			TargetName: "target",
+			Injected:   true,
+			Deadline:   time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+			Injectors: []ControlInjectorState{
+				{
+					Kind:   types.DisruptionKindNetworkDisruption,
+					Target: "ctn",
+					Config: map[string]string{"drop": "100"},
+				},
+			},
+		}
+
+		controllable = NewControllableMock(GinkgoT())
+		controllable.EXPECT().State().Return(state).Maybe()
+
+		method = http.MethodGet
+		path = ControlStatePath
+		recorder = httptest.NewRecorder()
+		token = controlToken
+	})
+
+	JustBeforeEach(func() {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set(ControlTokenHeader, token)
+
+		NewControlHandler(controllable, controlToken).ServeHTTP(recorder, req)
+	})
+
+	readState := func() ControlState {
+		s := ControlState{}
+		Expect(json.Unmarshal(recorder.Body.Bytes(), &s)).To(Succeed())
+
+		return s
+	}
+
+	Describe("state", func() {
+		It("should return the current state", func() {
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			Expect(readState()).To(Equal(state))
+		})
+
+		Context("with a non GET request", func() {
+			BeforeEach(func() {
+				method = http.MethodPost
+			})
+
+			It("should be refused", func() {
+				Expect(recorder.Code).To(Equal(http.StatusMethodNotAllowed))
+			})
+		})
+	})
+
+	Describe("authentication", func() {
+		BeforeEach(func() {
+			method = http.MethodPost
+			path = ControlCleanPath
+		})
+
+		Context("without a token", func() {
+			BeforeEach(func() {
+				token = ""
+			})
+
+			It("should be refused without cleaning", func() {
+				Expect(recorder.Code).To(Equal(http.StatusUnauthorized))
+				controllable.AssertNotCalled(GinkgoT(), "Clean")
+			})
+		})
+
+		Context("with an invalid token", func() {
+			BeforeEach(func() {
+				token = "guess"
+			})
+
+			It("should be refused without cleaning", func() {
+				Expect(recorder.Code).To(Equal(http.StatusUnauthorized))
+				controllable.AssertNotCalled(GinkgoT(), "Clean")
+			})
+		})
+
+		Context("with a handler configured without a token", func() {
+			BeforeEach(func() {
+				method = http.MethodGet
+				path = ControlStatePath
+			})
+
+			It("should refuse all the requests", func() {
+				rec := httptest.NewRecorder()
+				NewControlHandler(controllable, "").ServeHTTP(rec, httptest.NewRequest(http.MethodGet, ControlStatePath, nil))
+
+				Expect(rec.Code).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("clean", func() {
+		BeforeEach(func() {
+			method = http.MethodPost
+			path = ControlCleanPath
+		})
+
+		Context("when the cleanup succeeds", func() {
+			BeforeEach(func() {
+				controllable.EXPECT().Clean().Return(nil).Once()
+			})
+
+			It("should return the state after the cleanup", func() {
+				Expect(recorder.Code).To(Equal(http.StatusOK))
+				Expect(readState()).To(Equal(state))
+			})
+		})
+
+		Context("when the cleanup fails", func() {
+			BeforeEach(func() {
+				controllable.EXPECT().Clean().Return(fmt.Errorf("cleanup error")).Once()
+			})
+
+			It("should return the error", func() {
+				Expect(recorder.Code).To(Equal(http.StatusInternalServerError))
+				Expect(recorder.Body.String()).To(ContainSubstring("cleanup error"))
+			})
+		})
+
+		Context("with a GET request", func() {
+			BeforeEach(func() {
+				method = http.MethodGet
+			})
+
+			It("should be refused without cleaning", func() {
+				Expect(recorder.Code).To(Equal(http.StatusMethodNotAllowed))
+			})
+		})
+	})
+
+	Describe("reinject", func() {
+		BeforeEach(func() {
+			method = http.MethodPost
+			path = ControlReinjectPath
+			controllable.EXPECT().Reinject().Return(nil).Once()
+		})
+
+		It("should reinject and return the state", func() {
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			Expect(readState()).To(Equal(state))
+		})
+	})
+
+	Describe("deadline", func() {
+		var deadline time.Time
+
+		BeforeEach(func() {
+			deadline = time.Now().Add(2 * time.Hour).Truncate(time.Second)
+			method = http.MethodPost
+			path = fmt.Sprintf("%s?%s=%s", ControlDeadlinePath, ControlDeadlineKey, deadline.Format(time.RFC3339))
+		})
+
+		Context("with a valid deadline", func() {
+			BeforeEach(func() {
+				controllable.EXPECT().UpdateDeadline(mock.MatchedBy(func(d time.Time) bool {
+					return d.Equal(deadline)
+				})).Return(nil).Once()
+			})
+
+			It("should update the deadline", func() {
+				Expect(recorder.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		Context("with a deadline refused by the injector", func() {
+			BeforeEach(func() {
+				controllable.EXPECT().UpdateDeadline(mock.Anything).Return(fmt.Errorf("deadline too late")).Once()
+			})
+
+			It("should return the error", func() {
+				Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+				Expect(recorder.Body.String()).To(ContainSubstring("deadline too late"))
+			})
+		})
+
+		Context("with an invalid deadline", func() {
+			BeforeEach(func() {
+				path = fmt.Sprintf("%s?%s=tomorrow", ControlDeadlinePath, ControlDeadlineKey)
+			})
+
+			It("should be refused", func() {
+				Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+})
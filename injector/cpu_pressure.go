@@ -19,7 +19,7 @@ import (
 )
 
 type CPUStressArgsBuilder interface {
-	GenerateArgs(int) []string
+	GenerateArgs(CPUStressLoad) []string
 }
 
 type cpuPressureInjector struct {
@@ -32,14 +32,10 @@ type cpuPressureInjector struct {
 }
 
 // NewCPUPressureInjector creates a CPU pressure injector with the given config
-func NewCPUPressureInjector(config Config, count string, injectorCmdFactory InjectorCmdFactory, argsBuilder CPUStressArgsBuilder) Injector {
-	intstrCount := intstr.Parse(count)
-
+func NewCPUPressureInjector(config Config, spec v1beta1.CPUPressureSpec, injectorCmdFactory InjectorCmdFactory, argsBuilder CPUStressArgsBuilder) Injector {
 	return &cpuPressureInjector{
 		config,
-		&v1beta1.CPUPressureSpec{
-			Count: &intstrCount,
-		},
+		&spec,
 		injectorCmdFactory,
 		nil,
 		nil,
@@ -52,40 +48,31 @@ func (i *cpuPressureInjector) GetDisruptionKind() types.DisruptionKindName {
 }
 
 func (i *cpuPressureInjector) Inject() error {
-	i.config.Log.Infow("creating processes to stress target", "count", i.spec.Count)
+	i.config.Log.Infow("creating processes to stress target", "count", i.spec.Count, "target_utilization", i.spec.TargetUtilization, "profile", i.spec.Profile)
 
-	var (
-		percentage int
-		err        error
-	)
+	load := CPUStressLoad{
+		Profile: i.spec.Profile,
+	}
 
-	if i.spec.Count != nil && i.spec.Count.Type == intstr.Int { // if a number is provided, calculate a percentage against the amount of cpus assigned to current target
-		assignedCPUs, err := i.config.Cgroup.ReadCPUSet()
+	if i.spec.TargetUtilization != nil {
+		// the stress adapts itself to the load of the cores, no percentage to compute
+		load.TargetUtilization = *i.spec.TargetUtilization
+	} else {
+		percentage, err := i.percentage()
 		if err != nil {
-			return fmt.Errorf("unable to read CPUSet for current container: %w", err)
+			return err
 		}
 
-		percentage = int(math.Floor(float64(i.spec.Count.IntValue()) / float64(assignedCPUs.Size()) * 100))
-
-		i.config.Log.Infow("percentage calculated from number of cpu", "provided_value", i.spec.Count, "assigned_cpus", assignedCPUs, "percentage", percentage)
-	} else if percentage, err = intstr.GetScaledValueFromIntOrPercent(i.spec.Count, 100, true); err != nil { // if a percentage is provided, keep it as is
-		return fmt.Errorf("unable to calculate stress percentage for '%s': %w", i.spec.Count, err)
-	} else {
-		i.config.Log.Infow("percentage calculated from percentage", "provided_value", i.spec.Count, "percentage", percentage)
+		load.Percentage = percentage
 	}
 
-	// If a range is expected, it should be checked earlier than here, let's not fail in the injector that is far away from our users
-	if percentage < 0 {
-		percentage = 0
-	} else if 100 < percentage {
-		percentage = 100
-	}
+	var err error
 
 	if i.backgroundCmd, i.cancel, err = i.injectorCmdFactory.NewInjectorBackgroundCmd(
 		i.config.DisruptionDeadline,
 		i.config.Disruption,
 		i.config.TargetName(),
-		i.cpuStressArgsBuilder.GenerateArgs(percentage),
+		i.cpuStressArgsBuilder.GenerateArgs(load),
 	); err != nil {
 		return fmt.Errorf("unable to create new process definition for injector: %w", err)
 	}
@@ -98,11 +85,43 @@ func (i *cpuPressureInjector) Inject() error {
 
 	i.backgroundCmd.KeepAlive()
 
-	i.config.Log.Infow("all routines have been created successfully, now stressing in background", "percentage", percentage)
+	i.config.Log.Infow("all routines have been created successfully, now stressing in background", "percentage", load.Percentage, "target_utilization", load.TargetUtilization)
 
 	return nil
 }
 
+// percentage returns the percentage of stress to apply on every core from the count
+func (i *cpuPressureInjector) percentage() (int, error) {
+	var (
+		percentage int
+		err        error
+	)
+
+	if i.spec.Count != nil && i.spec.Count.Type == intstr.Int { // if a number is provided, calculate a percentage against the amount of cpus assigned to current target
+		assignedCPUs, err := i.config.Cgroup.ReadCPUSet()
+		if err != nil {
+			return 0, fmt.Errorf("unable to read CPUSet for current container: %w", err)
+		}
+
+		percentage = int(math.Floor(float64(i.spec.Count.IntValue()) / float64(assignedCPUs.Size()) * 100))
+
+		i.config.Log.Infow("percentage calculated from number of cpu", "provided_value", i.spec.Count, "assigned_cpus", assignedCPUs, "percentage", percentage)
+	} else if percentage, err = intstr.GetScaledValueFromIntOrPercent(i.spec.Count, 100, true); err != nil { // if a percentage is provided, keep it as is
+		return 0, fmt.Errorf("unable to calculate stress percentage for '%s': %w", i.spec.Count, err)
+	} else {
+		i.config.Log.Infow("percentage calculated from percentage", "provided_value", i.spec.Count, "percentage", percentage)
+	}
+
+	// If a range is expected, it should be checked earlier than here, let's not fail in the injector that is far away from our users
+	if percentage < 0 {
+		percentage = 0
+	} else if 100 < percentage {
+		percentage = 100
+	}
+
+	return percentage, nil
+}
+
 func (i *cpuPressureInjector) UpdateConfig(config Config) {
 	i.config = config
 }
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"strconv"
 
+	"github.com/DataDog/chaos-controller/api/v1beta1"
 	"github.com/DataDog/chaos-controller/cgroup"
 	"github.com/DataDog/chaos-controller/command"
 	"github.com/DataDog/chaos-controller/container"
@@ -16,10 +17,19 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/stretchr/testify/mock"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 var nothingToCancel = func() {}
 
+func countSpec(count string) v1beta1.CPUPressureSpec {
+	intstrCount := intstr.Parse(count)
+
+	return v1beta1.CPUPressureSpec{
+		Count: &intstrCount,
+	}
+}
+
 var _ = Describe("CPU pressure", func() {
 	var (
 		config     Config
@@ -52,14 +62,14 @@ var _ = Describe("CPU pressure", func() {
 	When("Inject is called", func() {
 		DescribeTable("succeed with valid user requests",
 			func(count string, stressExpected int, cpus cpuset.CPUSet) {
-				inj := NewCPUPressureInjector(config, count, factory, args)
+				inj := NewCPUPressureInjector(config, countSpec(count), factory, args)
 
 				seenArgs := []string{strconv.Itoa(stressExpected)}
 
 				cgroups.EXPECT().ReadCPUSet().Return(cpus, nil).Maybe() // Only called when Int, let's be simple, externally we should not know
 				ctr.EXPECT().Name().Return(containerName).Once()
 
-				args.EXPECT().GenerateArgs(stressExpected).Return(seenArgs).Once()
+				args.EXPECT().GenerateArgs(CPUStressLoad{Percentage: stressExpected}).Return(seenArgs).Once()
 
 				background.EXPECT().Start().Return(nil).Once()
 				background.EXPECT().KeepAlive().Once()
@@ -79,6 +89,21 @@ var _ = Describe("CPU pressure", func() {
 			Entry("6 core out of 3", "6", 100, threeCPUs),
 		)
 
+		It("passes the target utilization and the profile to the stress", func() {
+			targetUtilization := 70
+			profile := &v1beta1.CPUPressureProfileSpec{Type: v1beta1.CPUPressureProfileRamp, Duration: "5m0s"}
+			inj := NewCPUPressureInjector(config, v1beta1.CPUPressureSpec{TargetUtilization: &targetUtilization, Profile: profile}, factory, args)
+
+			ctr.EXPECT().Name().Return(containerName).Once()
+			args.EXPECT().GenerateArgs(CPUStressLoad{TargetUtilization: 70, Profile: profile}).Return(nil).Once()
+			factory.EXPECT().NewInjectorBackgroundCmd(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(background, nothingToCancel, nil).Once()
+			background.EXPECT().Start().Return(nil).Once()
+			background.EXPECT().KeepAlive().Once()
+
+			Expect(inj.Inject()).To(Succeed())
+			cgroups.AssertNotCalled(GinkgoT(), "ReadCPUSet")
+		})
+
 		Context("fails", func() {
 			var inj Injector
 			ExpectInjectError := func(expectedError string) {
@@ -88,23 +113,23 @@ var _ = Describe("CPU pressure", func() {
 			}
 
 			It("when count is empty", func() {
-				inj = NewCPUPressureInjector(config, "", factory, args)
+				inj = NewCPUPressureInjector(config, countSpec(""), factory, args)
 
 				ExpectInjectError("unable to calculate stress percentage for '': invalid value for IntOrString: invalid type: string is not a percentage")
 			})
 
 			It("with cgroup manager error", func() {
-				inj = NewCPUPressureInjector(config, "2", factory, args)
+				inj = NewCPUPressureInjector(config, countSpec("2"), factory, args)
 				cgroups.EXPECT().ReadCPUSet().Return(noCPUs, errors.New("cgroup manager error")).Once()
 
 				ExpectInjectError("unable to read CPUSet for current container: cgroup manager error")
 			})
 
 			It("with background manager error", func() {
-				inj = NewCPUPressureInjector(config, "100%", factory, args)
+				inj = NewCPUPressureInjector(config, countSpec("100%"), factory, args)
 
 				ctr.EXPECT().Name().Return("").Once()
-				args.EXPECT().GenerateArgs(100).Return(nil).Once()
+				args.EXPECT().GenerateArgs(CPUStressLoad{Percentage: 100}).Return(nil).Once()
 				factory.EXPECT().NewInjectorBackgroundCmd(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil, errors.New("background manager error")).Once()
 
 				ExpectInjectError("unable to create new process definition for injector: background manager error")
@@ -114,7 +139,7 @@ var _ = Describe("CPU pressure", func() {
 
 	When("Clean is called", func() {
 		It("succeed if no background process", func() {
-			inj := NewCPUPressureInjector(config, "", factory, args)
+			inj := NewCPUPressureInjector(config, countSpec(""), factory, args)
 			Expect(inj.Clean()).To(Succeed())
 		})
 
@@ -123,10 +148,10 @@ var _ = Describe("CPU pressure", func() {
 			background.EXPECT().KeepAlive().Once()
 			background.EXPECT().Stop().Return(nil).Once()
 
-			inj := NewCPUPressureInjector(config, "100%", factory, args)
+			inj := NewCPUPressureInjector(config, countSpec("100%"), factory, args)
 
 			ctr.EXPECT().Name().Return("").Once()
-			args.EXPECT().GenerateArgs(100).Return(nil).Once()
+			args.EXPECT().GenerateArgs(CPUStressLoad{Percentage: 100}).Return(nil).Once()
 			factory.EXPECT().NewInjectorBackgroundCmd(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(background, nothingToCancel, nil)
 
 			Expect(inj.Inject()).To(Succeed()) // we need to first call inject to store the background process
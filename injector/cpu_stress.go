@@ -5,30 +5,147 @@
 package injector
 
 import (
+	"bufio"
 	"fmt"
 	"math"
+	"os"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/DataDog/chaos-controller/api/v1beta1"
 	"github.com/DataDog/chaos-controller/process"
 	"github.com/DataDog/chaos-controller/types"
+	"go.uber.org/zap"
 )
 
+const (
+	// cpuStressPeriod is the period of a stress cycle, made of a stress and a pause
+	cpuStressPeriod = 100 * time.Millisecond
+	// cpuStressMeasurePeriods is the number of stress cycles between two measures of the core utilization
+	cpuStressMeasurePeriods = 10
+)
+
+// CPUStressLoad is the load a cpu stress generates on each core
+type CPUStressLoad struct {
+	// Percentage is the fixed percentage of time each core is stressed
+	Percentage int
+	// TargetUtilization, when not 0, makes the stress adapt to keep each core busy this percentage of time
+	TargetUtilization int
+	// Profile makes the stress level vary over time up to the percentage or the target utilization
+	Profile *v1beta1.CPUPressureProfileSpec
+}
+
+// Adaptive returns true if the stress adapts to the load already running on the cores
+func (l CPUStressLoad) Adaptive() bool {
+	return l.TargetUtilization != 0
+}
+
+// StressPercentage returns the percentage of time to stress a core once the given time elapsed since the beginning of the stress,
+// othersUtilization being the percentage of time the core is kept busy by other processes (only used by an adaptive stress)
+func (l CPUStressLoad) StressPercentage(elapsed time.Duration, othersUtilization int) int {
+	level := l.Percentage
+	if l.Adaptive() {
+		level = l.TargetUtilization
+	}
+
+	percentage := l.Profile.LevelAt(level, elapsed)
+	if l.Adaptive() {
+		percentage -= othersUtilization
+	}
+
+	if percentage < 0 {
+		return 0
+	} else if percentage > 100 {
+		return 100
+	}
+
+	return percentage
+}
+
+// CPUUsageReader reads the cumulated busy and total times of a core
+type CPUUsageReader interface {
+	Read(cpu int) (busy uint64, total uint64, err error)
+}
+
+type procStatCPUUsageReader struct{}
+
+// NewCPUUsageReader creates a cpu usage reader relying on /proc/stat
+func NewCPUUsageReader() CPUUsageReader {
+	return procStatCPUUsageReader{}
+}
+
+func (procStatCPUUsageReader) Read(cpu int) (uint64, uint64, error) {
+	file, err := os.Open("/proc/stat")
+	if err != nil {
+		return 0, 0, fmt.Errorf("unable to open /proc/stat: %w", err)
+	}
+	defer file.Close()
+
+	prefix := fmt.Sprintf("cpu%d ", cpu)
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, prefix) {
+			continue
+		}
+
+		// cpuN user nice system idle iowait irq softirq steal guest guest_nice
+		// guest times are already accounted in user times
+		fields := strings.Fields(line)[1:]
+		if len(fields) < 5 {
+			return 0, 0, fmt.Errorf("unexpected /proc/stat line format: %s", line)
+		}
+
+		if len(fields) > 8 {
+			fields = fields[:8]
+		}
+
+		var total, idle uint64
+
+		for i, field := range fields {
+			value, err := strconv.ParseUint(field, 10, 64)
+			if err != nil {
+				return 0, 0, fmt.Errorf("unexpected /proc/stat value %s: %w", field, err)
+			}
+
+			total += value
+
+			// idle and iowait
+			if i == 3 || i == 4 {
+				idle += value
+			}
+		}
+
+		return total - idle, total, nil
+	}
+
+	if err := scanner.Err(); err != nil {
+		return 0, 0, fmt.Errorf("unable to read /proc/stat: %w", err)
+	}
+
+	return 0, 0, fmt.Errorf("cpu %d not found in /proc/stat", cpu)
+}
+
 type cpuStressInjector struct {
 	config        *Config
 	process       process.Manager
 	runtime       process.Runtime
-	percentage    int
+	usage         CPUUsageReader
+	load          CPUStressLoad
 	exiters       chan struct{}
 	exitCompleted chan struct{}
 }
 
-// NewCPUPressureInjector creates a CPU pressure injector with the given config
-func NewCPUStressInjector(config Config, percentage int, process process.Manager, runtime process.Runtime) Injector {
+// NewCPUStressInjector creates a CPU stress injector with the given config
+func NewCPUStressInjector(config Config, load CPUStressLoad, process process.Manager, runtime process.Runtime, usage CPUUsageReader) Injector {
 	return &cpuStressInjector{
-		config:     &config,
-		percentage: percentage,
-		process:    process,
-		runtime:    runtime,
+		config:  &config,
+		load:    load,
+		process: process,
+		runtime: runtime,
+		usage:   usage,
 	}
 }
 
@@ -108,7 +225,7 @@ func (c *cpuStressInjector) Clean() error {
 
 // stress run a cpu intensive operation on cpu until an exit signal is received
 func (c *cpuStressInjector) stress(cpu int) {
-	logger := c.config.Log.With("cpu", cpu, "percentage", c.percentage)
+	logger := c.config.Log.With("cpu", cpu, "percentage", c.load.Percentage, "target_utilization", c.load.TargetUtilization)
 
 	stressConfigurationCompleted := make(chan struct{}, 1)
 
@@ -142,15 +259,19 @@ func (c *cpuStressInjector) stress(cpu int) {
 			logger.Warnw("unable to set affinity to a specific cpu, thread might move to another CPU", "error", err)
 		}
 
-		totalDuration := 100 * time.Millisecond
-		cpuPressureOnDuration := time.Duration(math.Floor(float64(totalDuration) * float64(c.percentage) / float64(100)))
-		cpuPressureOffDuration := totalDuration - cpuPressureOnDuration
+		start := time.Now()
+		othersUtilization := 0
+		percentage := c.load.StressPercentage(0, othersUtilization)
+
+		measure := c.newUtilizationMeasure(cpu, logger)
 
-		logger.Infow("stress is starting", "stress_duration", cpuPressureOnDuration, "pause_duration", cpuPressureOffDuration)
+		logger.Infow("stress is starting", "stress_percentage", percentage, "profile", c.load.Profile)
 
 		stressConfigurationCompleted <- struct{}{}
 
-		for {
+		for period := 1; ; period++ {
+			cpuPressureOnDuration := time.Duration(math.Floor(float64(cpuStressPeriod) * float64(percentage) / float64(100)))
+			cpuPressureOffDuration := cpuStressPeriod - cpuPressureOnDuration
 			stressUntilOff := time.After(cpuPressureOnDuration)
 
 		stressLoop:
@@ -164,8 +285,80 @@ func (c *cpuStressInjector) stress(cpu int) {
 				default:
 				}
 			}
+
+			if measure != nil {
+				measure.stressed += cpuPressureOnDuration
+
+				if period%cpuStressMeasurePeriods == 0 {
+					othersUtilization = measure.othersUtilization()
+				}
+			}
+
+			if newPercentage := c.load.StressPercentage(time.Since(start), othersUtilization); newPercentage != percentage {
+				logger.Debugw("stress percentage changed", "stress_percentage", newPercentage, "others_utilization", othersUtilization)
+
+				percentage = newPercentage
+			}
 		}
 	}()
 
 	<-stressConfigurationCompleted
 }
+
+// cpuUtilizationMeasure measures the utilization of a core by other processes than the stress
+type cpuUtilizationMeasure struct {
+	cpu       int
+	usage     CPUUsageReader
+	logger    *zap.SugaredLogger
+	valid     bool
+	busy      uint64
+	total     uint64
+	since     time.Time
+	stressed  time.Duration
+	lastValue int
+}
+
+// newUtilizationMeasure returns a measure of the utilization of the given core for an adaptive stress, nil otherwise
+func (c *cpuStressInjector) newUtilizationMeasure(cpu int, logger *zap.SugaredLogger) *cpuUtilizationMeasure {
+	if !c.load.Adaptive() {
+		return nil
+	}
+
+	m := &cpuUtilizationMeasure{
+		cpu:    cpu,
+		usage:  c.usage,
+		logger: logger,
+	}
+	m.reset()
+
+	return m
+}
+
+// reset starts a new measure
+func (m *cpuUtilizationMeasure) reset() {
+	busy, total, err := m.usage.Read(m.cpu)
+	if err != nil {
+		m.logger.Warnw("unable to read the cpu usage, keeping the last known load of the core", "error", err)
+	}
+
+	m.valid, m.busy, m.total, m.since, m.stressed = err == nil, busy, total, time.Now(), 0
+}
+
+// othersUtilization returns the percentage of time the core was busy because of other processes since the last measure,
+// the last known value is kept when the usage can't be read
+func (m *cpuUtilizationMeasure) othersUtilization() int {
+	valid, busy, total, stressed, elapsed := m.valid, m.busy, m.total, m.stressed, time.Since(m.since)
+
+	m.reset()
+
+	if !valid || !m.valid || m.total <= total || elapsed <= 0 {
+		return m.lastValue
+	}
+
+	utilization := float64(m.busy-busy) / float64(m.total-total) * 100
+	stress := float64(stressed) / float64(elapsed) * 100
+
+	m.lastValue = int(math.Max(0, math.Round(utilization-stress)))
+
+	return m.lastValue
+}
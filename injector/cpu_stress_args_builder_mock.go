@@ -22,11 +22,11 @@ func (_m *CPUStressArgsBuilderMock) EXPECT() *CPUStressArgsBuilderMock_Expecter
 }
 
 // GenerateArgs provides a mock function with given fields: _a0
-func (_m *CPUStressArgsBuilderMock) GenerateArgs(_a0 int) []string {
+func (_m *CPUStressArgsBuilderMock) GenerateArgs(_a0 CPUStressLoad) []string {
 	ret := _m.Called(_a0)
 
 	var r0 []string
-	if rf, ok := ret.Get(0).(func(int) []string); ok {
+	if rf, ok := ret.Get(0).(func(CPUStressLoad) []string); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
@@ -43,14 +43,14 @@ type CPUStressArgsBuilderMock_GenerateArgs_Call struct {
 }
 
 // GenerateArgs is a helper method to define mock.On call
-//   - _a0 int
+//   - _a0 CPUStressLoad
 func (_e *CPUStressArgsBuilderMock_Expecter) GenerateArgs(_a0 interface{}) *CPUStressArgsBuilderMock_GenerateArgs_Call {
 	return &CPUStressArgsBuilderMock_GenerateArgs_Call{Call: _e.mock.On("GenerateArgs", _a0)}
 }
 
-func (_c *CPUStressArgsBuilderMock_GenerateArgs_Call) Run(run func(_a0 int)) *CPUStressArgsBuilderMock_GenerateArgs_Call {
+func (_c *CPUStressArgsBuilderMock_GenerateArgs_Call) Run(run func(_a0 CPUStressLoad)) *CPUStressArgsBuilderMock_GenerateArgs_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(int))
+		run(args[0].(CPUStressLoad))
 	})
 	return _c
 }
@@ -60,7 +60,7 @@ func (_c *CPUStressArgsBuilderMock_GenerateArgs_Call) Return(_a0 []string) *CPUS
 	return _c
 }
 
-func (_c *CPUStressArgsBuilderMock_GenerateArgs_Call) RunAndReturn(run func(int) []string) *CPUStressArgsBuilderMock_GenerateArgs_Call {
+func (_c *CPUStressArgsBuilderMock_GenerateArgs_Call) RunAndReturn(run func(CPUStressLoad) []string) *CPUStressArgsBuilderMock_GenerateArgs_Call {
 	_c.Call.Return(run)
 	return _c
 }
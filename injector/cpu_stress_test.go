@@ -6,7 +6,9 @@ package injector_test
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/DataDog/chaos-controller/api/v1beta1"
 	"github.com/DataDog/chaos-controller/cgroup"
 	"github.com/DataDog/chaos-controller/cpuset"
 	. "github.com/DataDog/chaos-controller/injector"
@@ -39,7 +41,7 @@ var _ = Describe("CPU stress", func() {
 	})
 
 	JustBeforeEach(func() {
-		inj = NewCPUStressInjector(config, 10, manager, runtime)
+		inj = NewCPUStressInjector(config, CPUStressLoad{Percentage: 10}, manager, runtime, NewCPUUsageReaderMock(GinkgoT()))
 	})
 
 	Specify("invalid CPUSet returns error", func() {
@@ -106,4 +108,19 @@ var _ = Describe("CPU stress", func() {
 			})
 		})
 	})
+
+	Describe("CPUStressLoad.StressPercentage", func() {
+		ramp := &v1beta1.CPUPressureProfileSpec{Type: v1beta1.CPUPressureProfileRamp, Duration: "1m"}
+
+		DescribeTable("should compute the stress percentage",
+			func(load CPUStressLoad, elapsed time.Duration, othersUtilization int, expected int) {
+				Expect(load.StressPercentage(elapsed, othersUtilization)).To(Equal(expected))
+			},
+			Entry("fixed percentage", CPUStressLoad{Percentage: 60}, time.Minute, 30, 60),
+			Entry("fixed percentage with a ramp", CPUStressLoad{Percentage: 60, Profile: ramp}, 30*time.Second, 0, 30),
+			Entry("target utilization", CPUStressLoad{TargetUtilization: 70}, time.Minute, 30, 40),
+			Entry("target utilization already reached", CPUStressLoad{TargetUtilization: 70}, time.Minute, 90, 0),
+			Entry("target utilization with a ramp", CPUStressLoad{TargetUtilization: 80, Profile: ramp}, 30*time.Second, 10, 30),
+		)
+	})
 })
@@ -0,0 +1,255 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package injector
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/DataDog/chaos-controller/api/v1beta1"
+	"github.com/DataDog/chaos-controller/types"
+)
+
+const (
+	cpuThrottlingControllerName   = "cpu"
+	cpuThrottlingV2Filename       = "cpu.max"
+	cpuThrottlingV1QuotaFilename  = "cpu.cfs_quota_us"
+	cpuThrottlingV1PeriodFilename = "cpu.cfs_period_us"
+	cpuThrottlingV2Unlimited      = "max"
+	// cpuThrottlingMinQuota is the minimum quota accepted by the kernel, in microseconds
+	cpuThrottlingMinQuota = 1000
+	// cpuThrottlingStateDirectory is the directory where the original quotas are persisted, /run being mounted from the host
+	cpuThrottlingStateDirectory = "/run/chaos-controller/cpu-throttling"
+)
+
+type cpuThrottlingInjector struct {
+	spec   v1beta1.CPUPressureThrottlingSpec
+	config CPUThrottlingInjectorConfig
+	// original is the quota file content before the injection, empty if not injected
+	original string
+}
+
+// CPUThrottlingInjectorConfig is the cpu throttling injector config
+type CPUThrottlingInjectorConfig struct {
+	Config
+	// StateDirectory is the directory where the original quotas are persisted
+	// so they can be restored by another injector if this one crashes
+	StateDirectory string
+}
+
+// NewCPUThrottlingInjector creates a cpu throttling injector with the given config
+func NewCPUThrottlingInjector(spec v1beta1.CPUPressureThrottlingSpec, config CPUThrottlingInjectorConfig) Injector {
+	if config.StateDirectory == "" {
+		config.StateDirectory = cpuThrottlingStateDirectory
+	}
+
+	return &cpuThrottlingInjector{
+		spec:   spec,
+		config: config,
+	}
+}
+
+func (i *cpuThrottlingInjector) GetDisruptionKind() types.DisruptionKindName {
+	return types.DisruptionKindCPUPressure
+}
+
+func (i *cpuThrottlingInjector) Inject() error {
+	original, err := i.originalQuota()
+	if err != nil {
+		return err
+	}
+
+	quota, period, err := i.parseQuota(original)
+	if err != nil {
+		return err
+	}
+
+	// without any limit, the targets can use all their allocated cores
+	if quota < 0 {
+		cpus, err := i.config.Cgroup.ReadCPUSet()
+		if err != nil {
+			return fmt.Errorf("unable to read CPUSet: %w", err)
+		}
+
+		quota = int64(cpus.Size()) * period
+	}
+
+	throttled := quota * int64(i.spec.Percentage) / 100
+	if throttled < cpuThrottlingMinQuota {
+		throttled = cpuThrottlingMinQuota
+	}
+
+	// persist the original quota before lowering it so it can't be lost
+	if err := i.persistOriginalQuota(original); err != nil {
+		return err
+	}
+
+	i.original = original
+
+	if err := i.writeQuota(strconv.FormatInt(throttled, 10), period); err != nil {
+		return fmt.Errorf("error throttling the cpu: %w", err)
+	}
+
+	i.config.Log.Infow("cpu throttled", "original", original, "quota", throttled, "period", period)
+
+	return nil
+}
+
+func (i *cpuThrottlingInjector) UpdateConfig(config Config) {
+	i.config.Config = config
+}
+
+func (i *cpuThrottlingInjector) Clean() error {
+	original := i.original
+
+	// the original quota may have been persisted by a previous injector
+	if original == "" {
+		content, err := os.ReadFile(i.stateFile())
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("error reading the persisted cpu quota: %w", err)
+		}
+
+		original = string(content)
+	}
+
+	if i.config.Cgroup.IsCgroupV2() {
+		if err := i.config.Cgroup.Write(cpuThrottlingControllerName, cpuThrottlingV2Filename, original); err != nil {
+			return fmt.Errorf("error restoring the cpu quota: %w", err)
+		}
+	} else if err := i.config.Cgroup.Write(cpuThrottlingControllerName, cpuThrottlingV1QuotaFilename, original); err != nil {
+		return fmt.Errorf("error restoring the cpu quota: %w", err)
+	}
+
+	if err := os.Remove(i.stateFile()); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("error removing the persisted cpu quota: %w", err)
+	}
+
+	i.original = ""
+
+	i.config.Log.Infow("cpu quota restored", "quota", original)
+
+	return nil
+}
+
+// JournalChanges returns the cpu quota file of the target and its original value once throttled
+func (i *cpuThrottlingInjector) JournalChanges() []JournalChange {
+	if i.original == "" {
+		return []JournalChange{}
+	}
+
+	file := cpuThrottlingV1QuotaFilename
+	if i.config.Cgroup.IsCgroupV2() {
+		file = cpuThrottlingV2Filename
+	}
+
+	return []JournalChange{
+		{Type: JournalChangeCgroupFile, Controller: cpuThrottlingControllerName, File: file, Value: i.original},
+	}
+}
+
+// originalQuota returns the quota file content before any injection,
+// preferring the one persisted by a previous injector which could have crashed before cleaning
+func (i *cpuThrottlingInjector) originalQuota() (string, error) {
+	if i.original != "" {
+		return i.original, nil
+	}
+
+	content, err := os.ReadFile(i.stateFile())
+	if err == nil {
+		i.config.Log.Infow("using the cpu quota persisted by a previous injector", "quota", string(content))
+
+		return string(content), nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("error reading the persisted cpu quota: %w", err)
+	}
+
+	file := cpuThrottlingV1QuotaFilename
+	if i.config.Cgroup.IsCgroupV2() {
+		file = cpuThrottlingV2Filename
+	}
+
+	original, err := i.config.Cgroup.Read(cpuThrottlingControllerName, file)
+	if err != nil {
+		return "", fmt.Errorf("error reading the cpu quota: %w", err)
+	}
+
+	return original, nil
+}
+
+// parseQuota returns the quota and the period in microseconds from the given quota file content,
+// the quota being negative when the cpu is not limited
+func (i *cpuThrottlingInjector) parseQuota(original string) (quota int64, period int64, err error) {
+	rawQuota, rawPeriod := strings.TrimSpace(original), ""
+
+	if i.config.Cgroup.IsCgroupV2() {
+		// cgroups v2 cpu.max file format is "$MAX $PERIOD"
+		fields := strings.Fields(original)
+		if len(fields) != 2 {
+			return 0, 0, fmt.Errorf("unexpected %s content: %s", cpuThrottlingV2Filename, original)
+		}
+
+		rawQuota, rawPeriod = fields[0], fields[1]
+	} else if rawPeriod, err = i.config.Cgroup.Read(cpuThrottlingControllerName, cpuThrottlingV1PeriodFilename); err != nil {
+		return 0, 0, fmt.Errorf("error reading the cpu period: %w", err)
+	}
+
+	if period, err = strconv.ParseInt(strings.TrimSpace(rawPeriod), 10, 64); err != nil {
+		return 0, 0, fmt.Errorf("unexpected cpu period %s: %w", rawPeriod, err)
+	}
+
+	if rawQuota == cpuThrottlingV2Unlimited {
+		return -1, period, nil
+	}
+
+	if quota, err = strconv.ParseInt(rawQuota, 10, 64); err != nil {
+		return 0, 0, fmt.Errorf("unexpected cpu quota %s: %w", rawQuota, err)
+	}
+
+	return quota, period, nil
+}
+
+// writeQuota writes the given quota to the cgroup
+func (i *cpuThrottlingInjector) writeQuota(quota string, period int64) error {
+	if i.config.Cgroup.IsCgroupV2() {
+		return i.config.Cgroup.Write(cpuThrottlingControllerName, cpuThrottlingV2Filename, fmt.Sprintf("%s %d", quota, period))
+	}
+
+	return i.config.Cgroup.Write(cpuThrottlingControllerName, cpuThrottlingV1QuotaFilename, quota)
+}
+
+// persistOriginalQuota stores the original quota on the host, keeping the one persisted by a previous injector
+func (i *cpuThrottlingInjector) persistOriginalQuota(original string) error {
+	if i.config.Disruption.DryRun {
+		return nil
+	}
+
+	if _, err := os.Stat(i.stateFile()); err == nil {
+		return nil
+	}
+
+	if err := os.MkdirAll(i.config.StateDirectory, 0o700); err != nil {
+		return fmt.Errorf("error creating the cpu throttling state directory: %w", err)
+	}
+
+	if err := os.WriteFile(i.stateFile(), []byte(original), 0o600); err != nil {
+		return fmt.Errorf("error persisting the original cpu quota: %w", err)
+	}
+
+	return nil
+}
+
+// stateFile returns the file persisting the original quota of the target cgroup
+func (i *cpuThrottlingInjector) stateFile() string {
+	name := strings.ReplaceAll(strings.Trim(i.config.Cgroup.RelativePath(cpuThrottlingControllerName), "/"), "/", "_")
+
+	return filepath.Join(i.config.StateDirectory, name)
+}
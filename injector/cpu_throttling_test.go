@@ -0,0 +1,128 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+package injector_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/DataDog/chaos-controller/api/v1beta1"
+	"github.com/DataDog/chaos-controller/cgroup"
+	"github.com/DataDog/chaos-controller/cpuset"
+	. "github.com/DataDog/chaos-controller/injector"
+)
+
+var _ = Describe("CPU throttling", func() {
+	var (
+		config        CPUThrottlingInjectorConfig
+		cgroupManager *cgroup.ManagerMock
+		inj           Injector
+		spec          v1beta1.CPUPressureThrottlingSpec
+		stateFile     string
+	)
+
+	BeforeEach(func() {
+		// cgroup
+		cgroupManager = cgroup.NewManagerMock(GinkgoT())
+		cgroupManager.EXPECT().RelativePath("cpu").Return("/kubepods/pod1/ctr1").Maybe()
+
+		// config
+		config = CPUThrottlingInjectorConfig{
+			Config: Config{
+				Log:         log,
+				MetricsSink: ms,
+				Cgroup:      cgroupManager,
+			},
+			StateDirectory: GinkgoT().TempDir(),
+		}
+		stateFile = filepath.Join(config.StateDirectory, "kubepods_pod1_ctr1")
+
+		// spec
+		spec = v1beta1.CPUPressureThrottlingSpec{
+			Percentage: 25,
+		}
+	})
+
+	JustBeforeEach(func() {
+		inj = NewCPUThrottlingInjector(spec, config)
+	})
+
+	Context("with cgroups v2", func() {
+		BeforeEach(func() {
+			cgroupManager.EXPECT().IsCgroupV2().Return(true)
+		})
+
+		Context("with a cpu limit", func() {
+			BeforeEach(func() {
+				cgroupManager.EXPECT().Read("cpu", "cpu.max").Return("200000 100000", nil).Once()
+				cgroupManager.EXPECT().Write("cpu", "cpu.max", "50000 100000").Return(nil).Once()
+			})
+
+			It("should lower the quota, persist the original one and restore it on clean", func() {
+				Expect(inj.Inject()).To(Succeed())
+				Expect(os.ReadFile(stateFile)).To(Equal([]byte("200000 100000")))
+
+				cgroupManager.EXPECT().Write("cpu", "cpu.max", "200000 100000").Return(nil).Once()
+				Expect(inj.Clean()).To(Succeed())
+				Expect(stateFile).ToNot(BeAnExistingFile())
+			})
+		})
+
+		Context("without any cpu limit", func() {
+			BeforeEach(func() {
+				cgroupManager.EXPECT().Read("cpu", "cpu.max").Return("max 100000", nil).Once()
+				cgroupManager.EXPECT().ReadCPUSet().Return(cpuset.NewCPUSet(0, 1), nil).Once()
+				cgroupManager.EXPECT().Write("cpu", "cpu.max", "50000 100000").Return(nil).Once()
+				cgroupManager.EXPECT().Write("cpu", "cpu.max", "max 100000").Return(nil).Once()
+			})
+
+			It("should limit the quota relatively to the allocated cores", func() {
+				Expect(inj.Inject()).To(Succeed())
+				Expect(inj.Clean()).To(Succeed())
+			})
+		})
+
+		Context("with a quota persisted by a crashed injector", func() {
+			BeforeEach(func() {
+				Expect(os.WriteFile(stateFile, []byte("400000 100000"), 0o600)).To(Succeed())
+				cgroupManager.EXPECT().Write("cpu", "cpu.max", "100000 100000").Return(nil).Once()
+				cgroupManager.EXPECT().Write("cpu", "cpu.max", "400000 100000").Return(nil).Once()
+			})
+
+			It("should compute and restore the quota from the persisted one", func() {
+				Expect(inj.Inject()).To(Succeed())
+				cgroupManager.AssertNotCalled(GinkgoT(), "Read", "cpu", "cpu.max")
+				Expect(inj.Clean()).To(Succeed())
+				Expect(stateFile).ToNot(BeAnExistingFile())
+			})
+		})
+
+		Context("when cleaning without injecting", func() {
+			It("should restore the quota persisted by a crashed injector", func() {
+				Expect(os.WriteFile(stateFile, []byte("400000 100000"), 0o600)).To(Succeed())
+				cgroupManager.EXPECT().Write("cpu", "cpu.max", "400000 100000").Return(nil).Once()
+				Expect(inj.Clean()).To(Succeed())
+			})
+		})
+	})
+
+	Context("with cgroups v1", func() {
+		BeforeEach(func() {
+			cgroupManager.EXPECT().IsCgroupV2().Return(false)
+			cgroupManager.EXPECT().Read("cpu", "cpu.cfs_quota_us").Return("100000", nil).Once()
+			cgroupManager.EXPECT().Read("cpu", "cpu.cfs_period_us").Return("100000", nil).Once()
+			cgroupManager.EXPECT().Write("cpu", "cpu.cfs_quota_us", "25000").Return(nil).Once()
+			cgroupManager.EXPECT().Write("cpu", "cpu.cfs_quota_us", "100000").Return(nil).Once()
+		})
+
+		It("should lower and restore the cfs quota", func() {
+			Expect(inj.Inject()).To(Succeed())
+			Expect(inj.Clean()).To(Succeed())
+		})
+	})
+})
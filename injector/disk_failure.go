@@ -15,6 +15,8 @@ import (
 	"go.uber.org/zap"
 )
 
+// DiskFailureInjector describes a disk failure injector
+// it is not journaled: the eBPF program is not pinned and is detached by the kernel when its process exits with the chaos pod
 type DiskFailureInjector struct {
 	spec   v1beta1.DiskFailureSpec
 	config DiskFailureInjectorConfig
@@ -27,7 +29,7 @@ type DiskFailureInjectorConfig struct {
 }
 
 type BPFDiskFailureCommand interface {
-	Run(pid int, path string) error
+	Run(pid int, paths []v1beta1.DiskFailurePathSpec) error
 }
 
 type bPFDiskFailureCommand struct {
@@ -36,11 +38,18 @@ type bPFDiskFailureCommand struct {
 
 const EBPFDiskFailureCmd = "bpf-disk-failure"
 
-func (d bPFDiskFailureCommand) Run(pid int, path string) (err error) {
+// bpfDiskFailureMatchFlags are the flags of the eBPF program for each way of matching a path
+var bpfDiskFailureMatchFlags = map[v1beta1.DiskFailurePathMatch]string{
+	v1beta1.DiskFailurePathMatchPrefix: "-f",
+	v1beta1.DiskFailurePathMatchExact:  "-e",
+	v1beta1.DiskFailurePathMatchSuffix: "-s",
+}
+
+func (d bPFDiskFailureCommand) Run(pid int, paths []v1beta1.DiskFailurePathSpec) (err error) {
 	commandPath := []string{"-p", strconv.Itoa(pid)}
 
-	if path != "" {
-		commandPath = append(commandPath, "-f", path)
+	for _, path := range paths {
+		commandPath = append(commandPath, bpfDiskFailureMatchFlags[path.GetMatch()], path.Pattern)
 	}
 
 	execCmd := exec.Command(EBPFDiskFailureCmd, commandPath...)
@@ -90,7 +99,7 @@ func (i *DiskFailureInjector) Inject() (err error) {
 		pid = int(i.config.Config.TargetContainer.PID())
 	}
 
-	err = i.config.Cmd.Run(pid, i.spec.Path)
+	err = i.config.Cmd.Run(pid, i.spec.PathPatterns())
 
 	return
 }
@@ -75,7 +75,21 @@ var _ = Describe("Failure", func() {
 			})
 
 			It("should start the eBPF Disk failure program", func() {
-				commandMock.AssertCalled(GinkgoT(), "Run", proc.Pid, "/")
+				commandMock.AssertCalled(GinkgoT(), "Run", proc.Pid, []v1beta1.DiskFailurePathSpec{{Pattern: "/", Match: v1beta1.DiskFailurePathMatchPrefix}})
+			})
+		})
+
+		Context("with path patterns", func() {
+			BeforeEach(func() {
+				config.Disruption.Level = types.DisruptionLevelNode
+				spec.Paths = []v1beta1.DiskFailurePathSpec{{Pattern: ".sst", Match: v1beta1.DiskFailurePathMatchSuffix}}
+			})
+
+			It("should start the eBPF Disk failure program with all the patterns", func() {
+				commandMock.AssertCalled(GinkgoT(), "Run", 0, []v1beta1.DiskFailurePathSpec{
+					{Pattern: "/", Match: v1beta1.DiskFailurePathMatchPrefix},
+					{Pattern: ".sst", Match: v1beta1.DiskFailurePathMatchSuffix},
+				})
 			})
 		})
 
@@ -86,7 +100,7 @@ var _ = Describe("Failure", func() {
 
 			It("should start the eBPF Disk failure program", func() {
 				ctr.AssertNumberOfCalls(GinkgoT(), "PID", 0)
-				commandMock.AssertCalled(GinkgoT(), "Run", 0, "/")
+				commandMock.AssertCalled(GinkgoT(), "Run", 0, []v1beta1.DiskFailurePathSpec{{Pattern: "/", Match: v1beta1.DiskFailurePathMatchPrefix}})
 			})
 		})
 	})
@@ -0,0 +1,252 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package injector
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/DataDog/chaos-controller/api/v1beta1"
+	"github.com/DataDog/chaos-controller/disk"
+	"github.com/DataDog/chaos-controller/env"
+	"github.com/DataDog/chaos-controller/types"
+)
+
+const (
+	diskFillDirectoryPrefix = ".chaos-disk-fill-"
+	diskFillFilePrefix      = "fill-"
+	diskFillDefaultInterval = 5 * time.Second
+)
+
+type diskFillInjector struct {
+	spec      v1beta1.DiskFillSpec
+	config    DiskFillInjectorConfig
+	path      string
+	hostPath  string
+	mountHost string
+	files     int
+	stop      chan struct{}
+	wg        sync.WaitGroup
+}
+
+// DiskFillInjectorConfig is the disk fill injector config
+type DiskFillInjectorConfig struct {
+	Config
+	Filesystem disk.Filesystem
+	// Interval is the interval between two checks of the filesystem usage to keep the fill level
+	Interval time.Duration
+}
+
+// NewDiskFillInjector creates a disk fill injector with the given config
+func NewDiskFillInjector(spec v1beta1.DiskFillSpec, config DiskFillInjectorConfig) (Injector, error) {
+	var err error
+
+	path := spec.Path
+
+	// get root mount path
+	mountHost, ok := os.LookupEnv(env.InjectorMountHost)
+	if !ok {
+		return nil, fmt.Errorf("environment variable %s doesn't exist", env.InjectorMountHost)
+	}
+
+	// get path from container info if we target a pod
+	if config.Disruption.Level == types.DisruptionLevelPod {
+		// get host path from mount path
+		path, err = config.TargetContainer.Runtime().HostPath(config.TargetContainer.ID(), spec.Path)
+		if err != nil {
+			return nil, fmt.Errorf("error getting the host path of %s: %w", spec.Path, err)
+		}
+
+		if len(path) == 0 {
+			config.Log.Warnf("could not apply injector on container: %s; %s not found on this targeted container.", config.TargetContainer.Name(), spec.Path)
+			return nil, nil
+		}
+	}
+
+	hostPath := filepath.Clean("/" + path)
+	path = filepath.Clean(mountHost + path)
+
+	// ensure the path exists before going further
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("error initializing the disk fill injector: %w", err)
+	}
+
+	if config.Filesystem == nil {
+		config.Filesystem = disk.NewFilesystem(config.Disruption.DryRun)
+	}
+
+	if config.Interval == 0 {
+		config.Interval = diskFillDefaultInterval
+	}
+
+	return &diskFillInjector{
+		spec:      spec,
+		config:    config,
+		path:      path,
+		hostPath:  hostPath,
+		mountHost: mountHost,
+	}, nil
+}
+
+func (i *diskFillInjector) GetDisruptionKind() types.DisruptionKindName {
+	return types.DisruptionKindDiskFill
+}
+
+func (i *diskFillInjector) Inject() error {
+	// safemode: refuse to fill the host root filesystem unless explicitly allowed
+	// the admission webhook only refuses the "/" path of a node, any other path (including container root filesystems
+	// and emptyDir volumes) is refused here if it is located on the same device as the host root filesystem
+	if !i.config.Disruption.AllowRootDiskFill {
+		if i.spec.FillsNodeRootFilesystem(i.config.Disruption.Level) {
+			return fmt.Errorf("refusing to fill %s because it is the host root filesystem, set unsafeMode.allowRootDiskFill to allow it", i.spec.Path)
+		}
+
+		isRoot, err := i.isOnHostRootFilesystem()
+		if err != nil {
+			return err
+		}
+
+		if isRoot {
+			return fmt.Errorf("refusing to fill %s because it is located on the host root filesystem, set unsafeMode.allowRootDiskFill to allow it", i.spec.Path)
+		}
+	}
+
+	if err := os.MkdirAll(i.fillDirectory(), 0o700); err != nil {
+		return fmt.Errorf("error creating the disk fill directory: %w", err)
+	}
+
+	// fill the disk a first time synchronously so injection errors are reported
+	if err := i.fill(); err != nil {
+		return err
+	}
+
+	// keep the fill level in the background in case the disk space is freed
+	i.stop = make(chan struct{})
+	i.wg.Add(1)
+
+	go i.keepFillLevel(i.stop)
+
+	return nil
+}
+
+func (i *diskFillInjector) UpdateConfig(config Config) {
+	i.config.Config = config
+}
+
+func (i *diskFillInjector) Clean() error {
+	// stop keeping the fill level before removing the files
+	if i.stop != nil {
+		close(i.stop)
+		i.wg.Wait()
+		i.stop = nil
+	}
+
+	if err := os.RemoveAll(i.fillDirectory()); err != nil {
+		return fmt.Errorf("error removing the disk fill directory: %w", err)
+	}
+
+	i.files = 0
+
+	i.config.Log.Infow("disk fill files removed", "path", i.spec.Path)
+
+	return nil
+}
+
+// JournalChanges returns the directory containing the allocated files, relative to the node root filesystem
+func (i *diskFillInjector) JournalChanges() []JournalChange {
+	return []JournalChange{
+		{Type: JournalChangeFile, Path: filepath.Join(i.hostPath, i.fillDirectoryName())},
+	}
+}
+
+// keepFillLevel periodically fills the disk again until the given channel is closed
+func (i *diskFillInjector) keepFillLevel(stop <-chan struct{}) {
+	defer i.wg.Done()
+
+	ticker := time.NewTicker(i.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if err := i.fill(); err != nil {
+				i.config.Log.Errorw("error keeping the disk fill level", "error", err, "path", i.spec.Path)
+			}
+		}
+	}
+}
+
+// fill allocates a new file with the missing space to reach the fill target, if any
+func (i *diskFillInjector) fill() error {
+	usage, err := i.config.Filesystem.Usage(i.path)
+	if err != nil {
+		return fmt.Errorf("error getting the disk usage: %w", err)
+	}
+
+	size := i.bytesToAllocate(usage)
+	if size == 0 {
+		return nil
+	}
+
+	file := filepath.Join(i.fillDirectory(), fmt.Sprintf("%s%d", diskFillFilePrefix, i.files))
+
+	if err := i.config.Filesystem.Allocate(file, size); err != nil {
+		return fmt.Errorf("error filling the disk: %w", err)
+	}
+
+	i.files++
+
+	i.config.Log.Infow("disk filled", "path", i.spec.Path, "file", file, "bytes", size, "total", usage.Total, "available", usage.Available-size)
+
+	return nil
+}
+
+// bytesToAllocate returns the number of bytes to allocate to reach the fill target from the given usage
+func (i *diskFillInjector) bytesToAllocate(usage disk.Usage) uint64 {
+	var remaining uint64
+
+	if i.spec.Percentage != nil {
+		remaining = usage.Total - usage.Total*uint64(*i.spec.Percentage)/100
+	} else if i.spec.RemainingBytes != nil {
+		remaining = uint64(*i.spec.RemainingBytes)
+	}
+
+	if usage.Available <= remaining {
+		return 0
+	}
+
+	return usage.Available - remaining
+}
+
+// isOnHostRootFilesystem returns true if the path to fill is located on the same device as the host root filesystem
+func (i *diskFillInjector) isOnHostRootFilesystem() (bool, error) {
+	rootDevice, err := i.config.Filesystem.Device(i.mountHost)
+	if err != nil {
+		return false, fmt.Errorf("error getting the host root filesystem device: %w", err)
+	}
+
+	pathDevice, err := i.config.Filesystem.Device(i.path)
+	if err != nil {
+		return false, fmt.Errorf("error getting the device of %s: %w", i.spec.Path, err)
+	}
+
+	return rootDevice == pathDevice, nil
+}
+
+// fillDirectory returns the directory containing the allocated files
+func (i *diskFillInjector) fillDirectory() string {
+	return filepath.Join(i.path, i.fillDirectoryName())
+}
+
+// fillDirectoryName returns the name of the directory containing the allocated files
+func (i *diskFillInjector) fillDirectoryName() string {
+	return diskFillDirectoryPrefix + i.config.Disruption.DisruptionName
+}
@@ -0,0 +1,232 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+package injector_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	chaosapi "github.com/DataDog/chaos-controller/api"
+	"github.com/DataDog/chaos-controller/api/v1beta1"
+	"github.com/DataDog/chaos-controller/container"
+	"github.com/DataDog/chaos-controller/disk"
+	"github.com/DataDog/chaos-controller/env"
+	. "github.com/DataDog/chaos-controller/injector"
+	"github.com/DataDog/chaos-controller/types"
+)
+
+var _ = Describe("Disk fill", func() {
+	var (
+		config     DiskFillInjectorConfig
+		filesystem *disk.FilesystemMock
+		inj        Injector
+		spec       v1beta1.DiskFillSpec
+		mountHost  string
+		fillDir    string
+	)
+
+	BeforeEach(func() {
+		// fake host root with the path to fill
+		mountHost = GinkgoT().TempDir()
+		Expect(os.MkdirAll(filepath.Join(mountHost, "data"), 0o700)).To(Succeed())
+		fillDir = filepath.Join(mountHost, "data", ".chaos-disk-fill-foo")
+
+		// env vars
+		os.Setenv(env.InjectorMountHost, mountHost)
+
+		// filesystem
+		filesystem = disk.NewFilesystemMock(GinkgoT())
+		filesystem.EXPECT().Device(filepath.Clean(mountHost)).Return(1, nil).Maybe()
+		filesystem.EXPECT().Device(filepath.Join(mountHost, "data")).Return(2, nil).Maybe()
+		filesystem.EXPECT().Usage(filepath.Join(mountHost, "data")).Return(disk.Usage{Total: 1000, Available: 600}, nil).Maybe()
+		filesystem.EXPECT().Allocate(mock.Anything, mock.Anything).Return(nil).Maybe()
+
+		// config
+		config = DiskFillInjectorConfig{
+			Config: Config{
+				Log:         log,
+				MetricsSink: ms,
+				Disruption: chaosapi.DisruptionArgs{
+					Level:          types.DisruptionLevelNode,
+					DisruptionName: "foo",
+				},
+			},
+			Filesystem: filesystem,
+			Interval:   time.Hour,
+		}
+
+		// spec
+		percentage := 80
+		spec = v1beta1.DiskFillSpec{
+			Path:       "/data",
+			Percentage: &percentage,
+		}
+	})
+
+	AfterEach(func() {
+		os.Unsetenv(env.InjectorMountHost)
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		inj, err = NewDiskFillInjector(spec, config)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Describe("injection", func() {
+		AfterEach(func() {
+			Expect(inj.Clean()).To(Succeed())
+		})
+
+		Context("with a percentage", func() {
+			It("should allocate the missing space to reach the percentage", func() {
+				Expect(inj.Inject()).To(Succeed())
+				filesystem.AssertCalled(GinkgoT(), "Allocate", filepath.Join(fillDir, "fill-0"), uint64(400))
+				Expect(fillDir).To(BeADirectory())
+			})
+		})
+
+		Context("with remaining bytes", func() {
+			BeforeEach(func() {
+				remainingBytes := 100
+				spec.Percentage = nil
+				spec.RemainingBytes = &remainingBytes
+			})
+
+			It("should allocate the space to only leave the remaining bytes", func() {
+				Expect(inj.Inject()).To(Succeed())
+				filesystem.AssertCalled(GinkgoT(), "Allocate", filepath.Join(fillDir, "fill-0"), uint64(500))
+			})
+		})
+
+		Context("with a target already reached", func() {
+			BeforeEach(func() {
+				percentage := 10
+				spec.Percentage = &percentage
+			})
+
+			It("should not allocate anything", func() {
+				Expect(inj.Inject()).To(Succeed())
+				filesystem.AssertNotCalled(GinkgoT(), "Allocate", mock.Anything, mock.Anything)
+			})
+		})
+
+		Context("when the disk space is freed", func() {
+			var refilled chan struct{}
+
+			BeforeEach(func() {
+				refilled = make(chan struct{})
+				config.Interval = 10 * time.Millisecond
+
+				filesystem = disk.NewFilesystemMock(GinkgoT())
+				filesystem.EXPECT().Device(mock.Anything).Return(0, nil).Once()
+				filesystem.EXPECT().Device(mock.Anything).Return(1, nil).Once()
+				filesystem.EXPECT().Usage(mock.Anything).Return(disk.Usage{Total: 1000, Available: 600}, nil).Once()
+				filesystem.EXPECT().Usage(mock.Anything).Return(disk.Usage{Total: 1000, Available: 300}, nil).Once()
+				filesystem.EXPECT().Usage(mock.Anything).Return(disk.Usage{Total: 1000, Available: 200}, nil).Maybe()
+				filesystem.EXPECT().Allocate(filepath.Join(fillDir, "fill-0"), uint64(400)).Return(nil).Once()
+				filesystem.EXPECT().Allocate(filepath.Join(fillDir, "fill-1"), uint64(100)).Run(func(string, uint64) {
+					close(refilled)
+				}).Return(nil).Once()
+				config.Filesystem = filesystem
+			})
+
+			It("should allocate more space to keep the fill level", func() {
+				Expect(inj.Inject()).To(Succeed())
+				Eventually(refilled).Should(BeClosed())
+			})
+		})
+	})
+
+	Describe("injection on the host root filesystem", func() {
+		BeforeEach(func() {
+			spec.Path = "/"
+			fillDir = filepath.Join(mountHost, ".chaos-disk-fill-foo")
+
+			filesystem = disk.NewFilesystemMock(GinkgoT())
+			config.Filesystem = filesystem
+		})
+
+		Context("when not allowed", func() {
+			It("should refuse to fill the disk", func() {
+				Expect(inj.Inject()).ToNot(Succeed())
+				filesystem.AssertNotCalled(GinkgoT(), "Allocate", mock.Anything, mock.Anything)
+			})
+		})
+
+		Context("when explicitly allowed", func() {
+			BeforeEach(func() {
+				config.Disruption.AllowRootDiskFill = true
+				filesystem.EXPECT().Usage(mock.Anything).Return(disk.Usage{Total: 1000, Available: 600}, nil)
+				filesystem.EXPECT().Allocate(mock.Anything, mock.Anything).Return(nil)
+			})
+
+			It("should fill the disk without checking its device", func() {
+				Expect(inj.Inject()).To(Succeed())
+				filesystem.AssertNotCalled(GinkgoT(), "Device", mock.Anything)
+				filesystem.AssertCalled(GinkgoT(), "Allocate", filepath.Join(fillDir, "fill-0"), uint64(400))
+				Expect(inj.Clean()).To(Succeed())
+			})
+		})
+	})
+
+	Describe("injection on a path located on the host root filesystem", func() {
+		BeforeEach(func() {
+			filesystem = disk.NewFilesystemMock(GinkgoT())
+			filesystem.EXPECT().Device(mock.Anything).Return(1, nil)
+			config.Filesystem = filesystem
+		})
+
+		It("should refuse to fill the disk", func() {
+			err := inj.Inject()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("located on the host root filesystem"))
+			filesystem.AssertNotCalled(GinkgoT(), "Allocate", mock.Anything, mock.Anything)
+			Expect(fillDir).ToNot(BeAnExistingFile())
+		})
+
+		Context("at the pod level", func() {
+			BeforeEach(func() {
+				// the path of the target container is backed by the node root disk, like an emptyDir volume
+				runtime := container.NewRuntimeMock(GinkgoT())
+				runtime.EXPECT().HostPath("fake", "/data").Return("/data", nil)
+
+				ctr := container.NewContainerMock(GinkgoT())
+				ctr.EXPECT().ID().Return("fake")
+				ctr.EXPECT().Runtime().Return(runtime)
+
+				config.Disruption.Level = types.DisruptionLevelPod
+				config.TargetContainer = ctr
+			})
+
+			It("should refuse to fill the disk", func() {
+				Expect(inj.Inject()).ToNot(Succeed())
+				filesystem.AssertNotCalled(GinkgoT(), "Allocate", mock.Anything, mock.Anything)
+			})
+		})
+	})
+
+	Describe("journal", func() {
+		It("should journal the fill directory relative to the host root filesystem", func() {
+			Expect(inj.(Journaled).JournalChanges()).To(Equal([]JournalChange{
+				{Type: JournalChangeFile, Path: "/data/.chaos-disk-fill-foo"},
+			}))
+		})
+	})
+
+	Describe("clean", func() {
+		It("should remove the allocated files", func() {
+			Expect(inj.Inject()).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(fillDir, "fill-0"), []byte("foo"), 0o600)).To(Succeed())
+			Expect(inj.Clean()).To(Succeed())
+			Expect(fillDir).ToNot(BeAnExistingFile())
+		})
+	})
+})
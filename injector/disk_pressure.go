@@ -10,6 +10,8 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/DataDog/chaos-controller/api/v1beta1"
 	"github.com/DataDog/chaos-controller/disk"
@@ -36,7 +38,25 @@ const (
 	diskPressureThrottleModeWrite
 )
 
-const diskPressureBlkioControllerName = "blkio"
+// Possible throttle units enum
+type diskPressureThrottleUnit int
+
+const (
+	diskPressureThrottleUnitBPS diskPressureThrottleUnit = iota
+	diskPressureThrottleUnitIOPS
+)
+
+const (
+	diskPressureBlkioControllerName = "blkio"
+	diskPressureLatencyFilename     = "io.latency"
+)
+
+// diskPressureThrottle is a throttle applied on a given operation mode and unit
+type diskPressureThrottle struct {
+	mode  diskPressureThrottleMode
+	unit  diskPressureThrottleUnit
+	value *int
+}
 
 // NewDiskPressureInjector creates a disk pressure injector with the given config
 func NewDiskPressureInjector(spec v1beta1.DiskPressureSpec, config DiskPressureInjectorConfig) (Injector, error) {
@@ -84,22 +104,29 @@ func (i *diskPressureInjector) GetDisruptionKind() types.DisruptionKindName {
 }
 
 func (i *diskPressureInjector) Inject() error {
-	// add read throttle
-	if i.spec.Throttling.ReadBytesPerSec != nil {
-		if err := i.config.Cgroup.Write(diskPressureBlkioControllerName, i.getThrottleFilename(diskPressureThrottleModeRead), i.formatThrottle(*i.spec.Throttling.ReadBytesPerSec, diskPressureThrottleModeRead)); err != nil {
-			return fmt.Errorf("error throttling disk read: %w", err)
+	for _, throttle := range i.getThrottles() {
+		if throttle.value == nil {
+			continue
+		}
+
+		if err := i.config.Cgroup.Write(diskPressureBlkioControllerName, i.getThrottleFilename(throttle.mode, throttle.unit), i.formatThrottle(*throttle.value, throttle.mode, throttle.unit)); err != nil {
+			return fmt.Errorf("error throttling disk %s %s: %w", throttle.mode, throttle.unit, err)
 		}
 
-		i.config.Log.Infow("read throttling injected", "device", i.config.Informer.Source(), "bps", *i.spec.Throttling.ReadBytesPerSec)
+		i.config.Log.Infow(fmt.Sprintf("%s throttling injected", throttle.mode), "device", i.config.Informer.Source(), throttle.unit.String(), *throttle.value)
 	}
 
-	// add write throttle
-	if i.spec.Throttling.WriteBytesPerSec != nil {
-		if err := i.config.Cgroup.Write(diskPressureBlkioControllerName, i.getThrottleFilename(diskPressureThrottleModeWrite), i.formatThrottle(*i.spec.Throttling.WriteBytesPerSec, diskPressureThrottleModeWrite)); err != nil {
-			return fmt.Errorf("error throttling disk write: %w", err)
+	// add latency target
+	if i.spec.Throttling.LatencyTarget != "" {
+		if !i.config.Cgroup.IsCgroupV2() {
+			return fmt.Errorf("the io latency target is only supported by cgroups v2")
+		}
+
+		if err := i.config.Cgroup.Write(diskPressureBlkioControllerName, diskPressureLatencyFilename, i.formatLatencyTarget(i.spec.Throttling.LatencyTarget.Duration())); err != nil {
+			return fmt.Errorf("error setting disk latency target: %w", err)
 		}
 
-		i.config.Log.Infow("write throttling injected", "device", i.config.Informer.Source(), "bps", *i.spec.Throttling.WriteBytesPerSec)
+		i.config.Log.Infow("latency target injected", "device", i.config.Informer.Source(), "target", i.spec.Throttling.LatencyTarget)
 	}
 
 	return nil
@@ -110,26 +137,131 @@ func (i *diskPressureInjector) UpdateConfig(config Config) {
 }
 
 func (i *diskPressureInjector) Clean() error {
-	// clean read throttle
-	i.config.Log.Infow("cleaning disk read throttle", "device", i.config.Informer.Source())
+	for _, throttle := range i.getThrottles() {
+		// bandwidth throttles are always cleaned while iops ones are only cleaned when injected
+		if throttle.unit == diskPressureThrottleUnitIOPS && throttle.value == nil {
+			continue
+		}
+
+		i.config.Log.Infow(fmt.Sprintf("cleaning disk %s %s throttle", throttle.mode, throttle.unit), "device", i.config.Informer.Source())
 
-	if err := i.config.Cgroup.Write(diskPressureBlkioControllerName, i.getThrottleFilename(diskPressureThrottleModeRead), i.formatThrottle(0, diskPressureThrottleModeRead)); err != nil {
-		return fmt.Errorf("error cleaning read disk throttle: %w", err)
+		if err := i.config.Cgroup.Write(diskPressureBlkioControllerName, i.getThrottleFilename(throttle.mode, throttle.unit), i.formatThrottle(0, throttle.mode, throttle.unit)); err != nil {
+			return fmt.Errorf("error cleaning %s %s disk throttle: %w", throttle.mode, throttle.unit, err)
+		}
 	}
 
-	// clean write throttle
-	i.config.Log.Infow("cleaning disk write throttle", "device", i.config.Informer.Source())
+	// clean latency target
+	if i.spec.Throttling.LatencyTarget != "" && i.config.Cgroup.IsCgroupV2() {
+		i.config.Log.Infow("cleaning disk latency target", "device", i.config.Informer.Source())
 
-	if err := i.config.Cgroup.Write(diskPressureBlkioControllerName, i.getThrottleFilename(diskPressureThrottleModeWrite), i.formatThrottle(0, diskPressureThrottleModeWrite)); err != nil {
-		return fmt.Errorf("error cleaning write disk throttle: %w", err)
+		if err := i.config.Cgroup.Write(diskPressureBlkioControllerName, diskPressureLatencyFilename, i.formatLatencyTarget(0)); err != nil {
+			return fmt.Errorf("error cleaning disk latency target: %w", err)
+		}
 	}
 
 	return nil
 }
 
+// JournalChanges returns the throttle files of the target and the values removing the throttles,
+// following the cleanup logic
+func (i *diskPressureInjector) JournalChanges() []JournalChange {
+	changes := []JournalChange{}
+
+	for _, throttle := range i.getThrottles() {
+		if throttle.unit == diskPressureThrottleUnitIOPS && throttle.value == nil {
+			continue
+		}
+
+		changes = append(changes, JournalChange{
+			Type:       JournalChangeCgroupFile,
+			Controller: diskPressureBlkioControllerName,
+			File:       i.getThrottleFilename(throttle.mode, throttle.unit),
+			Value:      i.formatThrottle(0, throttle.mode, throttle.unit),
+		})
+	}
+
+	if i.spec.Throttling.LatencyTarget != "" && i.config.Cgroup.IsCgroupV2() {
+		changes = append(changes, JournalChange{
+			Type:       JournalChangeCgroupFile,
+			Controller: diskPressureBlkioControllerName,
+			File:       diskPressureLatencyFilename,
+			Value:      i.formatLatencyTarget(0),
+		})
+	}
+
+	return changes
+}
+
+// Verify checks the throttles and the latency target written in the blkio cgroup controller are still in effect
+func (i *diskPressureInjector) Verify() error {
+	i.config.Log.Debugw("verifying disk throttles", "device", i.config.Informer.Source())
+
+	for _, throttle := range i.getThrottles() {
+		if throttle.value == nil {
+			continue
+		}
+
+		if err := i.verifyCgroupFile(i.getThrottleFilename(throttle.mode, throttle.unit), i.formatThrottle(*throttle.value, throttle.mode, throttle.unit)); err != nil {
+			return fmt.Errorf("disk %s %s throttle is not in effect anymore: %w", throttle.mode, throttle.unit, err)
+		}
+	}
+
+	if i.spec.Throttling.LatencyTarget != "" && i.config.Cgroup.IsCgroupV2() {
+		if err := i.verifyCgroupFile(diskPressureLatencyFilename, i.formatLatencyTarget(i.spec.Throttling.LatencyTarget.Duration())); err != nil {
+			return fmt.Errorf("disk latency target is not in effect anymore: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// verifyCgroupFile ensures the given blkio cgroup file still holds the settings of the given written data,
+// the kernel listing one line per device with all its settings (example: 8:0 rbps=1024 wbps=max riops=max wiops=max)
+func (i *diskPressureInjector) verifyCgroupFile(filename string, written string) error {
+	content, err := i.config.Cgroup.Read(diskPressureBlkioControllerName, filename)
+	if err != nil {
+		return fmt.Errorf("error reading %s: %w", filename, err)
+	}
+
+	// the written data is the device followed by its settings
+	expected := strings.Fields(written)
+
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || fields[0] != expected[0] {
+			continue
+		}
+
+		settings := map[string]struct{}{}
+		for _, setting := range fields[1:] {
+			settings[setting] = struct{}{}
+		}
+
+		for _, setting := range expected[1:] {
+			if _, found := settings[setting]; !found {
+				return fmt.Errorf("%s does not contain %s for device %s", filename, setting, expected[0])
+			}
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("%s does not contain any setting for device %s", filename, expected[0])
+}
+
+// getThrottles returns the throttles of the spec, in the order they are applied
+func (i *diskPressureInjector) getThrottles() []diskPressureThrottle {
+	return []diskPressureThrottle{
+		{mode: diskPressureThrottleModeRead, unit: diskPressureThrottleUnitBPS, value: i.spec.Throttling.ReadBytesPerSec},
+		{mode: diskPressureThrottleModeWrite, unit: diskPressureThrottleUnitBPS, value: i.spec.Throttling.WriteBytesPerSec},
+		{mode: diskPressureThrottleModeRead, unit: diskPressureThrottleUnitIOPS, value: i.spec.Throttling.ReadIOPS},
+		{mode: diskPressureThrottleModeWrite, unit: diskPressureThrottleUnitIOPS, value: i.spec.Throttling.WriteIOPS},
+	}
+}
+
 // formatThrottle formats the throttle data to write to the IO throttling cgroup file
 // depending on the cgroup version
-func (i *diskPressureInjector) formatThrottle(throttle int, mode diskPressureThrottleMode) string {
+func (i *diskPressureInjector) formatThrottle(throttle int, mode diskPressureThrottleMode, unit diskPressureThrottleUnit) string {
 	// cgroups v2 io.max file used for throttling expects a slightly different data format
 	// example: 252:0 rbps=1024 wbps=max
 	if i.config.Cgroup.IsCgroupV2() {
@@ -143,25 +275,39 @@ func (i *diskPressureInjector) formatThrottle(throttle int, mode diskPressureThr
 		}
 
 		// the file can be used to configure both read and write throttling (both iops and bps too)
-		// to set that value, it is now a key/value pair (rbps for read throttling, wbps for write throttling)
+		// to set that value, it is now a key/value pair (rbps/riops for read throttling, wbps/wiops for write throttling)
+		key := ""
+
 		switch mode {
 		case diskPressureThrottleModeRead:
-			return fmt.Sprintf("%d:0 rbps=%s", i.config.Informer.Major(), sThrottle)
+			key = "r"
 		case diskPressureThrottleModeWrite:
-			return fmt.Sprintf("%d:0 wbps=%s", i.config.Informer.Major(), sThrottle)
+			key = "w"
 		default:
 			return "" // should never be used
 		}
+
+		return fmt.Sprintf("%d:0 %s%s=%s", i.config.Informer.Major(), key, unit, sThrottle)
 	}
 
-	// cgroups v1 throttling format is much simple and only takes the bps value
+	// cgroups v1 throttling format is much simple and only takes the bps or iops value
 	// example: 252:0 1024
 	return fmt.Sprintf("%d:0 %d", i.config.Informer.Major(), throttle)
 }
 
+// formatLatencyTarget formats the latency target data to write to the cgroups v2 io.latency file
+// example: 252:0 target=10000 (in microseconds), resetting the target being done by setting it to "max"
+func (i *diskPressureInjector) formatLatencyTarget(target time.Duration) string {
+	if target == 0 {
+		return fmt.Sprintf("%d:0 target=max", i.config.Informer.Major())
+	}
+
+	return fmt.Sprintf("%d:0 target=%d", i.config.Informer.Major(), target.Microseconds())
+}
+
 // getThrottleFilename returns the filename to use to write IO read/write throttling data to
 // depending on the cgroup version
-func (i *diskPressureInjector) getThrottleFilename(mode diskPressureThrottleMode) string {
+func (i *diskPressureInjector) getThrottleFilename(mode diskPressureThrottleMode, unit diskPressureThrottleUnit) string {
 	// cgroups v2 uses a single file to handle all kind of IO throttling
 	// read and write, iops and bps
 	if i.config.Cgroup.IsCgroupV2() {
@@ -171,14 +317,36 @@ func (i *diskPressureInjector) getThrottleFilename(mode diskPressureThrottleMode
 	// cgroups v1 uses separate files for both the mode and the unit
 	// - read and bps
 	// - write and bps
-	// - read and iops (unused here)
-	// - write and iops (unused here)
+	// - read and iops
+	// - write and iops
 	switch mode {
 	case diskPressureThrottleModeRead:
-		return "blkio.throttle.read_bps_device"
+		return fmt.Sprintf("blkio.throttle.read_%s_device", unit)
 	case diskPressureThrottleModeWrite:
-		return "blkio.throttle.write_bps_device"
+		return fmt.Sprintf("blkio.throttle.write_%s_device", unit)
 	}
 
 	return "" // should never be used
 }
+
+func (m diskPressureThrottleMode) String() string {
+	switch m {
+	case diskPressureThrottleModeRead:
+		return "read"
+	case diskPressureThrottleModeWrite:
+		return "write"
+	}
+
+	return ""
+}
+
+func (u diskPressureThrottleUnit) String() string {
+	switch u {
+	case diskPressureThrottleUnitBPS:
+		return "bps"
+	case diskPressureThrottleUnitIOPS:
+		return "iops"
+	}
+
+	return ""
+}
@@ -103,6 +103,59 @@ var _ = Describe("Failure", func() {
 				cgroupManager.AssertCalled(GinkgoT(), "Write", "blkio", "io.max", "8:0 wbps=4096")
 			})
 		})
+
+		Context("with iops throttling", func() {
+			BeforeEach(func() {
+				readIOPS := 100
+				writeIOPS := 200
+				spec.Throttling.ReadIOPS = &readIOPS
+				spec.Throttling.WriteIOPS = &writeIOPS
+			})
+
+			Context("with cgroups v1", func() {
+				BeforeEach(func() {
+					cgroupManager.EXPECT().IsCgroupV2().Return(false)
+				})
+
+				It("should throttle disk iops from cgroup", func() {
+					cgroupManager.AssertCalled(GinkgoT(), "Write", "blkio", "blkio.throttle.read_iops_device", "8:0 100")
+					cgroupManager.AssertCalled(GinkgoT(), "Write", "blkio", "blkio.throttle.write_iops_device", "8:0 200")
+				})
+			})
+
+			Context("with cgroups v2", func() {
+				BeforeEach(func() {
+					cgroupManager.EXPECT().IsCgroupV2().Return(true)
+				})
+
+				It("should throttle disk iops from cgroup", func() {
+					cgroupManager.AssertCalled(GinkgoT(), "Write", "blkio", "io.max", "8:0 riops=100")
+					cgroupManager.AssertCalled(GinkgoT(), "Write", "blkio", "io.max", "8:0 wiops=200")
+				})
+			})
+		})
+
+		Context("with a latency target on cgroups v2", func() {
+			BeforeEach(func() {
+				spec.Throttling.LatencyTarget = "10ms"
+				cgroupManager.EXPECT().IsCgroupV2().Return(true)
+			})
+
+			It("should set the latency target from cgroup", func() {
+				cgroupManager.AssertCalled(GinkgoT(), "Write", "blkio", "io.latency", "8:0 target=10000")
+			})
+		})
+	})
+
+	Describe("injection with a latency target on cgroups v1", func() {
+		BeforeEach(func() {
+			spec.Throttling.LatencyTarget = "10ms"
+			cgroupManager.EXPECT().IsCgroupV2().Return(false)
+		})
+
+		It("should fail", func() {
+			Expect(inj.Inject()).ToNot(Succeed())
+		})
 	})
 
 	Describe("clean", func() {
@@ -131,5 +184,85 @@ var _ = Describe("Failure", func() {
 				cgroupManager.AssertCalled(GinkgoT(), "Write", "blkio", "io.max", "8:0 wbps=max")
 			})
 		})
+
+		Context("with iops throttling and a latency target on cgroups v2", func() {
+			BeforeEach(func() {
+				readIOPS := 100
+				spec.Throttling.ReadIOPS = &readIOPS
+				spec.Throttling.LatencyTarget = "10ms"
+				cgroupManager.EXPECT().IsCgroupV2().Return(true)
+			})
+
+			It("should remove iops throttle and latency target from cgroup", func() {
+				cgroupManager.AssertCalled(GinkgoT(), "Write", "blkio", "io.max", "8:0 riops=max")
+				cgroupManager.AssertNotCalled(GinkgoT(), "Write", "blkio", "io.max", "8:0 wiops=max")
+				cgroupManager.AssertCalled(GinkgoT(), "Write", "blkio", "io.latency", "8:0 target=max")
+			})
+		})
+	})
+
+	Describe("verification", func() {
+		var verifyErr error
+
+		JustBeforeEach(func() {
+			Expect(inj.Inject()).To(Succeed())
+
+			verifyErr = inj.(Verifier).Verify()
+		})
+
+		Context("with cgroups v1", func() {
+			BeforeEach(func() {
+				cgroupManager.EXPECT().IsCgroupV2().Return(false)
+				cgroupManager.EXPECT().Read("blkio", "blkio.throttle.read_bps_device").Return("8:0 1024\n", nil)
+			})
+
+			Context("with the throttles in place", func() {
+				BeforeEach(func() {
+					cgroupManager.EXPECT().Read("blkio", "blkio.throttle.write_bps_device").Return("8:0 4096\n", nil)
+				})
+
+				It("should succeed", func() {
+					Expect(verifyErr).ToNot(HaveOccurred())
+				})
+			})
+
+			Context("with a throttle removed", func() {
+				BeforeEach(func() {
+					cgroupManager.EXPECT().Read("blkio", "blkio.throttle.write_bps_device").Return("", nil)
+				})
+
+				It("should fail", func() {
+					Expect(verifyErr).To(HaveOccurred())
+				})
+			})
+		})
+
+		Context("with cgroups v2", func() {
+			BeforeEach(func() {
+				spec.Throttling.LatencyTarget = "10ms"
+				cgroupManager.EXPECT().IsCgroupV2().Return(true)
+				cgroupManager.EXPECT().Read("blkio", "io.max").Return("8:0 rbps=1024 wbps=4096 riops=max wiops=max\n", nil)
+			})
+
+			Context("with the throttles and the latency target in place", func() {
+				BeforeEach(func() {
+					cgroupManager.EXPECT().Read("blkio", "io.latency").Return("8:0 target=10000\n", nil)
+				})
+
+				It("should succeed", func() {
+					Expect(verifyErr).ToNot(HaveOccurred())
+				})
+			})
+
+			Context("with the latency target reset", func() {
+				BeforeEach(func() {
+					cgroupManager.EXPECT().Read("blkio", "io.latency").Return("8:0 target=max\n", nil)
+				})
+
+				It("should fail", func() {
+					Expect(verifyErr).To(HaveOccurred())
+				})
+			})
+		})
 	})
 })
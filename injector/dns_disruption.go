@@ -197,6 +197,27 @@ func (i *DNSDisruptionInjector) UpdateConfig(config Config) {
 	i.config.Config = config
 }
 
+// Verify checks the injected iptables rules redirecting the dns requests still exist in the target network namespace
+func (i *DNSDisruptionInjector) Verify() (err error) {
+	// enter target network namespace
+	if err := i.config.Netns.Enter(); err != nil {
+		return fmt.Errorf("unable to enter the given container network namespace: %w", err)
+	}
+
+	// exit target network namespace whatever the verification result is
+	defer func() {
+		if exitErr := i.config.Netns.Exit(); exitErr != nil && err == nil {
+			err = fmt.Errorf("unable to exit the given container network namespace: %w", exitErr)
+		}
+	}()
+
+	if err := i.config.IPTables.Verify(); err != nil {
+		return fmt.Errorf("error verifying iptables rules: %w", err)
+	}
+
+	return nil
+}
+
 // Clean removes the injected disruption from the given container
 func (i *DNSDisruptionInjector) Clean() error {
 	// enter target network namespace
@@ -229,3 +250,17 @@ func (i *DNSDisruptionInjector) Clean() error {
 	// There is nothing we need to do to shut down the resolver beyond letting the pod terminate
 	return nil
 }
+
+// JournalChanges returns the iptables rules injected in the target network namespace
+// and the net_cls classid of the target with cgroups v1
+func (i *DNSDisruptionInjector) JournalChanges() []JournalChange {
+	changes := []JournalChange{
+		{Type: JournalChangeIPTables, Rules: i.config.IPTables.Rules()},
+	}
+
+	if !i.config.Cgroup.IsCgroupV2() {
+		changes = append(changes, JournalChange{Type: JournalChangeCgroupFile, Controller: "net_cls", File: "net_cls.classid", Value: "0"})
+	}
+
+	return changes
+}
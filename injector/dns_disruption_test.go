@@ -388,4 +388,42 @@ var _ = Describe("DNS Failure", func() {
 			})
 		})
 	})
+
+	Describe("inj.Verify", func() {
+		var verifyErr error
+
+		JustBeforeEach(func() {
+			verifyErr = inj.(Verifier).Verify()
+		})
+
+		Context("with the iptables rules in place", func() {
+			BeforeEach(func() {
+				iptables.EXPECT().Verify().Return(nil)
+			})
+
+			It("should not return an error", func() {
+				Expect(verifyErr).ToNot(HaveOccurred())
+			})
+
+			It("should enter/exit the target network namespace", func() {
+				netnsManager.AssertNumberOfCalls(GinkgoT(), "Enter", 1)
+				netnsManager.AssertNumberOfCalls(GinkgoT(), "Exit", 1)
+			})
+		})
+
+		Context("with an iptables rule removed", func() {
+			BeforeEach(func() {
+				iptables.EXPECT().Verify().Return(errors.New("message"))
+			})
+
+			It("should return an error", func() {
+				Expect(verifyErr).Should(HaveOccurred())
+				Expect(verifyErr.Error()).Should(Equal("error verifying iptables rules: message"))
+			})
+
+			It("should still exit the target network namespace", func() {
+				netnsManager.AssertNumberOfCalls(GinkgoT(), "Exit", 1)
+			})
+		})
+	})
 })
@@ -36,6 +36,19 @@ type Injector interface {
 	Clean() error
 }
 
+// Verifier is an optional interface of injectors being able to read back the state they injected,
+// allowing to detect an injection silently disappearing (e.g. a tc qdisc or an iptables rule being removed)
+type Verifier interface {
+	// Verify returns an error if the injected disruption is not in effect anymore
+	Verify() error
+}
+
+// HostsResolver is an optional interface of injectors resolving hosts, exposing the IPs they resolved
+type HostsResolver interface {
+	// ResolvedHosts returns the IPs resolved during the last injection, by host
+	ResolvedHosts() map[string][]string
+}
+
 // Config represents a generic injector config
 type Config struct {
 	Log                *zap.SugaredLogger
@@ -0,0 +1,288 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package injector
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/DataDog/chaos-controller/network"
+	"github.com/DataDog/chaos-controller/types"
+)
+
+// JournalDirectory is the default directory of the journal on the host, /run being cleared on reboot
+// just like the kernel state recorded in the journal
+const JournalDirectory = "/run/chaos-controller/journal"
+
+// JournalChangeType is the type of a change applied by an injector to its target
+type JournalChangeType string
+
+const (
+	// JournalChangeTrafficControl is a tc tree attached to the root of the interfaces of the target network namespace
+	JournalChangeTrafficControl JournalChangeType = "tc"
+	// JournalChangeIPTables is a set of iptables rules injected in the target network namespace
+	JournalChangeIPTables JournalChangeType = "iptables"
+	// JournalChangeCgroupFile is a value written to a cgroup file of the target
+	JournalChangeCgroupFile JournalChangeType = "cgroup-file"
+	// JournalChangeService is a node service stopped by the injector, started again on revert
+	JournalChangeService JournalChangeType = "service"
+	// JournalChangeProcess is a set of processes stopped (SIGSTOP) by the injector, resumed on revert
+	JournalChangeProcess JournalChangeType = "process"
+	// JournalChangeNodeCordon is a node cordoned by the injector, uncordoned on revert
+	JournalChangeNodeCordon JournalChangeType = "node-cordon"
+	// JournalChangeFile is a file or directory created on the node by the injector, removed on revert
+	JournalChangeFile JournalChangeType = "file"
+)
+
+// JournalChange describes a change applied by an injector to its target and how to revert it
+type JournalChange struct {
+	Type JournalChangeType `json:"type"`
+	// HostNetwork is true when the change is applied to the node network namespace rather than the target one
+	HostNetwork bool `json:"hostNetwork,omitempty"`
+	// Interfaces are the interfaces the tc tree is attached to, the only ones cleared on revert
+	Interfaces []string `json:"interfaces,omitempty"`
+	// Rules are the injected iptables rules
+	Rules []network.Rule `json:"rules,omitempty"`
+	// Controller, File and Value are the cgroup file written by the injector and the value restoring it
+	// for a process change, Controller and File are the cgroup file listing the stopped processes, if they are
+	// limited to the target cgroup rather than to the target PID namespace
+	Controller string `json:"controller,omitempty"`
+	File       string `json:"file,omitempty"`
+	Value      string `json:"value,omitempty"`
+	// Service is the stopped node service
+	Service string `json:"service,omitempty"`
+	// Pattern matches the name of the stopped processes
+	Pattern string `json:"pattern,omitempty"`
+	// Node is the cordoned node, Value being the value of its cordoned by annotation
+	Node string `json:"node,omitempty"`
+	// Path is the created file or directory, relative to the node root filesystem
+	Path string `json:"path,omitempty"`
+}
+
+// JournalTarget identifies the process whose namespaces and cgroups the journaled changes were applied to
+type JournalTarget struct {
+	PID uint32 `json:"pid"`
+	// StartTime is the start time of the process, telling it apart from another process reusing its PID
+	StartTime uint64 `json:"startTime"`
+	// NetnsInode identifies the network namespace of the process, which can outlive it (e.g. held by the pod sandbox)
+	NetnsInode uint64 `json:"netnsInode"`
+}
+
+// JournalEntry records the changes applied by an injector of a chaos pod to a target
+type JournalEntry struct {
+	ID                  string                   `json:"id"`
+	DisruptionName      string                   `json:"disruptionName"`
+	DisruptionNamespace string                   `json:"disruptionNamespace"`
+	ChaosNamespace      string                   `json:"chaosNamespace"`
+	ChaosPodName        string                   `json:"chaosPodName"`
+	Kind                types.DisruptionKindName `json:"kind"`
+	TargetName          string                   `json:"targetName"`
+	Target              JournalTarget            `json:"target"`
+	Changes             []JournalChange          `json:"changes"`
+	RecordedAt          time.Time                `json:"recordedAt"`
+	// Injector identifies the injector process which recorded the entry, its changes being orphaned once it is gone
+	// even if its chaos pod status can't be updated anymore (e.g. with the kubelet stopped)
+	Injector *JournalTarget `json:"injector,omitempty"`
+}
+
+// Journaled is an optional interface of injectors applying changes to their target (tc, iptables, cgroups, stopped
+// services or processes, cordoned nodes, created files), which would be left in place if the injector was killed before cleaning them
+type Journaled interface {
+	// JournalChanges returns the changes applied, or about to be applied, by the injector
+	JournalChanges() []JournalChange
+}
+
+// Journal durably records the changes applied by the injectors of a node, so they can be reverted
+// by the sweeper if the chaos pod dies before cleaning them
+type Journal interface {
+	// Record creates or replaces the given entry
+	Record(entry JournalEntry) error
+	// Remove removes the entry with the given ID, if any
+	Remove(id string) error
+	// List returns all the recorded entries
+	List() ([]JournalEntry, error)
+}
+
+type fileJournal struct {
+	dir string
+}
+
+// NewFileJournal creates a journal storing one file per entry in the given directory
+func NewFileJournal(dir string) (Journal, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("error creating the journal directory %s: %w", dir, err)
+	}
+
+	return fileJournal{dir: dir}, nil
+}
+
+func (j fileJournal) Record(entry JournalEntry) error {
+	content, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("error encoding journal entry %s: %w", entry.ID, err)
+	}
+
+	// write a temporary file first and rename it so an entry is never partially written
+	tmp := j.path(entry.ID) + ".tmp"
+
+	if err := os.WriteFile(tmp, content, 0o600); err != nil {
+		return fmt.Errorf("error writing journal entry %s: %w", entry.ID, err)
+	}
+
+	if err := os.Rename(tmp, j.path(entry.ID)); err != nil {
+		return fmt.Errorf("error writing journal entry %s: %w", entry.ID, err)
+	}
+
+	return nil
+}
+
+func (j fileJournal) Remove(id string) error {
+	if err := os.Remove(j.path(id)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("error removing journal entry %s: %w", id, err)
+	}
+
+	return nil
+}
+
+func (j fileJournal) List() ([]JournalEntry, error) {
+	files, err := filepath.Glob(filepath.Join(j.dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("error listing journal entries: %w", err)
+	}
+
+	entries := []JournalEntry{}
+
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("error reading journal entry %s: %w", file, err)
+		}
+
+		entry := JournalEntry{}
+		if err := json.Unmarshal(content, &entry); err != nil {
+			return nil, fmt.Errorf("error decoding journal entry %s: %w", file, err)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+func (j fileJournal) path(id string) string {
+	return filepath.Join(j.dir, id+".json")
+}
+
+type noopJournal struct{}
+
+// NewNoopJournal creates a journal recording nothing, used when the journal is disabled
+func NewNoopJournal() Journal {
+	return noopJournal{}
+}
+
+func (noopJournal) Record(JournalEntry) error {
+	return nil
+}
+
+func (noopJournal) Remove(string) error {
+	return nil
+}
+
+func (noopJournal) List() ([]JournalEntry, error) {
+	return []JournalEntry{}, nil
+}
+
+// NewJournalTarget identifies the process with the given PID in the given procfs
+func NewJournalTarget(procPath string, pid uint32) (JournalTarget, error) {
+	startTime, err := processStartTime(procPath, pid)
+	if err != nil {
+		return JournalTarget{}, err
+	}
+
+	inode, err := netnsInode(procPath, pid)
+	if err != nil {
+		return JournalTarget{}, err
+	}
+
+	return JournalTarget{
+		PID:        pid,
+		StartTime:  startTime,
+		NetnsInode: inode,
+	}, nil
+}
+
+// Alive returns true if the target process is still running in the given procfs
+func (t JournalTarget) Alive(procPath string) bool {
+	startTime, err := processStartTime(procPath, t.PID)
+
+	return err == nil && startTime == t.StartTime
+}
+
+// NetnsPID returns the PID of a process of the given procfs living in the target network namespace,
+// false if the network namespace does not exist anymore
+func (t JournalTarget) NetnsPID(procPath string) (uint32, bool) {
+	if inode, err := netnsInode(procPath, t.PID); err == nil && inode == t.NetnsInode {
+		return t.PID, true
+	}
+
+	dirs, err := os.ReadDir(procPath)
+	if err != nil {
+		return 0, false
+	}
+
+	for _, dir := range dirs {
+		pid, err := strconv.ParseUint(dir.Name(), 10, 32)
+		if err != nil {
+			continue
+		}
+
+		if inode, err := netnsInode(procPath, uint32(pid)); err == nil && inode == t.NetnsInode {
+			return uint32(pid), true
+		}
+	}
+
+	return 0, false
+}
+
+// processStartTime returns the start time of the given process, in clock ticks since the boot
+func processStartTime(procPath string, pid uint32) (uint64, error) {
+	content, err := os.ReadFile(filepath.Join(procPath, strconv.FormatUint(uint64(pid), 10), "stat"))
+	if err != nil {
+		return 0, fmt.Errorf("error reading the status of process %d: %w", pid, err)
+	}
+
+	// the process name can contain spaces and parenthesis, the start time is the 20th field following it
+	stat := string(content)
+	fields := strings.Fields(stat[strings.LastIndex(stat, ")")+1:])
+
+	if len(fields) < 20 {
+		return 0, fmt.Errorf("unexpected status format for process %d", pid)
+	}
+
+	return strconv.ParseUint(fields[19], 10, 64)
+}
+
+// netnsInode returns the inode of the network namespace of the given process
+func netnsInode(procPath string, pid uint32) (uint64, error) {
+	info, err := os.Stat(filepath.Join(procPath, strconv.FormatUint(uint64(pid), 10), "ns", "net"))
+	if err != nil {
+		return 0, fmt.Errorf("error reading the network namespace of process %d: %w", pid, err)
+	}
+
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0, fmt.Errorf("unable to read the network namespace inode of process %d", pid)
+	}
+
+	return stat.Ino, nil
+}
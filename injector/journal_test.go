@@ -0,0 +1,124 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package injector_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/DataDog/chaos-controller/injector"
+	"github.com/DataDog/chaos-controller/network"
+	"github.com/DataDog/chaos-controller/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("File journal", func() {
+	var (
+		dir     string
+		journal Journal
+		entry   JournalEntry
+	)
+
+	BeforeEach(func() {
+		var err error
+
+		dir = filepath.Join(GinkgoT().TempDir(), "journal")
+		journal, err = NewFileJournal(dir)
+		Expect(err).ToNot(HaveOccurred())
+
+		entry = JournalEntry{
+			ID:                  "chaos-pod-0",
+			DisruptionName:      "disruption",
+			DisruptionNamespace: "namespace",
+			ChaosNamespace:      "chaos-engineering",
+			ChaosPodName:        "chaos-pod",
+			Kind:                types.DisruptionKindNetworkDisruption,
+			TargetName:          "target",
+			Target:              JournalTarget{PID: 42, StartTime: 1000, NetnsInode: 4026531840},
+			Changes: []JournalChange{
+				{Type: JournalChangeTrafficControl, Interfaces: []string{"lo", "eth0"}},
+				{Type: JournalChangeIPTables, Rules: []network.Rule{{Table: "nat", Chain: "OUTPUT", Rulespec: []string{"-j", "CHAOS-DNS"}}}},
+			},
+			RecordedAt: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+		}
+	})
+
+	It("should create the journal directory", func() {
+		Expect(dir).To(BeADirectory())
+	})
+
+	It("should list the recorded entries", func() {
+		Expect(journal.Record(entry)).To(Succeed())
+		Expect(journal.List()).To(Equal([]JournalEntry{entry}))
+	})
+
+	It("should replace an entry recorded again", func() {
+		Expect(journal.Record(entry)).To(Succeed())
+
+		entry.Changes = []JournalChange{{Type: JournalChangeTrafficControl}}
+		Expect(journal.Record(entry)).To(Succeed())
+
+		Expect(journal.List()).To(Equal([]JournalEntry{entry}))
+	})
+
+	It("should not list removed entries", func() {
+		Expect(journal.Record(entry)).To(Succeed())
+		Expect(journal.Remove(entry.ID)).To(Succeed())
+		Expect(journal.List()).To(BeEmpty())
+	})
+
+	It("should not fail to remove an entry which does not exist", func() {
+		Expect(journal.Remove("unknown")).To(Succeed())
+	})
+
+	It("should return an error on a corrupted entry", func() {
+		Expect(os.WriteFile(filepath.Join(dir, "corrupted.json"), []byte("{"), 0o600)).To(Succeed())
+
+		_, err := journal.List()
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("Journal target", func() {
+	It("should identify the current process", func() {
+		target, err := NewJournalTarget("/proc", uint32(os.Getpid()))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(target.StartTime).ToNot(BeZero())
+		Expect(target.NetnsInode).ToNot(BeZero())
+
+		Expect(target.Alive("/proc")).To(BeTrue())
+
+		pid, found := target.NetnsPID("/proc")
+		Expect(found).To(BeTrue())
+		Expect(pid).To(Equal(uint32(os.Getpid())))
+	})
+
+	It("should not consider a process with a reused PID alive", func() {
+		target, err := NewJournalTarget("/proc", uint32(os.Getpid()))
+		Expect(err).ToNot(HaveOccurred())
+
+		target.StartTime++
+		Expect(target.Alive("/proc")).To(BeFalse())
+	})
+
+	It("should find another process in the network namespace of a gone process", func() {
+		target, err := NewJournalTarget("/proc", uint32(os.Getpid()))
+		Expect(err).ToNot(HaveOccurred())
+
+		target.PID = 0
+
+		pid, found := target.NetnsPID("/proc")
+		Expect(found).To(BeTrue())
+		Expect(pid).ToNot(BeZero())
+	})
+
+	It("should not find a network namespace which does not exist anymore", func() {
+		_, found := JournalTarget{PID: 0, NetnsInode: 1}.NetnsPID("/proc")
+		Expect(found).To(BeFalse())
+	})
+})
@@ -0,0 +1,232 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package injector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/DataDog/chaos-controller/api/v1beta1"
+	"github.com/DataDog/chaos-controller/env"
+	"github.com/DataDog/chaos-controller/process"
+	"github.com/DataDog/chaos-controller/types"
+	"github.com/hashicorp/go-multierror"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	kubeletServiceName = "kubelet"
+	// kubeletHostPID is the PID of the node init process, the kubelet living in its PID namespace
+	kubeletHostPID = 1
+)
+
+// kubeletProcessPattern matches the name of the kubelet processes
+var kubeletProcessPattern = regexp.MustCompile("^kubelet$")
+
+// kubeletFailureInjector describes a kubelet failure injector
+type kubeletFailureInjector struct {
+	spec   v1beta1.NodeFailureKubeletSpec
+	config KubeletFailureInjectorConfig
+	stop   chan struct{}
+	wg     sync.WaitGroup
+}
+
+// KubeletFailureInjectorConfig contains needed drivers to
+// create a KubeletFailureInjector
+type KubeletFailureInjectorConfig struct {
+	Config
+	ProcessManager process.Manager
+	ProcessFinder  process.Finder
+	ServiceManager NodeServiceManager
+	// ChaosPodName is the name of the chaos pod running the injector, watched to restore the kubelet
+	// as soon as the chaos pod is deleted, the kubelet being unable to terminate it while disrupted
+	ChaosPodName string
+	// ChaosPodPollInterval is the interval at which the chaos pod is watched
+	ChaosPodPollInterval time.Duration
+}
+
+// NewKubeletFailureInjector creates a KubeletFailureInjector object with the given config,
+// missing fields being initialized with the defaults
+func NewKubeletFailureInjector(spec v1beta1.NodeFailureKubeletSpec, config KubeletFailureInjectorConfig) (Injector, error) {
+	if config.ProcessManager == nil {
+		config.ProcessManager = process.NewManager(config.Disruption.DryRun)
+	}
+
+	if config.ProcessFinder == nil {
+		mountProc, ok := os.LookupEnv(env.InjectorMountProc)
+		if !ok {
+			return nil, fmt.Errorf("environment variable %s doesn't exist", env.InjectorMountProc)
+		}
+
+		config.ProcessFinder = process.NewFinder(mountProc)
+	}
+
+	if config.ServiceManager == nil {
+		config.ServiceManager = NewNodeServiceManager(config.Log, config.Disruption.DryRun)
+	}
+
+	if config.ChaosPodName == "" {
+		config.ChaosPodName = os.Getenv(env.InjectorPodName)
+	}
+
+	if config.ChaosPodPollInterval == 0 {
+		config.ChaosPodPollInterval = 5 * time.Second
+	}
+
+	return &kubeletFailureInjector{
+		spec:   spec,
+		config: config,
+	}, nil
+}
+
+func (i *kubeletFailureInjector) GetDisruptionKind() types.DisruptionKindName {
+	return types.DisruptionKindNodeFailure
+}
+
+// Inject freezes the kubelet processes or stops the kubelet service
+func (i *kubeletFailureInjector) Inject() error {
+	if i.spec.Action == v1beta1.NodeFailureKubeletStop {
+		i.config.Log.Infow("stopping the kubelet service", "service", kubeletServiceName)
+
+		if err := i.config.ServiceManager.Stop(kubeletServiceName); err != nil {
+			return fmt.Errorf("error stopping the kubelet: %w", err)
+		}
+	} else {
+		if err := i.signalKubelet(syscall.SIGSTOP); err != nil {
+			return fmt.Errorf("error freezing the kubelet: %w", err)
+		}
+	}
+
+	if i.stop == nil && i.config.ChaosPodName != "" && i.config.K8sClient != nil {
+		i.stop = make(chan struct{})
+		i.wg.Add(1)
+
+		go i.watchChaosPodDeletion(i.stop)
+	}
+
+	return nil
+}
+
+func (i *kubeletFailureInjector) UpdateConfig(config Config) {
+	i.config.Config = config
+}
+
+// Clean resumes the kubelet processes or starts the kubelet service,
+// even if the failure was injected by a previous injector
+func (i *kubeletFailureInjector) Clean() error {
+	if i.stop != nil {
+		close(i.stop)
+		i.wg.Wait()
+		i.stop = nil
+	}
+
+	return i.restore()
+}
+
+// JournalChanges returns the stopped kubelet service or the frozen kubelet processes of the node, the node being
+// NotReady until they are restored, which only the sweeper can do if the chaos pod is killed
+func (i *kubeletFailureInjector) JournalChanges() []JournalChange {
+	if i.spec.Action == v1beta1.NodeFailureKubeletStop {
+		return []JournalChange{
+			{Type: JournalChangeService, Service: kubeletServiceName},
+		}
+	}
+
+	return []JournalChange{
+		{Type: JournalChangeProcess, Pattern: kubeletProcessPattern.String()},
+	}
+}
+
+// restore resumes the kubelet processes or starts the kubelet service
+func (i *kubeletFailureInjector) restore() error {
+	if i.spec.Action == v1beta1.NodeFailureKubeletStop {
+		i.config.Log.Infow("starting the kubelet service", "service", kubeletServiceName)
+
+		if err := i.config.ServiceManager.Start(kubeletServiceName); err != nil {
+			return fmt.Errorf("error starting the kubelet: %w", err)
+		}
+
+		return nil
+	}
+
+	if err := i.signalKubelet(syscall.SIGCONT); err != nil {
+		return fmt.Errorf("error resuming the kubelet: %w", err)
+	}
+
+	return nil
+}
+
+// signalKubelet sends the given signal to all the kubelet processes of the node
+func (i *kubeletFailureInjector) signalKubelet(signal syscall.Signal) (retErr error) {
+	processes, err := i.config.ProcessFinder.FindInNamespace(kubeletHostPID, kubeletProcessPattern)
+	if err != nil {
+		return fmt.Errorf("error while finding the kubelet processes: %w", err)
+	}
+
+	if len(processes) == 0 {
+		return errors.New("no kubelet process found on the node")
+	}
+
+	for _, info := range processes {
+		proc, err := i.config.ProcessManager.Find(info.PID)
+		if err != nil {
+			retErr = multierror.Append(retErr, fmt.Errorf("error while finding the kubelet process %d: %w", info.PID, err))
+
+			continue
+		}
+
+		i.config.Log.Infow("sending a signal to the kubelet", "signal", signal, "pid", info.PID)
+
+		if err := i.config.ProcessManager.Signal(proc, signal); err != nil && !errors.Is(err, os.ErrProcessDone) {
+			retErr = multierror.Append(retErr, fmt.Errorf("error while sending the %s signal to the kubelet process %d: %w", signal, info.PID, err))
+		}
+	}
+
+	return retErr
+}
+
+// watchChaosPodDeletion restores the kubelet as soon as the chaos pod is being deleted,
+// so the kubelet can then terminate it and let the injector clean the disruption as usual
+func (i *kubeletFailureInjector) watchChaosPodDeletion(stop <-chan struct{}) {
+	defer i.wg.Done()
+
+	ticker := time.NewTicker(i.config.ChaosPodPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			pod, err := i.config.K8sClient.CoreV1().Pods(i.config.Disruption.ChaosNamespace).Get(context.Background(), i.config.ChaosPodName, metav1.GetOptions{})
+			if err != nil {
+				i.config.Log.Warnw("error getting the chaos pod, the kubelet will be restored at the end of the disruption", "error", err, "pod", i.config.ChaosPodName)
+
+				continue
+			}
+
+			if pod.DeletionTimestamp.IsZero() {
+				continue
+			}
+
+			i.config.Log.Infow("the chaos pod is being deleted, restoring the kubelet", "pod", i.config.ChaosPodName)
+
+			if err := i.restore(); err != nil {
+				i.config.Log.Errorw("error restoring the kubelet", "error", err)
+
+				continue
+			}
+
+			return
+		}
+	}
+}
@@ -0,0 +1,174 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package injector_test
+
+import (
+	"os"
+	"syscall"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	chaosapi "github.com/DataDog/chaos-controller/api"
+	"github.com/DataDog/chaos-controller/api/v1beta1"
+	. "github.com/DataDog/chaos-controller/injector"
+	"github.com/DataDog/chaos-controller/process"
+)
+
+var _ = Describe("Kubelet failure", func() {
+	var (
+		config         KubeletFailureInjectorConfig
+		manager        *process.ManagerMock
+		finder         *process.FinderMock
+		serviceManager *NodeServiceManagerMock
+		kubelet        *os.Process
+		inj            Injector
+		spec           v1beta1.NodeFailureKubeletSpec
+	)
+
+	BeforeEach(func() {
+		kubelet = &os.Process{Pid: 1234}
+
+		finder = process.NewFinderMock(GinkgoT())
+		manager = process.NewManagerMock(GinkgoT())
+		serviceManager = NewNodeServiceManagerMock(GinkgoT())
+
+		config = KubeletFailureInjectorConfig{
+			Config: Config{
+				Log:         log,
+				MetricsSink: ms,
+			},
+			ProcessManager: manager,
+			ProcessFinder:  finder,
+			ServiceManager: serviceManager,
+		}
+
+		spec = v1beta1.NodeFailureKubeletSpec{}
+	})
+
+	JustBeforeEach(func() {
+		var err error
+
+		inj, err = NewKubeletFailureInjector(spec, config)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Context("freezing the kubelet", func() {
+		BeforeEach(func() {
+			finder.EXPECT().FindInNamespace(1, mock.Anything).Return([]process.Info{{PID: 1234, Name: "kubelet"}}, nil)
+			manager.EXPECT().Find(1234).Return(kubelet, nil)
+		})
+
+		It("should pause the kubelet processes and resume them on clean", func() {
+			manager.EXPECT().Signal(kubelet, syscall.SIGSTOP).Return(nil).Once()
+			Expect(inj.Inject()).To(Succeed())
+
+			manager.EXPECT().Signal(kubelet, syscall.SIGCONT).Return(nil).Once()
+			Expect(inj.Clean()).To(Succeed())
+		})
+
+		It("should journal the kubelet processes to resume", func() {
+			manager.EXPECT().Signal(kubelet, syscall.SIGSTOP).Return(nil).Once()
+			Expect(inj.Inject()).To(Succeed())
+			Expect(inj.(Journaled).JournalChanges()).To(Equal([]JournalChange{
+				{Type: JournalChangeProcess, Pattern: "^kubelet$"},
+			}))
+
+			manager.EXPECT().Signal(kubelet, syscall.SIGCONT).Return(nil).Once()
+			Expect(inj.Clean()).To(Succeed())
+		})
+	})
+
+	Context("without any kubelet process", func() {
+		BeforeEach(func() {
+			finder.EXPECT().FindInNamespace(1, mock.Anything).Return([]process.Info{}, nil)
+		})
+
+		It("should fail to inject", func() {
+			Expect(inj.Inject()).ToNot(Succeed())
+		})
+	})
+
+	Context("stopping the kubelet", func() {
+		BeforeEach(func() {
+			spec.Action = v1beta1.NodeFailureKubeletStop
+		})
+
+		It("should stop the kubelet service and start it on clean", func() {
+			serviceManager.EXPECT().Stop("kubelet").Return(nil).Once()
+			Expect(inj.Inject()).To(Succeed())
+
+			serviceManager.EXPECT().Start("kubelet").Return(nil).Once()
+			Expect(inj.Clean()).To(Succeed())
+		})
+
+		It("should journal the kubelet service to start", func() {
+			Expect(inj.(Journaled).JournalChanges()).To(Equal([]JournalChange{
+				{Type: JournalChangeService, Service: "kubelet"},
+			}))
+		})
+
+		Context("when the chaos pod is being deleted", func() {
+			BeforeEach(func() {
+				now := metav1.Now()
+
+				config.Disruption = chaosapi.DisruptionArgs{ChaosNamespace: "chaos-engineering"}
+				config.ChaosPodName = "chaos-pod"
+				config.ChaosPodPollInterval = 10 * time.Millisecond
+				config.K8sClient = fake.NewSimpleClientset(&corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              "chaos-pod",
+						Namespace:         "chaos-engineering",
+						DeletionTimestamp: &now,
+						Finalizers:        []string{"finalizer.chaos.datadoghq.com/chaos-pod"},
+					},
+				})
+			})
+
+			It("should start the kubelet without waiting for the clean", func() {
+				started := make(chan struct{})
+
+				serviceManager.EXPECT().Stop("kubelet").Return(nil).Once()
+				serviceManager.EXPECT().Start("kubelet").Run(func(string) { close(started) }).Return(nil).Once()
+				Expect(inj.Inject()).To(Succeed())
+				Eventually(started).Should(BeClosed())
+
+				serviceManager.EXPECT().Start("kubelet").Return(nil).Once()
+				Expect(inj.Clean()).To(Succeed())
+			})
+		})
+
+		Context("when the chaos pod is running", func() {
+			BeforeEach(func() {
+				config.Disruption = chaosapi.DisruptionArgs{ChaosNamespace: "chaos-engineering"}
+				config.ChaosPodName = "chaos-pod"
+				config.ChaosPodPollInterval = 10 * time.Millisecond
+				config.K8sClient = fake.NewSimpleClientset(&corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "chaos-pod",
+						Namespace: "chaos-engineering",
+					},
+				})
+			})
+
+			It("should keep the kubelet stopped until the clean", func() {
+				serviceManager.EXPECT().Stop("kubelet").Return(nil).Once()
+				Expect(inj.Inject()).To(Succeed())
+
+				// any unexpected start of the kubelet would fail the mock
+				time.Sleep(5 * config.ChaosPodPollInterval)
+
+				serviceManager.EXPECT().Start("kubelet").Return(nil).Once()
+				Expect(inj.Clean()).To(Succeed())
+			})
+		})
+	})
+})
@@ -45,10 +45,12 @@ func (n networkDisruptionService) String() string {
 
 // networkDisruptionInjector describes a network disruption
 type networkDisruptionInjector struct {
-	spec       v1beta1.NetworkDisruptionSpec
-	config     NetworkDisruptionInjectorConfig
-	operations []linkOperation
-	cancel     context.CancelFunc
+	spec          v1beta1.NetworkDisruptionSpec
+	config        NetworkDisruptionInjectorConfig
+	operations    []linkOperation
+	interfaces    []string // interfaces the tc tree was applied to
+	cancel        context.CancelFunc
+	resolvedHosts map[string][]string
 }
 
 // NetworkDisruptionInjectorConfig contains all needed drivers to create a network disruption using `tc`
@@ -110,9 +112,10 @@ func NewNetworkDisruptionInjector(spec v1beta1.NetworkDisruptionSpec, config Net
 	}
 
 	return &networkDisruptionInjector{
-		spec:       spec,
-		config:     config,
-		operations: []linkOperation{},
+		spec:          spec,
+		config:        config,
+		operations:    []linkOperation{},
+		resolvedHosts: map[string][]string{},
 	}, nil
 }
 
@@ -127,6 +130,8 @@ func (i *networkDisruptionInjector) Inject() error {
 		return fmt.Errorf("unable to enter the given container network namespace: %w", err)
 	}
 
+	i.resolvedHosts = map[string][]string{}
+
 	i.config.Log.Infow("adding network disruptions", "drop", i.spec.Drop, "duplicate", i.spec.Duplicate, "corrupt", i.spec.Corrupt, "delay", i.spec.Delay, "delayJitter", i.spec.DelayJitter, "bandwidthLimit", i.spec.BandwidthLimit)
 
 	// add netem
@@ -194,6 +199,11 @@ func (i *networkDisruptionInjector) Inject() error {
 	return nil
 }
 
+// ResolvedHosts returns the IPs the hosts and allowed hosts of the spec were resolved to during the last injection
+func (i *networkDisruptionInjector) ResolvedHosts() map[string][]string {
+	return i.resolvedHosts
+}
+
 func (i *networkDisruptionInjector) UpdateConfig(config Config) {
 	i.config.Config = config
 }
@@ -240,6 +250,107 @@ func (i *networkDisruptionInjector) Clean() error {
 	return nil
 }
 
+// JournalChanges returns the tc tree and the iptables rules injected in the target network namespace,
+// and the net_cls classid of the target with cgroups v1
+func (i *networkDisruptionInjector) JournalChanges() []JournalChange {
+	// the tc tree is about to be applied to all the current interfaces when it is not applied yet
+	interfaces := i.interfaces
+	if interfaces == nil {
+		interfaces = i.currentInterfaces()
+	}
+
+	changes := []JournalChange{
+		{Type: JournalChangeTrafficControl, Interfaces: interfaces},
+		{Type: JournalChangeIPTables, Rules: i.config.IPTables.Rules()},
+	}
+
+	if !i.config.Cgroup.IsCgroupV2() {
+		changes = append(changes, JournalChange{Type: JournalChangeCgroupFile, Controller: "net_cls", File: "net_cls.classid", Value: "0"})
+	}
+
+	return changes
+}
+
+// currentInterfaces returns the interfaces of the target network namespace, nil if they can't be listed
+func (i *networkDisruptionInjector) currentInterfaces() []string {
+	if err := i.config.Netns.Enter(); err != nil {
+		i.config.Log.Warnw("unable to enter the target network namespace to journal its interfaces", "error", err)
+
+		return nil
+	}
+
+	defer func() {
+		if err := i.config.Netns.Exit(); err != nil {
+			i.config.Log.Warnw("unable to exit the target network namespace", "error", err)
+		}
+	}()
+
+	links, err := i.config.NetlinkAdapter.LinkList()
+	if err != nil {
+		i.config.Log.Warnw("error listing the interfaces to journal", "error", err)
+
+		return nil
+	}
+
+	interfaces := []string{}
+	for _, link := range links {
+		interfaces = append(interfaces, link.Name())
+	}
+
+	return interfaces
+}
+
+// Verify checks the root prio qdisc of the tc tree is still present on the interfaces it was applied to
+// and the injected iptables rules still exist in the target network namespace
+func (i *networkDisruptionInjector) Verify() (err error) {
+	// enter target network namespace
+	if err := i.config.Netns.Enter(); err != nil {
+		return fmt.Errorf("unable to enter the given container network namespace: %w", err)
+	}
+
+	// exit target network namespace whatever the verification result is
+	defer func() {
+		if exitErr := i.config.Netns.Exit(); exitErr != nil && err == nil {
+			err = fmt.Errorf("unable to exit the given container network namespace: %w", exitErr)
+		}
+	}()
+
+	if len(i.interfaces) > 0 {
+		links, err := i.config.NetlinkAdapter.LinkList()
+		if err != nil {
+			return fmt.Errorf("error listing interfaces: %w", err)
+		}
+
+		// interfaces created after the injection (e.g. new pods veths at the node level) are not disrupted
+		// and the ones removed since then (e.g. deleted pods veths) have nothing left to verify
+		existingLinks := map[string]struct{}{}
+		for _, link := range links {
+			existingLinks[link.Name()] = struct{}{}
+		}
+
+		for _, iface := range i.interfaces {
+			if _, ok := existingLinks[iface]; !ok {
+				continue
+			}
+
+			qdiscs, err := i.config.TrafficController.ListQdiscs(iface)
+			if err != nil {
+				return fmt.Errorf("error listing qdiscs of interface %s: %w", iface, err)
+			}
+
+			if !strings.Contains(qdiscs, "qdisc prio 1: root") {
+				return fmt.Errorf("the root prio qdisc of the disruption does not exist anymore on interface %s", iface)
+			}
+		}
+	}
+
+	if err := i.config.IPTables.Verify(); err != nil {
+		return fmt.Errorf("error verifying iptables rules: %w", err)
+	}
+
+	return nil
+}
+
 // applyOperations applies the added operations by building a tc tree
 // Here's what happen on tc side:
 //   - a first prio qdisc will be created and attached to root
@@ -278,6 +389,8 @@ func (i *networkDisruptionInjector) applyOperations() error {
 		interfaces = append(interfaces, link.Name())
 	}
 
+	i.interfaces = interfaces
+
 	// retrieve the default route information
 	defaultRoutes, err := i.config.NetlinkAdapter.DefaultRoutes()
 	if err != nil {
@@ -897,6 +1010,13 @@ func (i *networkDisruptionInjector) addFiltersForHosts(interfaces []string, host
 
 		i.config.Log.Infof("resolved %s as %s", host.Host, ips)
 
+		// the same host can be given several times with different ports or protocols
+		if _, found := i.resolvedHosts[host.Host]; !found {
+			for _, ip := range ips {
+				i.resolvedHosts[host.Host] = append(i.resolvedHosts[host.Host], ip.String())
+			}
+		}
+
 		for _, ip := range ips {
 			var (
 				srcPort, dstPort int
@@ -970,6 +1090,7 @@ func (i *networkDisruptionInjector) clearOperations() error {
 
 	// clear operations to avoid them to stack up
 	i.operations = []linkOperation{}
+	i.interfaces = nil
 
 	return nil
 }
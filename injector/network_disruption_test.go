@@ -352,6 +352,13 @@ var _ = Describe("Failure", func() {
 				tc.AssertCalled(GinkgoT(), "AddFilter", []string{"lo", "eth0", "eth1"}, "1:0", "", nilIPNet, buildSingleIPNetUsingParse(testHostIP), 0, 80, network.TCP, network.ConnStateNew, "1:4")
 				tc.AssertCalled(GinkgoT(), "AddFilter", []string{"lo", "eth0", "eth1"}, "1:0", "", nilIPNet, buildSingleIPNetUsingParse("2.2.2.2"), 0, 443, network.TCP, network.ConnStateEstablished, "1:4")
 			})
+
+			It("should expose the IPs the hosts were resolved to", func() {
+				Expect(inj.(HostsResolver).ResolvedHosts()).To(Equal(map[string][]string{
+					testHostIP: {testHostIP + "/32"},
+					"2.2.2.2":  {"2.2.2.2/32"},
+				}))
+			})
 		})
 
 		Context("with one service specified", func() {
@@ -569,6 +576,118 @@ var _ = Describe("Failure", func() {
 			})
 		})
 	})
+	Describe("inj.Verify", func() {
+		var (
+			listQdiscsCall  *network.TrafficControllerMock_ListQdiscs_Call
+			verifyRulesCall *network.IPTablesMock_Verify_Call
+			verifyErr       error
+		)
+
+		BeforeEach(func() {
+			listQdiscsCall = tc.EXPECT().ListQdiscs(mock.Anything).Return("qdisc prio 1: root refcnt 2 bands 4 priomap 1 2 2 2 1 2 0 0 1 1 1 1 1 1 1 1\nqdisc prio 2: parent 1:4 bands 2\n", nil)
+			listQdiscsCall.Maybe()
+			verifyRulesCall = iptables.EXPECT().Verify().Return(nil)
+			verifyRulesCall.Maybe()
+		})
+
+		JustBeforeEach(func() {
+			Expect(inj.Inject()).To(Succeed())
+
+			verifyErr = inj.(Verifier).Verify()
+		})
+
+		It("should succeed while the tc tree and the iptables rules are in place", func() {
+			Expect(verifyErr).ToNot(HaveOccurred())
+			tc.AssertCalled(GinkgoT(), "ListQdiscs", "eth0")
+			netnsManager.AssertNumberOfCalls(GinkgoT(), "Exit", 2)
+		})
+
+		Context("with the root qdisc removed", func() {
+			BeforeEach(func() {
+				listQdiscsCall.Return("qdisc noqueue 0: root refcnt 2\n", nil)
+			})
+
+			It("should return an error", func() {
+				Expect(verifyErr).To(HaveOccurred())
+				netnsManager.AssertNumberOfCalls(GinkgoT(), "Exit", 2)
+			})
+		})
+
+		Context("with an interface created after the injection", func() {
+			BeforeEach(func() {
+				nllink4 := network.NewNetlinkLinkMock(GinkgoT())
+				nllink4.EXPECT().Name().Return("veth1234").Maybe()
+
+				nl.EXPECT().LinkList().Unset()
+				nl.EXPECT().LinkList().Return([]network.NetlinkLink{nllink1, nllink2, nllink3}, nil).Once()
+				nl.EXPECT().LinkList().Return([]network.NetlinkLink{nllink1, nllink2, nllink3, nllink4}, nil).Maybe()
+			})
+
+			It("should only verify the interfaces the disruption was applied to", func() {
+				Expect(verifyErr).ToNot(HaveOccurred())
+				tc.AssertNotCalled(GinkgoT(), "ListQdiscs", "veth1234")
+			})
+		})
+
+		Context("with an interface removed after the injection", func() {
+			BeforeEach(func() {
+				nl.EXPECT().LinkList().Unset()
+				nl.EXPECT().LinkList().Return([]network.NetlinkLink{nllink1, nllink2, nllink3}, nil).Once()
+				nl.EXPECT().LinkList().Return([]network.NetlinkLink{nllink1, nllink2}, nil).Maybe()
+			})
+
+			It("should not verify it anymore", func() {
+				Expect(verifyErr).ToNot(HaveOccurred())
+				tc.AssertNotCalled(GinkgoT(), "ListQdiscs", "eth1")
+			})
+		})
+
+		Context("with an iptables rule removed", func() {
+			BeforeEach(func() {
+				verifyRulesCall.Return(fmt.Errorf("rule does not exist anymore"))
+			})
+
+			It("should return an error", func() {
+				Expect(verifyErr).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("inj.JournalChanges", func() {
+		BeforeEach(func() {
+			iptables.EXPECT().Rules().Return(nil).Maybe()
+		})
+
+		tcInterfaces := func() []string {
+			for _, change := range inj.(Journaled).JournalChanges() {
+				if change.Type == JournalChangeTrafficControl {
+					return change.Interfaces
+				}
+			}
+
+			return nil
+		}
+
+		It("should journal the current interfaces before the injection", func() {
+			Expect(tcInterfaces()).To(Equal([]string{"lo", "eth0", "eth1"}))
+		})
+
+		Context("with an interface created after the injection", func() {
+			BeforeEach(func() {
+				nllink4 := network.NewNetlinkLinkMock(GinkgoT())
+				nllink4.EXPECT().Name().Return("veth1234").Maybe()
+
+				nl.EXPECT().LinkList().Unset()
+				nl.EXPECT().LinkList().Return([]network.NetlinkLink{nllink1, nllink2, nllink3}, nil).Once()
+				nl.EXPECT().LinkList().Return([]network.NetlinkLink{nllink1, nllink2, nllink3, nllink4}, nil).Maybe()
+			})
+
+			It("should only journal the interfaces the tc tree was applied to", func() {
+				Expect(inj.Inject()).To(Succeed())
+				Expect(tcInterfaces()).To(Equal([]string{"lo", "eth0", "eth1"}))
+			})
+		})
+	})
 })
 
 func buildSingleIPNet(ip string) *net.IPNet {
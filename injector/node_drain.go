@@ -0,0 +1,236 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package injector
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/DataDog/chaos-controller/api/v1beta1"
+	"github.com/DataDog/chaos-controller/types"
+	"github.com/hashicorp/go-multierror"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// NodeDrainCordonedByAnnotation is set on the nodes cordoned by a drain node failure,
+	// so they are uncordoned on cleanup even if the injector which cordoned them is gone
+	NodeDrainCordonedByAnnotation = types.GroupName + "/cordoned-by"
+	// mirrorPodAnnotation is set on the static pods, which can't be evicted
+	mirrorPodAnnotation = "kubernetes.io/config.mirror"
+)
+
+// nodeDrainInjector describes a node drain injector
+type nodeDrainInjector struct {
+	spec   v1beta1.NodeFailureDrainSpec
+	config NodeDrainInjectorConfig
+}
+
+// NodeDrainInjectorConfig contains needed drivers to
+// create a NodeDrainInjector
+type NodeDrainInjectorConfig struct {
+	Config
+}
+
+// NewNodeDrainInjector creates a NodeDrainInjector object with the given config
+func NewNodeDrainInjector(spec v1beta1.NodeFailureDrainSpec, config NodeDrainInjectorConfig) (Injector, error) {
+	if config.Disruption.TargetNodeName == "" {
+		return nil, errors.New("the target node name is required to drain the node")
+	}
+
+	return &nodeDrainInjector{
+		spec:   spec,
+		config: config,
+	}, nil
+}
+
+func (i *nodeDrainInjector) GetDisruptionKind() types.DisruptionKindName {
+	return types.DisruptionKindNodeFailure
+}
+
+// Inject cordons the node and evicts its pods
+func (i *nodeDrainInjector) Inject() error {
+	if err := i.cordon(); err != nil {
+		return err
+	}
+
+	return i.evictPods()
+}
+
+func (i *nodeDrainInjector) UpdateConfig(config Config) {
+	i.config.Config = config
+}
+
+// Clean uncordons the node if it has been cordoned by a drain node failure
+func (i *nodeDrainInjector) Clean() error {
+	node, err := i.config.K8sClient.CoreV1().Nodes().Get(context.Background(), i.config.Disruption.TargetNodeName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error getting the node: %w", err)
+	}
+
+	// the node was already cordoned before the injection, it must stay as is
+	if _, found := node.Annotations[NodeDrainCordonedByAnnotation]; !found {
+		return nil
+	}
+
+	i.config.Log.Infow("uncordoning the node", "node", node.Name)
+
+	if i.config.Disruption.DryRun {
+		return nil
+	}
+
+	return UncordonNode(i.config.K8sClient, node.Name)
+}
+
+// JournalChanges returns the node cordoned by the injector, identified by its cordoned by annotation
+func (i *nodeDrainInjector) JournalChanges() []JournalChange {
+	return []JournalChange{
+		{Type: JournalChangeNodeCordon, Node: i.config.Disruption.TargetNodeName, Value: i.cordonedBy()},
+	}
+}
+
+// cordon marks the node as unschedulable, flagging it so it can be uncordoned on cleanup
+func (i *nodeDrainInjector) cordon() error {
+	node, err := i.config.K8sClient.CoreV1().Nodes().Get(context.Background(), i.config.Disruption.TargetNodeName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error getting the node: %w", err)
+	}
+
+	if node.Spec.Unschedulable {
+		i.config.Log.Infow("the node is already cordoned", "node", node.Name)
+
+		return nil
+	}
+
+	i.config.Log.Infow("cordoning the node", "node", node.Name)
+
+	if i.config.Disruption.DryRun {
+		return nil
+	}
+
+	cordonedBy := i.cordonedBy()
+
+	if err := patchNodeCordon(i.config.K8sClient, node.Name, true, &cordonedBy); err != nil {
+		return fmt.Errorf("error cordoning the node: %w", err)
+	}
+
+	return nil
+}
+
+// cordonedBy returns the value of the cordoned by annotation set by the injector
+func (i *nodeDrainInjector) cordonedBy() string {
+	return i.config.Disruption.DisruptionNamespace + "/" + i.config.Disruption.DisruptionName
+}
+
+// UncordonNode marks the given node as schedulable and removes its cordoned by annotation
+func UncordonNode(k8sClient kubernetes.Interface, nodeName string) error {
+	// a null value removes the annotation
+	if err := patchNodeCordon(k8sClient, nodeName, false, nil); err != nil {
+		return fmt.Errorf("error uncordoning the node: %w", err)
+	}
+
+	return nil
+}
+
+// patchNodeCordon sets the unschedulable field and the cordoned by annotation of the node, removing the annotation if nil
+// a merge patch only changes those fields, so it does not conflict with the node updates made by the kubelet
+// and the node lifecycle controller meanwhile, unlike an update of the whole node
+func patchNodeCordon(k8sClient kubernetes.Interface, nodeName string, unschedulable bool, cordonedBy *string) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]*string{
+				NodeDrainCordonedByAnnotation: cordonedBy,
+			},
+		},
+		"spec": map[string]interface{}{
+			"unschedulable": unschedulable,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("error building the node patch: %w", err)
+	}
+
+	_, err = k8sClient.CoreV1().Nodes().Patch(context.Background(), nodeName, k8stypes.MergePatchType, patch, metav1.PatchOptions{})
+
+	return err
+}
+
+// evictPods evicts the pods of the node through the eviction API, respecting their pod disruption budgets
+func (i *nodeDrainInjector) evictPods() (retErr error) {
+	pods, err := i.config.K8sClient.CoreV1().Pods(metav1.NamespaceAll).List(context.Background(), metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", i.config.Disruption.TargetNodeName).String(),
+	})
+	if err != nil {
+		return fmt.Errorf("error listing the pods of the node: %w", err)
+	}
+
+	for _, pod := range pods.Items {
+		if !i.isEvictable(pod) {
+			continue
+		}
+
+		i.config.Log.Infow("evicting pod", "pod", pod.Name, "namespace", pod.Namespace)
+
+		if i.config.Disruption.DryRun {
+			continue
+		}
+
+		eviction := &policyv1.Eviction{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      pod.Name,
+				Namespace: pod.Namespace,
+			},
+			DeleteOptions: &metav1.DeleteOptions{
+				GracePeriodSeconds: i.spec.GracePeriodSeconds,
+			},
+		}
+
+		err := i.config.K8sClient.PolicyV1().Evictions(pod.Namespace).Evict(context.Background(), eviction)
+
+		switch {
+		case err == nil, apierrors.IsNotFound(err):
+		case apierrors.IsTooManyRequests(err):
+			// like kubectl drain, the pod is not evicted if it would violate its pod disruption budget
+			i.config.Log.Warnw("the pod can't be evicted without violating its pod disruption budget, skipping", "pod", pod.Name, "namespace", pod.Namespace)
+		default:
+			retErr = multierror.Append(retErr, fmt.Errorf("error evicting pod %s/%s: %w", pod.Namespace, pod.Name, err))
+		}
+	}
+
+	return retErr
+}
+
+// isEvictable returns false for the pods which would not be evicted by a drain: terminated pods,
+// static pods, daemonset pods and the chaos pods
+func (i *nodeDrainInjector) isEvictable(pod corev1.Pod) bool {
+	if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		return false
+	}
+
+	if _, found := pod.Annotations[mirrorPodAnnotation]; found {
+		return false
+	}
+
+	if pod.Namespace == i.config.Disruption.ChaosNamespace {
+		return false
+	}
+
+	for _, owner := range pod.OwnerReferences {
+		if owner.Kind == "DaemonSet" {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,179 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package injector_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	chaosapi "github.com/DataDog/chaos-controller/api"
+	"github.com/DataDog/chaos-controller/api/v1beta1"
+	. "github.com/DataDog/chaos-controller/injector"
+)
+
+var _ = Describe("Node drain", func() {
+	var (
+		config    NodeDrainInjectorConfig
+		k8sClient *fake.Clientset
+		node      *corev1.Node
+		inj       Injector
+		spec      v1beta1.NodeFailureDrainSpec
+	)
+
+	newPod := func(name, namespace string, owners ...metav1.OwnerReference) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       namespace,
+				OwnerReferences: owners,
+			},
+			Spec: corev1.PodSpec{
+				NodeName: "node1",
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+			},
+		}
+	}
+
+	evictedPods := func() []string {
+		evicted := []string{}
+
+		for _, action := range k8sClient.Actions() {
+			if action.GetSubresource() != "eviction" {
+				continue
+			}
+
+			eviction := action.(k8stesting.CreateAction).GetObject().(*policyv1.Eviction)
+			evicted = append(evicted, eviction.Namespace+"/"+eviction.Name)
+		}
+
+		return evicted
+	}
+
+	getNode := func() *corev1.Node {
+		node, err := k8sClient.CoreV1().Nodes().Get(context.Background(), "node1", metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		return node
+	}
+
+	BeforeEach(func() {
+		node = &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "node1",
+			},
+		}
+
+		spec = v1beta1.NodeFailureDrainSpec{}
+	})
+
+	JustBeforeEach(func() {
+		var err error
+
+		k8sClient = fake.NewSimpleClientset(
+			node,
+			newPod("app", "default"),
+			newPod("agent", "monitoring", metav1.OwnerReference{Kind: "DaemonSet", Name: "agent"}),
+			newPod("chaos-pod", "chaos-engineering"),
+		)
+
+		config = NodeDrainInjectorConfig{
+			Config: Config{
+				Log:         log,
+				MetricsSink: ms,
+				K8sClient:   k8sClient,
+				Disruption: chaosapi.DisruptionArgs{
+					TargetNodeName:      "node1",
+					ChaosNamespace:      "chaos-engineering",
+					DisruptionName:      "drain",
+					DisruptionNamespace: "chaos-demo",
+				},
+			},
+		}
+
+		inj, err = NewNodeDrainInjector(spec, config)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should cordon the node, evict its pods and uncordon it on clean", func() {
+		Expect(inj.Inject()).To(Succeed())
+		Expect(getNode().Spec.Unschedulable).To(BeTrue())
+		Expect(getNode().Annotations).To(HaveKeyWithValue(NodeDrainCordonedByAnnotation, "chaos-demo/drain"))
+		Expect(evictedPods()).To(ConsistOf("default/app"))
+
+		Expect(inj.Clean()).To(Succeed())
+		Expect(getNode().Spec.Unschedulable).To(BeFalse())
+		Expect(getNode().Annotations).ToNot(HaveKey(NodeDrainCordonedByAnnotation))
+	})
+
+	It("should journal the node to uncordon", func() {
+		Expect(inj.(Journaled).JournalChanges()).To(Equal([]JournalChange{
+			{Type: JournalChangeNodeCordon, Node: "node1", Value: "chaos-demo/drain"},
+		}))
+	})
+
+	Context("with a node uncordoned by the sweeper", func() {
+		It("should uncordon the node and keep it uncordoned on clean", func() {
+			Expect(inj.Inject()).To(Succeed())
+
+			Expect(UncordonNode(k8sClient, "node1")).To(Succeed())
+			Expect(getNode().Spec.Unschedulable).To(BeFalse())
+			Expect(getNode().Annotations).ToNot(HaveKey(NodeDrainCordonedByAnnotation))
+
+			Expect(inj.Clean()).To(Succeed())
+			Expect(getNode().Spec.Unschedulable).To(BeFalse())
+		})
+	})
+
+	Context("with a node concurrently updated", func() {
+		BeforeEach(func() {
+			node.Annotations = map[string]string{"foo": "bar"}
+		})
+
+		JustBeforeEach(func() {
+			// the kubelet and the node lifecycle controller keep updating the node
+			k8sClient.PrependReactor("update", "nodes", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				return true, nil, apierrors.NewConflict(schema.GroupResource{Resource: "nodes"}, "node1", errors.New("the object has been modified"))
+			})
+		})
+
+		It("should cordon and uncordon the node without conflicting, keeping its other annotations", func() {
+			Expect(inj.Inject()).To(Succeed())
+			Expect(getNode().Spec.Unschedulable).To(BeTrue())
+			Expect(getNode().Annotations).To(HaveKeyWithValue("foo", "bar"))
+
+			Expect(inj.Clean()).To(Succeed())
+			Expect(getNode().Spec.Unschedulable).To(BeFalse())
+			Expect(getNode().Annotations).To(Equal(map[string]string{"foo": "bar"}))
+		})
+	})
+
+	Context("with a node already cordoned", func() {
+		BeforeEach(func() {
+			node.Spec.Unschedulable = true
+		})
+
+		It("should leave the node cordoned on clean", func() {
+			Expect(inj.Inject()).To(Succeed())
+			Expect(evictedPods()).To(ConsistOf("default/app"))
+
+			Expect(inj.Clean()).To(Succeed())
+			Expect(getNode().Spec.Unschedulable).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,156 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package injector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/DataDog/chaos-controller/api/v1beta1"
+	"github.com/DataDog/chaos-controller/netns"
+	"github.com/DataDog/chaos-controller/network"
+	"github.com/DataDog/chaos-controller/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// controlPlaneEndpointsNamespace and controlPlaneEndpointsName identify the endpoints of the API servers
+	controlPlaneEndpointsNamespace = "default"
+	controlPlaneEndpointsName      = "kubernetes"
+)
+
+// nodeNetworkIsolationInjector describes a node network isolation injector
+type nodeNetworkIsolationInjector struct {
+	spec   v1beta1.NodeFailureNetworkIsolationSpec
+	config NodeNetworkIsolationInjectorConfig
+}
+
+// NodeNetworkIsolationInjectorConfig contains needed drivers to
+// create a NodeNetworkIsolationInjector
+type NodeNetworkIsolationInjectorConfig struct {
+	Config
+	IPTables network.IPTables
+	// HostNetns is the network namespace of the node, the config one being the target pod one at the pod level
+	HostNetns netns.Manager
+}
+
+// NewNodeNetworkIsolationInjector creates a NodeNetworkIsolationInjector object with the given config,
+// missing fields being initialized with the defaults
+func NewNodeNetworkIsolationInjector(spec v1beta1.NodeFailureNetworkIsolationSpec, config NodeNetworkIsolationInjectorConfig) (Injector, error) {
+	var err error
+
+	if config.IPTables == nil {
+		config.IPTables, err = network.NewIPTables(config.Log, config.Disruption.DryRun)
+		if err != nil {
+			return nil, fmt.Errorf("error creating the iptables manager: %w", err)
+		}
+	}
+
+	if config.HostNetns == nil {
+		if config.Disruption.Level == types.DisruptionLevelNode {
+			config.HostNetns = config.Netns
+		} else if config.HostNetns, err = netns.NewManager(config.Log, 1); err != nil {
+			return nil, fmt.Errorf("error creating the node network namespace manager: %w", err)
+		}
+	}
+
+	return &nodeNetworkIsolationInjector{
+		spec:   spec,
+		config: config,
+	}, nil
+}
+
+func (i *nodeNetworkIsolationInjector) GetDisruptionKind() types.DisruptionKindName {
+	return types.DisruptionKindNodeFailure
+}
+
+// Inject drops all the traffic of the node except the one exchanged with the control plane and the allowed hosts
+func (i *nodeNetworkIsolationInjector) Inject() error {
+	controlPlane, err := i.controlPlaneIPs()
+	if err != nil {
+		return err
+	}
+
+	allowedHosts := append(controlPlane, i.spec.AllowedHosts...)
+
+	i.config.Log.Infow("isolating the node network", "allowedHosts", allowedHosts)
+
+	if err := i.config.HostNetns.Enter(); err != nil {
+		return fmt.Errorf("unable to enter the node network namespace: %w", err)
+	}
+
+	if err := i.config.IPTables.Isolate(allowedHosts); err != nil {
+		// remove the partially injected rules, the node could be isolated from the control plane otherwise
+		if clearErr := i.config.IPTables.Clear(); clearErr != nil {
+			i.config.Log.Errorw("error cleaning the partially injected isolation rules", "error", clearErr)
+		}
+
+		_ = i.config.HostNetns.Exit()
+
+		return fmt.Errorf("error isolating the node network: %w", err)
+	}
+
+	if err := i.config.HostNetns.Exit(); err != nil {
+		return fmt.Errorf("unable to exit the node network namespace: %w", err)
+	}
+
+	return nil
+}
+
+func (i *nodeNetworkIsolationInjector) UpdateConfig(config Config) {
+	i.config.Config = config
+}
+
+// Clean removes the isolation rules
+func (i *nodeNetworkIsolationInjector) Clean() error {
+	if err := i.config.HostNetns.Enter(); err != nil {
+		return fmt.Errorf("unable to enter the node network namespace: %w", err)
+	}
+
+	if err := i.config.IPTables.Clear(); err != nil {
+		_ = i.config.HostNetns.Exit()
+
+		return fmt.Errorf("error removing the node network isolation: %w", err)
+	}
+
+	if err := i.config.HostNetns.Exit(); err != nil {
+		return fmt.Errorf("unable to exit the node network namespace: %w", err)
+	}
+
+	i.config.Log.Infow("node network isolation removed")
+
+	return nil
+}
+
+// JournalChanges returns the isolation rules injected in the node network namespace
+func (i *nodeNetworkIsolationInjector) JournalChanges() []JournalChange {
+	return []JournalChange{
+		{Type: JournalChangeIPTables, HostNetwork: true, Rules: i.config.IPTables.Rules()},
+	}
+}
+
+// controlPlaneIPs returns the IPs of the API servers, the kubelet and the injector still having to reach them
+func (i *nodeNetworkIsolationInjector) controlPlaneIPs() ([]string, error) {
+	endpoints, err := i.config.K8sClient.CoreV1().Endpoints(controlPlaneEndpointsNamespace).Get(context.Background(), controlPlaneEndpointsName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error getting the control plane endpoints: %w", err)
+	}
+
+	ips := []string{}
+
+	for _, subset := range endpoints.Subsets {
+		for _, address := range subset.Addresses {
+			ips = append(ips, address.IP)
+		}
+	}
+
+	if len(ips) == 0 {
+		return nil, errors.New("no control plane endpoint found, the node can't be isolated safely")
+	}
+
+	return ips, nil
+}
@@ -0,0 +1,95 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package injector_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/DataDog/chaos-controller/api/v1beta1"
+	. "github.com/DataDog/chaos-controller/injector"
+	"github.com/DataDog/chaos-controller/netns"
+	"github.com/DataDog/chaos-controller/network"
+)
+
+var _ = Describe("Node network isolation", func() {
+	var (
+		config    NodeNetworkIsolationInjectorConfig
+		iptables  *network.IPTablesMock
+		hostNetns *netns.ManagerMock
+		inj       Injector
+		spec      v1beta1.NodeFailureNetworkIsolationSpec
+	)
+
+	BeforeEach(func() {
+		iptables = network.NewIPTablesMock(GinkgoT())
+		hostNetns = netns.NewManagerMock(GinkgoT())
+		hostNetns.EXPECT().Enter().Return(nil).Maybe()
+		hostNetns.EXPECT().Exit().Return(nil).Maybe()
+
+		config = NodeNetworkIsolationInjectorConfig{
+			Config: Config{
+				Log:         log,
+				MetricsSink: ms,
+				K8sClient: fake.NewSimpleClientset(&corev1.Endpoints{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "kubernetes",
+						Namespace: "default",
+					},
+					Subsets: []corev1.EndpointSubset{
+						{
+							Addresses: []corev1.EndpointAddress{{IP: "172.16.0.1"}, {IP: "172.16.0.2"}},
+						},
+					},
+				}),
+			},
+			IPTables:  iptables,
+			HostNetns: hostNetns,
+		}
+
+		spec = v1beta1.NodeFailureNetworkIsolationSpec{
+			AllowedHosts: []string{"10.1.0.0/16"},
+		}
+	})
+
+	JustBeforeEach(func() {
+		var err error
+
+		inj, err = NewNodeNetworkIsolationInjector(spec, config)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should isolate the node except from the control plane and the allowed hosts, and remove the isolation on clean", func() {
+		iptables.EXPECT().Isolate([]string{"172.16.0.1", "172.16.0.2", "10.1.0.0/16"}).Return(nil).Once()
+		Expect(inj.Inject()).To(Succeed())
+
+		iptables.EXPECT().Clear().Return(nil).Once()
+		Expect(inj.Clean()).To(Succeed())
+	})
+
+	It("should remove the partially injected rules when the isolation fails", func() {
+		iptables.EXPECT().Isolate(mock.Anything).Return(errors.New("iptables failure")).Once()
+		iptables.EXPECT().Clear().Return(nil).Once()
+		Expect(inj.Inject()).ToNot(Succeed())
+	})
+
+	Context("without any control plane endpoint", func() {
+		BeforeEach(func() {
+			config.K8sClient = fake.NewSimpleClientset()
+		})
+
+		It("should not isolate the node", func() {
+			Expect(inj.Inject()).ToNot(Succeed())
+			iptables.AssertNotCalled(GinkgoT(), "Isolate", mock.Anything)
+		})
+	})
+})
@@ -0,0 +1,62 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package injector
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// NodeServiceManager is a component allowing to start and stop the services of the node
+type NodeServiceManager interface {
+	Start(service string) error
+	Stop(service string) error
+}
+
+// systemdNodeServiceManager implements the NodeServiceManager interface by running systemctl
+// in the namespaces of the node init process, the injector sharing the node PID namespace
+type systemdNodeServiceManager struct {
+	log    *zap.SugaredLogger
+	dryRun bool
+}
+
+// NewNodeServiceManager creates a node service manager running systemctl on the node
+func NewNodeServiceManager(log *zap.SugaredLogger, dryRun bool) NodeServiceManager {
+	return systemdNodeServiceManager{
+		log:    log,
+		dryRun: dryRun,
+	}
+}
+
+// Start starts the given service
+func (m systemdNodeServiceManager) Start(service string) error {
+	return m.systemctl("start", service)
+}
+
+// Stop stops the given service
+func (m systemdNodeServiceManager) Stop(service string) error {
+	return m.systemctl("stop", service)
+}
+
+func (m systemdNodeServiceManager) systemctl(action, service string) error {
+	args := []string{"--target", "1", "--mount", "--uts", "--ipc", "--net", "--pid", "--", "systemctl", action, service}
+
+	m.log.Infow("running systemctl on the node", "command", "nsenter", "args", strings.Join(args, " "))
+
+	// early exit if dry-run mode is enabled
+	if m.dryRun {
+		return nil
+	}
+
+	if output, err := exec.Command("nsenter", args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("error running systemctl %s %s: %w: %s", action, service, err, output)
+	}
+
+	return nil
+}
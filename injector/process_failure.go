@@ -0,0 +1,264 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package injector
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/DataDog/chaos-controller/api/v1beta1"
+	"github.com/DataDog/chaos-controller/env"
+	"github.com/DataDog/chaos-controller/process"
+	"github.com/DataDog/chaos-controller/types"
+	"github.com/hashicorp/go-multierror"
+)
+
+const (
+	// cgroup.procs lists the same processes in every controller of the cgroup, the cpu one always being available
+	processFailureCgroupControllerName = "cpu"
+	processFailureCgroupProcsFilename  = "cgroup.procs"
+)
+
+// processFailureInjector describes a process failure injector
+type processFailureInjector struct {
+	spec    v1beta1.ProcessFailureSpec
+	config  ProcessFailureInjectorConfig
+	pattern *regexp.Regexp
+	signal  syscall.Signal
+	// paused are the processes stopped by the injector which must be resumed
+	paused map[int]*os.Process
+	mu     sync.Mutex
+	stop   chan struct{}
+	wg     sync.WaitGroup
+}
+
+// ProcessFailureInjectorConfig contains needed drivers to
+// create a ProcessFailureInjector
+type ProcessFailureInjectorConfig struct {
+	Config
+	ProcessManager process.Manager
+	ProcessFinder  process.Finder
+}
+
+// NewProcessFailureInjector creates a ProcessFailureInjector object with the given config,
+// missing fields being initialized with the defaults
+func NewProcessFailureInjector(spec v1beta1.ProcessFailureSpec, config ProcessFailureInjectorConfig) (Injector, error) {
+	pattern, err := regexp.Compile(spec.Pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid process pattern %s: %w", spec.Pattern, err)
+	}
+
+	signal, err := v1beta1.ParseProcessFailureSignal(spec.Signal)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.ProcessManager == nil {
+		config.ProcessManager = process.NewManager(config.Disruption.DryRun)
+	}
+
+	if config.ProcessFinder == nil {
+		mountProc, ok := os.LookupEnv(env.InjectorMountProc)
+		if !ok {
+			return nil, fmt.Errorf("environment variable %s doesn't exist", env.InjectorMountProc)
+		}
+
+		config.ProcessFinder = process.NewFinder(mountProc)
+	}
+
+	return &processFailureInjector{
+		spec:    spec,
+		config:  config,
+		pattern: pattern,
+		signal:  signal,
+		paused:  map[int]*os.Process{},
+	}, nil
+}
+
+func (i *processFailureInjector) GetDisruptionKind() types.DisruptionKindName {
+	return types.DisruptionKindProcessFailure
+}
+
+// Inject sends the signal to the matching processes of the container,
+// resuming them after the pause duration and repeating it at the given interval if any
+func (i *processFailureInjector) Inject() error {
+	if err := i.signalProcesses(); err != nil {
+		return err
+	}
+
+	pauseDuration, interval := i.spec.PauseDuration.Duration(), i.spec.Interval.Duration()
+	if pauseDuration == 0 && interval == 0 {
+		return nil
+	}
+
+	i.stop = make(chan struct{})
+	i.wg.Add(1)
+
+	go i.run(i.stop, pauseDuration, interval)
+
+	return nil
+}
+
+func (i *processFailureInjector) UpdateConfig(config Config) {
+	i.config.Config = config
+}
+
+// Clean stops repeating the injection and resumes the paused processes
+func (i *processFailureInjector) Clean() error {
+	if i.stop != nil {
+		close(i.stop)
+		i.wg.Wait()
+		i.stop = nil
+	}
+
+	return i.resumeProcesses()
+}
+
+// JournalChanges returns the processes of the container paused by the injector, if any
+func (i *processFailureInjector) JournalChanges() []JournalChange {
+	if i.signal != syscall.SIGSTOP {
+		return nil
+	}
+
+	return []JournalChange{
+		{Type: JournalChangeProcess, Pattern: i.spec.Pattern, Controller: processFailureCgroupControllerName, File: processFailureCgroupProcsFilename},
+	}
+}
+
+// run resumes the paused processes after the pause duration and repeats the injection at the given interval until the given channel is closed
+func (i *processFailureInjector) run(stop <-chan struct{}, pauseDuration, interval time.Duration) {
+	defer i.wg.Done()
+
+	var resume, repeat <-chan time.Time
+
+	if pauseDuration > 0 {
+		resume = time.After(pauseDuration)
+	}
+
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		repeat = ticker.C
+	}
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-resume:
+			resume = nil
+
+			if err := i.resumeProcesses(); err != nil {
+				i.config.Log.Errorw("error resuming the paused processes", "error", err)
+			}
+		case <-repeat:
+			if err := i.signalProcesses(); err != nil {
+				i.config.Log.Errorw("error repeating the process failure", "error", err)
+			}
+
+			if pauseDuration > 0 {
+				resume = time.After(pauseDuration)
+			}
+		}
+	}
+}
+
+// signalProcesses sends the signal to all the processes of the container matching the pattern
+func (i *processFailureInjector) signalProcesses() error {
+	pids, err := i.getContainerPIDs()
+	if err != nil {
+		return err
+	}
+
+	processes, err := i.config.ProcessFinder.Find(pids, i.pattern)
+	if err != nil {
+		return fmt.Errorf("error while finding the processes: %w", err)
+	}
+
+	if len(processes) == 0 {
+		i.config.Log.Warnw("no process matching the pattern found in the container", "pattern", i.spec.Pattern, "container", i.config.TargetContainer.Name())
+
+		return nil
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	for _, info := range processes {
+		proc, err := i.config.ProcessManager.Find(info.PID)
+		if err != nil {
+			return fmt.Errorf("error while finding the process %d: %w", info.PID, err)
+		}
+
+		i.config.Log.Infow("injecting a process failure", "signal", i.signal, "pid", info.PID, "name", info.Name, "cmdline", info.Cmdline)
+
+		if err := i.config.ProcessManager.Signal(proc, i.signal); err != nil {
+			// the process may have exited since it has been found
+			if errors.Is(err, os.ErrProcessDone) {
+				continue
+			}
+
+			return fmt.Errorf("error while sending the %s signal to process %d: %w", i.signal, info.PID, err)
+		}
+
+		if i.signal == syscall.SIGSTOP {
+			i.paused[info.PID] = proc
+		}
+	}
+
+	return nil
+}
+
+// getContainerPIDs returns the processes of the target container cgroup
+// the PID namespace of the container can be shared with other containers of the pod or with the host,
+// so its processes are the only ones which can be safely signaled
+func (i *processFailureInjector) getContainerPIDs() ([]int, error) {
+	procs, err := i.config.Cgroup.Read(processFailureCgroupControllerName, processFailureCgroupProcsFilename)
+	if err != nil {
+		return nil, fmt.Errorf("error reading the processes of the container cgroup: %w", err)
+	}
+
+	pids := []int{}
+
+	for _, line := range strings.Fields(procs) {
+		pid, err := strconv.Atoi(line)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing the process %s of the container cgroup: %w", line, err)
+		}
+
+		pids = append(pids, pid)
+	}
+
+	return pids, nil
+}
+
+// resumeProcesses sends the SIGCONT signal to the paused processes
+func (i *processFailureInjector) resumeProcesses() (retErr error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	for pid, proc := range i.paused {
+		i.config.Log.Infow("resuming a paused process", "pid", pid)
+
+		if err := i.config.ProcessManager.Signal(proc, syscall.SIGCONT); err != nil && !errors.Is(err, os.ErrProcessDone) {
+			retErr = multierror.Append(retErr, fmt.Errorf("error while resuming the process %d: %w", pid, err))
+
+			continue
+		}
+
+		delete(i.paused, pid)
+	}
+
+	return retErr
+}
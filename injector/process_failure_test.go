@@ -0,0 +1,155 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package injector_test
+
+import (
+	"os"
+	"syscall"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/DataDog/chaos-controller/api/v1beta1"
+	"github.com/DataDog/chaos-controller/cgroup"
+	"github.com/DataDog/chaos-controller/container"
+	. "github.com/DataDog/chaos-controller/injector"
+	"github.com/DataDog/chaos-controller/process"
+)
+
+var _ = Describe("Process failure", func() {
+	var (
+		config        ProcessFailureInjectorConfig
+		manager       *process.ManagerMock
+		finder        *process.FinderMock
+		cgroupManager *cgroup.ManagerMock
+		worker        *os.Process
+		ctn           *container.ContainerMock
+		inj           Injector
+		spec          v1beta1.ProcessFailureSpec
+	)
+
+	BeforeEach(func() {
+		worker = &os.Process{Pid: 43}
+
+		// container
+		ctn = container.NewContainerMock(GinkgoT())
+		ctn.EXPECT().Name().Return("app").Maybe()
+
+		// cgroup of the container, listing its processes
+		cgroupManager = cgroup.NewManagerMock(GinkgoT())
+		cgroupManager.EXPECT().Read("cpu", "cgroup.procs").Return("42\n43", nil)
+
+		// finder
+		finder = process.NewFinderMock(GinkgoT())
+		finder.EXPECT().Find([]int{42, 43}, mock.Anything).Return([]process.Info{{PID: 43, Name: "worker", Cmdline: "worker --queue foo"}}, nil)
+
+		// manager
+		manager = process.NewManagerMock(GinkgoT())
+		manager.EXPECT().Find(43).Return(worker, nil)
+
+		config = ProcessFailureInjectorConfig{
+			Config: Config{
+				Log:             log,
+				MetricsSink:     ms,
+				TargetContainer: ctn,
+				Cgroup:          cgroupManager,
+			},
+			ProcessManager: manager,
+			ProcessFinder:  finder,
+		}
+
+		spec = v1beta1.ProcessFailureSpec{
+			Pattern: "^worker",
+		}
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		inj, err = NewProcessFailureInjector(spec, config)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Context("with the default signal", func() {
+		BeforeEach(func() {
+			manager.EXPECT().Signal(worker, syscall.SIGTERM).Return(nil).Once()
+		})
+
+		It("should send the SIGTERM signal to the matching processes", func() {
+			Expect(inj.Inject()).To(Succeed())
+			Expect(inj.Clean()).To(Succeed())
+		})
+
+		It("should not journal any change", func() {
+			Expect(inj.Inject()).To(Succeed())
+			Expect(inj.(Journaled).JournalChanges()).To(BeEmpty())
+		})
+	})
+
+	Context("with the SIGSTOP signal", func() {
+		BeforeEach(func() {
+			spec.Signal = "SIGSTOP"
+			manager.EXPECT().Signal(worker, syscall.SIGSTOP).Return(nil).Once()
+		})
+
+		It("should pause the processes until the disruption is cleaned", func() {
+			Expect(inj.Inject()).To(Succeed())
+			manager.AssertNotCalled(GinkgoT(), "Signal", worker, syscall.SIGCONT)
+
+			manager.EXPECT().Signal(worker, syscall.SIGCONT).Return(nil).Once()
+			Expect(inj.Clean()).To(Succeed())
+		})
+
+		It("should journal the container processes to resume", func() {
+			Expect(inj.Inject()).To(Succeed())
+			Expect(inj.(Journaled).JournalChanges()).To(Equal([]JournalChange{
+				{Type: JournalChangeProcess, Pattern: "^worker", Controller: "cpu", File: "cgroup.procs"},
+			}))
+
+			manager.EXPECT().Signal(worker, syscall.SIGCONT).Return(nil).Once()
+			Expect(inj.Clean()).To(Succeed())
+		})
+
+		Context("with a pause duration", func() {
+			var resumed chan struct{}
+
+			BeforeEach(func() {
+				spec.PauseDuration = "10ms"
+				resumed = make(chan struct{})
+				manager.EXPECT().Signal(worker, syscall.SIGCONT).Run(func(*os.Process, os.Signal) {
+					close(resumed)
+				}).Return(nil).Once()
+			})
+
+			It("should resume the processes after the pause duration", func() {
+				Expect(inj.Inject()).To(Succeed())
+				Eventually(resumed).Should(BeClosed())
+				Expect(inj.Clean()).To(Succeed())
+			})
+		})
+	})
+
+	Context("with an interval", func() {
+		var repeated chan struct{}
+
+		BeforeEach(func() {
+			spec.Signal = "SIGUSR1"
+			spec.Interval = "10ms"
+			repeated = make(chan struct{})
+			manager.EXPECT().Signal(worker, syscall.SIGUSR1).Return(nil).Twice()
+			manager.EXPECT().Signal(worker, syscall.SIGUSR1).Run(func(*os.Process, os.Signal) {
+				close(repeated)
+			}).Return(nil).Once()
+			manager.EXPECT().Signal(worker, syscall.SIGUSR1).Return(nil).Maybe()
+		})
+
+		It("should repeat the injection", func() {
+			Expect(inj.Inject()).To(Succeed())
+			Eventually(repeated).Should(BeClosed())
+			Expect(inj.Clean()).To(Succeed())
+		})
+	})
+})
@@ -0,0 +1,72 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package injector
+
+import (
+	"math/rand"
+	"time"
+)
+
+// PulseScheduler computes the durations of the active and dormant states of a pulsing disruption
+type PulseScheduler struct {
+	minActive  time.Duration
+	maxActive  time.Duration
+	minDormant time.Duration
+	maxDormant time.Duration
+	rand       *rand.Rand
+}
+
+// NewPulseScheduler creates a pulse scheduler picking the durations of the active and dormant states between the given
+// minimum and maximum durations, a maximum duration lower than its minimum duration giving a fixed duration.
+// The same seed always gives the same sequence of durations.
+func NewPulseScheduler(minActive, maxActive, minDormant, maxDormant time.Duration, seed int64) *PulseScheduler {
+	return &PulseScheduler{
+		minActive:  minActive,
+		maxActive:  maxActive,
+		minDormant: minDormant,
+		maxDormant: maxDormant,
+		rand:       rand.New(rand.NewSource(seed)),
+	}
+}
+
+// Sync replays the pulse from the given anchor, the time at which the first active state starts, and returns
+// whether the disruption is active at the given time and when this state ends. States starting from the same anchor
+// with the same seed flip at the same instant. The next durations then follow the replayed ones.
+func (p *PulseScheduler) Sync(anchor, now time.Time) (active bool, end time.Time) {
+	// the first active state starts at the anchor, the disruption being considered active before it
+	active = true
+	end = anchor.Add(p.NextActive())
+
+	for !end.After(now) {
+		active = !active
+
+		if active {
+			end = end.Add(p.NextActive())
+		} else {
+			end = end.Add(p.NextDormant())
+		}
+	}
+
+	return active, end
+}
+
+// NextActive returns the duration of the next active state
+func (p *PulseScheduler) NextActive() time.Duration {
+	return p.next(p.minActive, p.maxActive)
+}
+
+// NextDormant returns the duration of the next dormant state
+func (p *PulseScheduler) NextDormant() time.Duration {
+	return p.next(p.minDormant, p.maxDormant)
+}
+
+func (p *PulseScheduler) next(min, max time.Duration) time.Duration {
+	if max <= min {
+		return min
+	}
+
+	return min + time.Duration(p.rand.Int63n(int64(max-min)+1))
+}
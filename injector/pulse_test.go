@@ -0,0 +1,113 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package injector_test
+
+import (
+	"time"
+
+	. "github.com/DataDog/chaos-controller/injector"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Pulse scheduler", func() {
+	Context("without maximum durations", func() {
+		It("should return fixed durations", func() {
+			scheduler := NewPulseScheduler(time.Minute, 0, 2*time.Minute, 0, 42)
+
+			for i := 0; i < 10; i++ {
+				Expect(scheduler.NextActive()).To(Equal(time.Minute))
+				Expect(scheduler.NextDormant()).To(Equal(2 * time.Minute))
+			}
+		})
+	})
+
+	Context("with maximum durations", func() {
+		It("should return durations between the minimum and maximum durations", func() {
+			scheduler := NewPulseScheduler(time.Minute, 5*time.Minute, 10*time.Second, 30*time.Second, 42)
+			actives := map[time.Duration]struct{}{}
+
+			for i := 0; i < 100; i++ {
+				active := scheduler.NextActive()
+				Expect(active).To(BeNumerically(">=", time.Minute))
+				Expect(active).To(BeNumerically("<=", 5*time.Minute))
+
+				dormant := scheduler.NextDormant()
+				Expect(dormant).To(BeNumerically(">=", 10*time.Second))
+				Expect(dormant).To(BeNumerically("<=", 30*time.Second))
+
+				actives[active] = struct{}{}
+			}
+
+			Expect(len(actives)).To(BeNumerically(">", 1))
+		})
+
+		It("should return the same durations for the same seed", func() {
+			first := NewPulseScheduler(time.Minute, 5*time.Minute, 10*time.Second, 30*time.Second, 42)
+			second := NewPulseScheduler(time.Minute, 5*time.Minute, 10*time.Second, 30*time.Second, 42)
+
+			for i := 0; i < 10; i++ {
+				Expect(first.NextActive()).To(Equal(second.NextActive()))
+				Expect(first.NextDormant()).To(Equal(second.NextDormant()))
+			}
+		})
+	})
+})
+
+var _ = Describe("Pulse scheduler sync", func() {
+	var anchor time.Time
+
+	BeforeEach(func() {
+		anchor = time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	})
+
+	Context("with fixed durations", func() {
+		DescribeTable("should return the state at the given time and its end",
+			func(elapsed time.Duration, expectedActive bool, expectedEnd time.Duration) {
+				scheduler := NewPulseScheduler(time.Minute, 0, 2*time.Minute, 0, 42)
+
+				active, end := scheduler.Sync(anchor, anchor.Add(elapsed))
+				Expect(active).To(Equal(expectedActive))
+				Expect(end).To(Equal(anchor.Add(expectedEnd)))
+			},
+			Entry("before the anchor", -time.Minute, true, time.Minute),
+			Entry("at the anchor", time.Duration(0), true, time.Minute),
+			Entry("during the first active state", 30*time.Second, true, time.Minute),
+			Entry("at the end of the first active state", time.Minute, false, 3*time.Minute),
+			Entry("during the first dormant state", 2*time.Minute, false, 3*time.Minute),
+			Entry("during the second active state", 3*time.Minute+time.Second, true, 4*time.Minute),
+			Entry("during the tenth dormant state", 29*time.Minute, false, 30*time.Minute),
+		)
+	})
+
+	Context("with random durations", func() {
+		It("should return the same state for schedulers with the same seed synced at different times", func() {
+			first := NewPulseScheduler(time.Minute, 5*time.Minute, 10*time.Second, 30*time.Second, 42)
+			second := NewPulseScheduler(time.Minute, 5*time.Minute, 10*time.Second, 30*time.Second, 42)
+
+			firstActive, firstEnd := first.Sync(anchor, anchor.Add(time.Second))
+
+			// replay the states of the first scheduler until the second one is synced
+			for firstEnd.Before(anchor.Add(time.Hour)) {
+				firstActive = !firstActive
+
+				if firstActive {
+					firstEnd = firstEnd.Add(first.NextActive())
+				} else {
+					firstEnd = firstEnd.Add(first.NextDormant())
+				}
+			}
+
+			secondActive, secondEnd := second.Sync(anchor, anchor.Add(time.Hour))
+			Expect(secondActive).To(Equal(firstActive))
+			Expect(secondEnd).To(Equal(firstEnd))
+
+			// both schedulers keep on flipping at the same instants
+			Expect(second.NextActive()).To(Equal(first.NextActive()))
+			Expect(second.NextDormant()).To(Equal(first.NextDormant()))
+		})
+	})
+})
@@ -0,0 +1,115 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package injector
+
+import (
+	"context"
+	"fmt"
+
+	clientsetv1beta1 "github.com/DataDog/chaos-controller/clientset/v1beta1"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// JournalReverter reverts the changes recorded in a journal entry
+type JournalReverter interface {
+	Revert(entry JournalEntry) error
+}
+
+// Sweeper reverts the journaled changes left behind by chaos pods which died before cleaning them
+type Sweeper struct {
+	Log              *zap.SugaredLogger
+	Journal          Journal
+	Reverter         JournalReverter
+	K8sClient        kubernetes.Interface
+	DisruptionClient clientsetv1beta1.DisruptionV1Beta1Interface
+	// ProcPath is the host procfs, used to check if the injector processes which recorded the entries are still running
+	ProcPath string
+	// DryRun keeps the entries in the journal as their changes are not actually reverted
+	DryRun bool
+}
+
+// Sweep reverts and removes the journal entries whose disruption or chaos pod does not exist anymore,
+// or whose chaos pod or injector process is terminated and can't clean its changes anymore
+func (s Sweeper) Sweep(ctx context.Context) error {
+	entries, err := s.Journal.List()
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		log := s.Log.With("entry", entry.ID, "disruptionName", entry.DisruptionName, "disruptionNamespace", entry.DisruptionNamespace, "chaosPod", entry.ChaosPodName, "kind", entry.Kind, "targetName", entry.TargetName)
+
+		orphaned, reason, err := s.isOrphaned(ctx, entry)
+		if err != nil {
+			log.Errorw("error checking if the journaled changes are orphaned, skipping", "error", err)
+
+			continue
+		}
+
+		if !orphaned {
+			continue
+		}
+
+		log.Infow("reverting orphaned journaled changes", "reason", reason, "recordedAt", entry.RecordedAt)
+
+		if err := s.Reverter.Revert(entry); err != nil {
+			log.Errorw("error reverting orphaned journaled changes, they will be reverted again on the next sweep", "error", err)
+
+			continue
+		}
+
+		if s.DryRun {
+			log.Infow("dry-run mode enabled, keeping the journal entry")
+
+			continue
+		}
+
+		if err := s.Journal.Remove(entry.ID); err != nil {
+			log.Errorw("error removing the reverted journal entry", "error", err)
+
+			continue
+		}
+
+		log.Infow("orphaned journaled changes reverted")
+	}
+
+	return nil
+}
+
+// isOrphaned returns true with the reason if the given entry can't be cleaned by its chaos pod anymore
+func (s Sweeper) isOrphaned(ctx context.Context, entry JournalEntry) (bool, string, error) {
+	if _, err := s.DisruptionClient.Disruptions(entry.DisruptionNamespace).Get(ctx, entry.DisruptionName, metav1.GetOptions{}); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return true, "the disruption does not exist anymore", nil
+		}
+
+		return false, "", fmt.Errorf("error getting the disruption: %w", err)
+	}
+
+	pod, err := s.K8sClient.CoreV1().Pods(entry.ChaosNamespace).Get(ctx, entry.ChaosPodName, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return true, "the chaos pod does not exist anymore", nil
+		}
+
+		return false, "", fmt.Errorf("error getting the chaos pod: %w", err)
+	}
+
+	if pod.Status.Phase == corev1.PodFailed || pod.Status.Phase == corev1.PodSucceeded {
+		return true, fmt.Sprintf("the chaos pod is terminated (%s)", pod.Status.Phase), nil
+	}
+
+	// the chaos pod status is not updated if the kubelet is down, the injector process being the only reliable witness
+	if entry.Injector != nil && s.ProcPath != "" && !entry.Injector.Alive(s.ProcPath) {
+		return true, "the injector process is not running anymore", nil
+	}
+
+	return false, "", nil
+}
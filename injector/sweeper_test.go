@@ -0,0 +1,202 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package injector_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/DataDog/chaos-controller/api/v1beta1"
+	clientsetv1beta1 "github.com/DataDog/chaos-controller/clientset/v1beta1"
+	. "github.com/DataDog/chaos-controller/injector"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var _ = Describe("Sweeper", func() {
+	var (
+		journal          *JournalMock
+		reverter         *JournalReverterMock
+		disruptionClient *clientsetv1beta1.DisruptionV1Beta1InterfaceMock
+		disruptions      *clientsetv1beta1.DisruptionInterfaceMock
+		k8sClient        *fake.Clientset
+		entry            JournalEntry
+		dryRun           bool
+		procPath         string
+		sweepErr         error
+	)
+
+	BeforeEach(func() {
+		entry = JournalEntry{
+			ID:                  "chaos-pod-0",
+			DisruptionName:      "disruption",
+			DisruptionNamespace: "namespace",
+			ChaosNamespace:      "chaos-engineering",
+			ChaosPodName:        "chaos-pod",
+			Changes:             []JournalChange{{Type: JournalChangeTrafficControl}},
+		}
+
+		journal = NewJournalMock(GinkgoT())
+		journal.EXPECT().List().Return([]JournalEntry{entry}, nil)
+
+		reverter = NewJournalReverterMock(GinkgoT())
+
+		disruptions = clientsetv1beta1.NewDisruptionInterfaceMock(GinkgoT())
+		disruptionClient = clientsetv1beta1.NewDisruptionV1Beta1InterfaceMock(GinkgoT())
+		disruptionClient.EXPECT().Disruptions("namespace").Return(disruptions)
+
+		k8sClient = fake.NewSimpleClientset(&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "chaos-pod", Namespace: "chaos-engineering"},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		})
+
+		dryRun = false
+		procPath = GinkgoT().TempDir()
+	})
+
+	JustBeforeEach(func() {
+		sweeper := Sweeper{
+			Log:              log,
+			Journal:          journal,
+			Reverter:         reverter,
+			K8sClient:        k8sClient,
+			DisruptionClient: disruptionClient,
+			DryRun:           dryRun,
+			ProcPath:         procPath,
+		}
+
+		sweepErr = sweeper.Sweep(context.Background())
+	})
+
+	Context("when the disruption and the chaos pod are running", func() {
+		BeforeEach(func() {
+			disruptions.EXPECT().Get(mock.Anything, "disruption", mock.Anything).Return(&v1beta1.Disruption{}, nil)
+		})
+
+		It("should keep the changes", func() {
+			Expect(sweepErr).ToNot(HaveOccurred())
+			reverter.AssertNotCalled(GinkgoT(), "Revert", mock.Anything)
+			journal.AssertNotCalled(GinkgoT(), "Remove", mock.Anything)
+		})
+	})
+
+	Context("when the injector process is running", func() {
+		BeforeEach(func() {
+			entry.Injector = &JournalTarget{PID: 42, StartTime: 1234}
+			Expect(os.MkdirAll(filepath.Join(procPath, "42"), 0o700)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(procPath, "42", "stat"), []byte("42 (injector) S 1 2 3 4 5 6 7 8 9 10 11 12 13 14 15 16 17 18 1234 0"), 0o600)).To(Succeed())
+			journal.EXPECT().List().Unset()
+			journal.EXPECT().List().Return([]JournalEntry{entry}, nil)
+			disruptions.EXPECT().Get(mock.Anything, "disruption", mock.Anything).Return(&v1beta1.Disruption{}, nil)
+		})
+
+		It("should keep the changes", func() {
+			Expect(sweepErr).ToNot(HaveOccurred())
+			reverter.AssertNotCalled(GinkgoT(), "Revert", mock.Anything)
+		})
+	})
+
+	Context("when the injector process is gone while the chaos pod still looks running", func() {
+		BeforeEach(func() {
+			// e.g. the chaos pod was OOMKilled while the kubelet was stopped, its status never being updated
+			entry.Injector = &JournalTarget{PID: 42, StartTime: 1234}
+			journal.EXPECT().List().Unset()
+			journal.EXPECT().List().Return([]JournalEntry{entry}, nil)
+			disruptions.EXPECT().Get(mock.Anything, "disruption", mock.Anything).Return(&v1beta1.Disruption{}, nil)
+			reverter.EXPECT().Revert(entry).Return(nil).Once()
+			journal.EXPECT().Remove("chaos-pod-0").Return(nil).Once()
+		})
+
+		It("should revert the changes and remove them from the journal", func() {
+			Expect(sweepErr).ToNot(HaveOccurred())
+		})
+	})
+
+	Context("when the disruption does not exist anymore", func() {
+		BeforeEach(func() {
+			disruptions.EXPECT().Get(mock.Anything, "disruption", mock.Anything).Return(nil, k8serrors.NewNotFound(schema.GroupResource{Resource: "disruptions"}, "disruption"))
+			reverter.EXPECT().Revert(entry).Return(nil).Once()
+			journal.EXPECT().Remove("chaos-pod-0").Return(nil).Once()
+		})
+
+		It("should revert the changes and remove them from the journal", func() {
+			Expect(sweepErr).ToNot(HaveOccurred())
+		})
+	})
+
+	Context("when the chaos pod does not exist anymore", func() {
+		BeforeEach(func() {
+			disruptions.EXPECT().Get(mock.Anything, "disruption", mock.Anything).Return(&v1beta1.Disruption{}, nil)
+			k8sClient = fake.NewSimpleClientset()
+			reverter.EXPECT().Revert(entry).Return(nil).Once()
+			journal.EXPECT().Remove("chaos-pod-0").Return(nil).Once()
+		})
+
+		It("should revert the changes and remove them from the journal", func() {
+			Expect(sweepErr).ToNot(HaveOccurred())
+		})
+	})
+
+	Context("when the chaos pod is terminated", func() {
+		BeforeEach(func() {
+			disruptions.EXPECT().Get(mock.Anything, "disruption", mock.Anything).Return(&v1beta1.Disruption{}, nil)
+			k8sClient = fake.NewSimpleClientset(&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "chaos-pod", Namespace: "chaos-engineering"},
+				Status:     corev1.PodStatus{Phase: corev1.PodFailed, Reason: "OOMKilled"},
+			})
+			reverter.EXPECT().Revert(entry).Return(nil).Once()
+			journal.EXPECT().Remove("chaos-pod-0").Return(nil).Once()
+		})
+
+		It("should revert the changes and remove them from the journal", func() {
+			Expect(sweepErr).ToNot(HaveOccurred())
+		})
+	})
+
+	Context("when the changes can't be reverted", func() {
+		BeforeEach(func() {
+			disruptions.EXPECT().Get(mock.Anything, "disruption", mock.Anything).Return(nil, k8serrors.NewNotFound(schema.GroupResource{Resource: "disruptions"}, "disruption"))
+			reverter.EXPECT().Revert(entry).Return(fmt.Errorf("revert error")).Once()
+		})
+
+		It("should keep them in the journal to retry on the next sweep", func() {
+			Expect(sweepErr).ToNot(HaveOccurred())
+			journal.AssertNotCalled(GinkgoT(), "Remove", mock.Anything)
+		})
+	})
+
+	Context("when the disruption can't be retrieved", func() {
+		BeforeEach(func() {
+			disruptions.EXPECT().Get(mock.Anything, "disruption", mock.Anything).Return(nil, fmt.Errorf("api server error"))
+		})
+
+		It("should keep the changes", func() {
+			Expect(sweepErr).ToNot(HaveOccurred())
+			reverter.AssertNotCalled(GinkgoT(), "Revert", mock.Anything)
+		})
+	})
+
+	Context("in dry-run mode", func() {
+		BeforeEach(func() {
+			dryRun = true
+			disruptions.EXPECT().Get(mock.Anything, "disruption", mock.Anything).Return(nil, k8serrors.NewNotFound(schema.GroupResource{Resource: "disruptions"}, "disruption"))
+			reverter.EXPECT().Revert(entry).Return(nil).Once()
+		})
+
+		It("should keep the changes in the journal", func() {
+			Expect(sweepErr).ToNot(HaveOccurred())
+			journal.AssertNotCalled(GinkgoT(), "Remove", mock.Anything)
+		})
+	})
+})
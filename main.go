@@ -159,11 +159,15 @@ func main() {
 		InjectorAnnotations:                   cfg.Injector.Annotations,
 		InjectorLabels:                        cfg.Injector.Labels,
 		InjectorServiceAccount:                cfg.Injector.ServiceAccount,
+		InjectorNodeFailureServiceAccount:     cfg.Injector.NodeFailureServiceAccount,
 		InjectorImage:                         cfg.Injector.Image,
 		ChaosNamespace:                        cfg.Injector.ChaosNamespace,
 		InjectorDNSDisruptionDNSServer:        cfg.Injector.DNSDisruption.DNSServer,
 		InjectorDNSDisruptionKubeDNS:          cfg.Injector.DNSDisruption.KubeDNS,
 		InjectorNetworkDisruptionAllowedHosts: cfg.Injector.NetworkDisruption.AllowedHosts,
+		InjectorControlPort:                   cfg.Injector.ControlPort,
+		InjectorControlTokenSecret:            cfg.Injector.ControlTokenSecret,
+		AbortProbeAllowedHosts:                cfg.Controller.AbortProbeAllowedHosts,
 		ImagePullSecrets:                      cfg.Injector.ImagePullSecrets,
 		ExpiredDisruptionGCDelay:              gcPtr,
 		CacheContextStore:                     make(map[string]controllers.CtxTuple),
@@ -183,7 +187,37 @@ func main() {
 
 	r.Controller = cont
 
-	watcherFactory := watchers.NewWatcherFactory(logger, ms, r.Client, r.Recorder)
+	// create disruption cron reconciler
+	cronReconciler := &controllers.DisruptionCronReconciler{
+		Client:         mgr.GetClient(),
+		BaseLog:        logger,
+		Scheme:         mgr.GetScheme(),
+		Recorder:       r.Recorder,
+		TargetSelector: targetSelector,
+	}
+
+	if err := cronReconciler.SetupWithManager(mgr); err != nil {
+		logger.Errorw("unable to create controller", "controller", chaosv1beta1.DisruptionCronKind, "error", err)
+		os.Exit(1) //nolint:gocritic
+	}
+
+	// create chaos workflow reconciler
+	workflowReconciler := &controllers.ChaosWorkflowReconciler{
+		Client:                 mgr.GetClient(),
+		Reader:                 mgr.GetAPIReader(),
+		BaseLog:                logger,
+		Scheme:                 mgr.GetScheme(),
+		Recorder:               r.Recorder,
+		AbortProbeAllowedHosts: cfg.Controller.AbortProbeAllowedHosts,
+	}
+
+	if err := workflowReconciler.SetupWithManager(mgr); err != nil {
+		logger.Errorw("unable to create controller", "controller", chaosv1beta1.ChaosWorkflowKind, "error", err)
+		os.Exit(1) //nolint:gocritic
+	}
+
+	r.TargetsHealth = watchers.NewTargetsHealth()
+	watcherFactory := watchers.NewWatcherFactory(logger, ms, r.Client, r.Recorder, r.TargetsHealth)
 	r.DisruptionsWatchersManager = watchers.NewDisruptionsWatchersManager(cont, watcherFactory, r.Reader, logger)
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -224,6 +258,7 @@ func main() {
 		DeleteOnlyFlag:                cfg.Controller.DeleteOnly,
 		HandlerEnabledFlag:            cfg.Handler.Enabled,
 		DefaultDurationFlag:           cfg.Controller.DefaultDuration,
+		AbortProbeAllowedHostsFlag:    cfg.Controller.AbortProbeAllowedHosts,
 		ChaosNamespace:                cfg.Injector.ChaosNamespace,
 		CloudServicesProvidersManager: cloudProviderManager,
 		Environment:                   cfg.Controller.SafeMode.Environment,
@@ -233,6 +268,37 @@ func main() {
 		os.Exit(1) //nolint:gocritic
 	}
 
+	// register disruption cron validating webhook
+	if err = (&chaosv1beta1.DisruptionCron{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", chaosv1beta1.DisruptionCronKind)
+		os.Exit(1) //nolint:gocritic
+	}
+
+	// register chaos workflow validating webhook
+	if err = (&chaosv1beta1.ChaosWorkflow{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", chaosv1beta1.ChaosWorkflowKind)
+		os.Exit(1) //nolint:gocritic
+	}
+
+	// register disruption template validating webhooks
+	if err = (&chaosv1beta1.DisruptionTemplate{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", chaosv1beta1.DisruptionTemplateKind)
+		os.Exit(1) //nolint:gocritic
+	}
+
+	if err = (&chaosv1beta1.ClusterDisruptionTemplate{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", chaosv1beta1.ClusterDisruptionTemplateKind)
+		os.Exit(1) //nolint:gocritic
+	}
+
+	// register disruption template mutating webhook, instantiating the disruptions referencing a template
+	mgr.GetWebhookServer().Register("/mutate-chaos-datadoghq-com-v1beta1-disruption-template", &webhook.Admission{
+		Handler: &chaoswebhook.DisruptionTemplateMutator{
+			Client: mgr.GetClient(),
+			Log:    logger,
+		},
+	})
+
 	if cfg.Handler.Enabled {
 		// register chaos handler init container mutating webhook
 		mgr.GetWebhookServer().Register("/mutate-v1-pod-chaos-handler-init-container", &webhook.Admission{
@@ -108,6 +108,48 @@ func (_c *IPTablesMock_Intercept_Call) RunAndReturn(run func(string, string, str
 	return _c
 }
 
+// Isolate provides a mock function with given fields: allowedHosts
+func (_m *IPTablesMock) Isolate(allowedHosts []string) error {
+	ret := _m.Called(allowedHosts)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]string) error); ok {
+		r0 = rf(allowedHosts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IPTablesMock_Isolate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Isolate'
+type IPTablesMock_Isolate_Call struct {
+	*mock.Call
+}
+
+// Isolate is a helper method to define mock.On call
+//   - allowedHosts []string
+func (_e *IPTablesMock_Expecter) Isolate(allowedHosts interface{}) *IPTablesMock_Isolate_Call {
+	return &IPTablesMock_Isolate_Call{Call: _e.mock.On("Isolate", allowedHosts)}
+}
+
+func (_c *IPTablesMock_Isolate_Call) Run(run func(allowedHosts []string)) *IPTablesMock_Isolate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]string))
+	})
+	return _c
+}
+
+func (_c *IPTablesMock_Isolate_Call) Return(_a0 error) *IPTablesMock_Isolate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IPTablesMock_Isolate_Call) RunAndReturn(run func([]string) error) *IPTablesMock_Isolate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // LogConntrack provides a mock function with given fields:
 func (_m *IPTablesMock) LogConntrack() error {
 	ret := _m.Called()
@@ -279,6 +321,90 @@ func (_c *IPTablesMock_RedirectTo_Call) RunAndReturn(run func(string, string, st
 	return _c
 }
 
+// Rules provides a mock function with given fields:
+func (_m *IPTablesMock) Rules() []Rule {
+	ret := _m.Called()
+
+	var r0 []Rule
+	if rf, ok := ret.Get(0).(func() []Rule); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Rule)
+		}
+	}
+
+	return r0
+}
+
+// IPTablesMock_Rules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Rules'
+type IPTablesMock_Rules_Call struct {
+	*mock.Call
+}
+
+// Rules is a helper method to define mock.On call
+func (_e *IPTablesMock_Expecter) Rules() *IPTablesMock_Rules_Call {
+	return &IPTablesMock_Rules_Call{Call: _e.mock.On("Rules")}
+}
+
+func (_c *IPTablesMock_Rules_Call) Run(run func()) *IPTablesMock_Rules_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *IPTablesMock_Rules_Call) Return(_a0 []Rule) *IPTablesMock_Rules_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IPTablesMock_Rules_Call) RunAndReturn(run func() []Rule) *IPTablesMock_Rules_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Verify provides a mock function with given fields:
+func (_m *IPTablesMock) Verify() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IPTablesMock_Verify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Verify'
+type IPTablesMock_Verify_Call struct {
+	*mock.Call
+}
+
+// Verify is a helper method to define mock.On call
+func (_e *IPTablesMock_Expecter) Verify() *IPTablesMock_Verify_Call {
+	return &IPTablesMock_Verify_Call{Call: _e.mock.On("Verify")}
+}
+
+func (_c *IPTablesMock_Verify_Call) Run(run func()) *IPTablesMock_Verify_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *IPTablesMock_Verify_Call) Return(_a0 error) *IPTablesMock_Verify_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IPTablesMock_Verify_Call) RunAndReturn(run func() error) *IPTablesMock_Verify_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 type mockConstructorTestingTNewIPTablesMock interface {
 	mock.TestingT
 	Cleanup(func())
@@ -8,6 +8,7 @@ package network
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	goiptables "github.com/coreos/go-iptables/iptables"
 	"go.uber.org/zap"
@@ -21,23 +22,29 @@ type IPTables interface {
 	Intercept(protocol string, port string, cgroupPath string, cgroupClassID string, injectorPodIP string) error
 	MarkCgroupPath(cgroupPath string, mark string) error
 	MarkClassID(classid string, mark string) error
+	Isolate(allowedHosts []string) error
+	Verify() error
+	Rules() []Rule
 }
 
 type iptables struct {
 	log           *zap.SugaredLogger
 	dryRun        bool
 	ip            *goiptables.IPTables
-	injectedRules []rule
+	injectedRules []Rule
 }
 
-type rule struct {
-	table    string
-	chain    string
-	rulespec []string
+// Rule is an iptables rule injected in a chain of a table
+type Rule struct {
+	Table    string   `json:"table"`
+	Chain    string   `json:"chain"`
+	Rulespec []string `json:"rulespec"`
 }
 
 const (
 	chaosChainName = "CHAOS-DNS"
+	// isolationComment identifies the isolation rules so they can't be mistaken with existing rules of the node
+	isolationComment = "chaos-controller network isolation"
 )
 
 // NewIPTables returns an implementation of the IPTables interface that can log
@@ -48,7 +55,20 @@ func NewIPTables(log *zap.SugaredLogger, dryRun bool) (IPTables, error) {
 		log:           log,
 		dryRun:        dryRun,
 		ip:            ip,
-		injectedRules: []rule{},
+		injectedRules: []Rule{},
+	}, err
+}
+
+// NewIPTablesWithRules returns an implementation of the IPTables interface aware of the given previously injected rules,
+// allowing to clear rules injected by another process
+func NewIPTablesWithRules(log *zap.SugaredLogger, dryRun bool, rules []Rule) (IPTables, error) {
+	ip, err := goiptables.New()
+
+	return &iptables{
+		log:           log,
+		dryRun:        dryRun,
+		ip:            ip,
+		injectedRules: rules,
 	}, err
 }
 
@@ -62,22 +82,22 @@ func (i *iptables) Clear() error {
 
 	// remove previously injected rules
 	for _, r := range i.injectedRules {
-		i.log.Infow("deleting injected iptables rule", "chain", r.chain, "table", r.table, "rulespec", r.rulespec)
+		i.log.Infow("deleting injected iptables rule", "chain", r.Chain, "table", r.Table, "rulespec", r.Rulespec)
 
 		// skip if it does not exist anymore for idempotency
-		exists, err := i.ip.Exists(r.table, r.chain, r.rulespec...)
+		exists, err := i.ip.Exists(r.Table, r.Chain, r.Rulespec...)
 		if err != nil {
 			return err
 		}
 
 		if !exists {
-			i.log.Infow("iptables rule doesn't exist anymore, skipping cleaning", "table", r.table, "chain", r.chain, "rulespec", r.rulespec)
+			i.log.Infow("iptables rule doesn't exist anymore, skipping cleaning", "table", r.Table, "chain", r.Chain, "rulespec", r.Rulespec)
 
 			continue
 		}
 
 		// delete rule
-		if err := i.ip.Delete(r.table, r.chain, r.rulespec...); err != nil {
+		if err := i.ip.Delete(r.Table, r.Chain, r.Rulespec...); err != nil {
 			return err
 		}
 	}
@@ -90,6 +110,31 @@ func (i *iptables) Clear() error {
 	return nil
 }
 
+// Verify returns an error if one of the previously injected rules does not exist anymore
+func (i *iptables) Verify() error {
+	if i.dryRun {
+		return nil
+	}
+
+	for _, r := range i.injectedRules {
+		exists, err := i.ip.Exists(r.Table, r.Chain, r.Rulespec...)
+		if err != nil {
+			return err
+		}
+
+		if !exists {
+			return fmt.Errorf("injected iptables rule %s does not exist anymore in the %s chain of the %s table", strings.Join(r.Rulespec, " "), r.Chain, r.Table)
+		}
+	}
+
+	return nil
+}
+
+// Rules returns the rules injected so far
+func (i *iptables) Rules() []Rule {
+	return i.injectedRules
+}
+
 // LogConntrack creates a rule logging packets with a new or established connection state,
 // usually used to enable the conntrack tracking in non-root network namespaces
 func (i *iptables) LogConntrack() error {
@@ -164,11 +209,59 @@ func (i *iptables) MarkClassID(classID string, mark string) error {
 	return i.insert("mangle", "OUTPUT", "-m", "cgroup", "--cgroup", classID, "-j", "MARK", "--set-mark", mark)
 }
 
+// Isolate drops all the packets going through the network namespace except the loopback ones
+// and the ones exchanged with the given hosts (IPs or CIDRs)
+func (i *iptables) Isolate(allowedHosts []string) error {
+	// the accept rules are inserted first so the allowed traffic is never dropped while isolating,
+	// the drop rules are then inserted right after them
+	acceptRules := map[string][][]string{
+		"INPUT":   {isolationRulespec("ACCEPT", "-i", "lo")},
+		"OUTPUT":  {isolationRulespec("ACCEPT", "-o", "lo")},
+		"FORWARD": {},
+	}
+
+	for _, host := range allowedHosts {
+		acceptRules["INPUT"] = append(acceptRules["INPUT"], isolationRulespec("ACCEPT", "-s", host))
+		acceptRules["OUTPUT"] = append(acceptRules["OUTPUT"], isolationRulespec("ACCEPT", "-d", host))
+		acceptRules["FORWARD"] = append(acceptRules["FORWARD"], isolationRulespec("ACCEPT", "-s", host), isolationRulespec("ACCEPT", "-d", host))
+	}
+
+	chains := []string{"INPUT", "OUTPUT", "FORWARD"}
+
+	for _, chain := range chains {
+		for _, rulespec := range acceptRules[chain] {
+			if err := i.insert("filter", chain, rulespec...); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, chain := range chains {
+		if err := i.insertAt("filter", chain, len(acceptRules[chain])+1, isolationRulespec("DROP")...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isolationRulespec returns the rulespec of an isolation rule jumping to the given target
+func isolationRulespec(target string, match ...string) []string {
+	return append(match, "-m", "comment", "--comment", isolationComment, "-j", target)
+}
+
 // insert creates a new iptables rule definition, stores it
 // for further cleanup and inserts the rule in the given table and chain
 // at the first position
 func (i *iptables) insert(table string, chain string, rulespec ...string) error {
-	i.log.Infow("injecting iptables rule", "table", table, "chain", chain, "rulespec", rulespec)
+	return i.insertAt(table, chain, 1, rulespec...)
+}
+
+// insertAt creates a new iptables rule definition, stores it
+// for further cleanup and inserts the rule in the given table and chain
+// at the given position
+func (i *iptables) insertAt(table string, chain string, position int, rulespec ...string) error {
+	i.log.Infow("injecting iptables rule", "table", table, "chain", chain, "position", position, "rulespec", rulespec)
 
 	if i.dryRun {
 		return nil
@@ -201,14 +294,14 @@ func (i *iptables) insert(table string, chain string, rulespec ...string) error
 	}
 
 	// inject rule
-	if err := i.ip.Insert(table, chain, 1, rulespec...); err != nil {
+	if err := i.ip.Insert(table, chain, position, rulespec...); err != nil {
 		return fmt.Errorf("error injecting rule: %w", err)
 	}
 
-	r := rule{
-		table:    table,
-		chain:    chain,
-		rulespec: rulespec,
+	r := Rule{
+		Table:    table,
+		Chain:    chain,
+		Rulespec: rulespec,
 	}
 
 	// store rule for further cleanup
@@ -66,6 +66,7 @@ type TrafficController interface {
 	AddFwFilter(ifaces []string, parent string, handle string, flowid string) error
 	AddOutputLimit(ifaces []string, parent string, handle string, bytesPerSec uint) error
 	ClearQdisc(ifaces []string) error
+	ListQdiscs(iface string) (string, error)
 }
 
 type tcExecuter interface {
@@ -197,6 +198,13 @@ func (t *tc) ClearQdisc(ifaces []string) error {
 	return nil
 }
 
+// ListQdiscs returns the output of the qdiscs listing of the given interface
+func (t *tc) ListQdiscs(iface string) (string, error) {
+	_, stdout, err := t.executer.Run([]string{"qdisc", "show", "dev", iface})
+
+	return stdout, err
+}
+
 // AddFilter generates a filter to redirect the traffic matching the given ip, port and protocol to the given flowid
 // this function relies on the tc flower (https://man7.org/linux/man-pages/man8/tc-flower.8.html) filtering module
 func (t *tc) AddFilter(ifaces []string, parent string, handle string, srcIP, dstIP *net.IPNet, srcPort, dstPort int, protocol protocol, connState connState, flowid string) (uint32, error) {
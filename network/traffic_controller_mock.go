@@ -358,6 +358,58 @@ func (_c *TrafficControllerMock_DeleteFilter_Call) RunAndReturn(run func(string,
 	return _c
 }
 
+// ListQdiscs provides a mock function with given fields: iface
+func (_m *TrafficControllerMock) ListQdiscs(iface string) (string, error) {
+	ret := _m.Called(iface)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
+		return rf(iface)
+	}
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(iface)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(iface)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TrafficControllerMock_ListQdiscs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListQdiscs'
+type TrafficControllerMock_ListQdiscs_Call struct {
+	*mock.Call
+}
+
+// ListQdiscs is a helper method to define mock.On call
+//   - iface string
+func (_e *TrafficControllerMock_Expecter) ListQdiscs(iface interface{}) *TrafficControllerMock_ListQdiscs_Call {
+	return &TrafficControllerMock_ListQdiscs_Call{Call: _e.mock.On("ListQdiscs", iface)}
+}
+
+func (_c *TrafficControllerMock_ListQdiscs_Call) Run(run func(iface string)) *TrafficControllerMock_ListQdiscs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *TrafficControllerMock_ListQdiscs_Call) Return(_a0 string, _a1 error) *TrafficControllerMock_ListQdiscs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TrafficControllerMock_ListQdiscs_Call) RunAndReturn(run func(string) (string, error)) *TrafficControllerMock_ListQdiscs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 type mockConstructorTestingTNewTrafficControllerMock interface {
 	mock.TestingT
 	Cleanup(func())
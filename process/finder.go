@@ -0,0 +1,125 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package process
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Info describes a running process
+type Info struct {
+	PID     int
+	Name    string
+	Cmdline string
+}
+
+// Finder finds the running processes matching a pattern, never returning the calling process
+type Finder interface {
+	// FindInNamespace returns the processes sharing the PID namespace of the given process
+	// whose name or command line matches the given pattern
+	FindInNamespace(pid int, pattern *regexp.Regexp) ([]Info, error)
+	// Find returns the given processes whose name or command line matches the given pattern
+	Find(pids []int, pattern *regexp.Regexp) ([]Info, error)
+}
+
+type finder struct {
+	procPath string
+}
+
+// NewFinder creates a new process finder relying on the given proc filesystem path
+func NewFinder(procPath string) Finder {
+	return finder{
+		procPath: procPath,
+	}
+}
+
+func (f finder) FindInNamespace(pid int, pattern *regexp.Regexp) ([]Info, error) {
+	namespace, err := f.pidNamespace(pid)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the pid namespace of process %d: %w", pid, err)
+	}
+
+	entries, err := os.ReadDir(f.procPath)
+	if err != nil {
+		return nil, fmt.Errorf("error listing the processes: %w", err)
+	}
+
+	pids := []int{}
+
+	for _, entry := range entries {
+		candidate, err := strconv.Atoi(entry.Name())
+		if err != nil || !entry.IsDir() {
+			continue
+		}
+
+		// the process may have exited in the meantime, skip it
+		if candidateNamespace, err := f.pidNamespace(candidate); err != nil || candidateNamespace != namespace {
+			continue
+		}
+
+		pids = append(pids, candidate)
+	}
+
+	return f.Find(pids, pattern)
+}
+
+func (f finder) Find(pids []int, pattern *regexp.Regexp) ([]Info, error) {
+	processes := []Info{}
+	self := os.Getpid()
+
+	for _, pid := range pids {
+		// the injector must never signal itself, whatever the pattern
+		if pid == self {
+			continue
+		}
+
+		// the process may have exited in the meantime, skip it
+		info, err := f.info(pid)
+		if err != nil {
+			continue
+		}
+
+		if pattern.MatchString(info.Name) || pattern.MatchString(info.Cmdline) {
+			processes = append(processes, info)
+		}
+	}
+
+	sort.Slice(processes, func(i, j int) bool {
+		return processes[i].PID < processes[j].PID
+	})
+
+	return processes, nil
+}
+
+// info returns the name and command line of the given process
+func (f finder) info(pid int) (Info, error) {
+	name, err := os.ReadFile(filepath.Join(f.procPath, strconv.Itoa(pid), "comm"))
+	if err != nil {
+		return Info{}, err
+	}
+
+	cmdline, err := os.ReadFile(filepath.Join(f.procPath, strconv.Itoa(pid), "cmdline"))
+	if err != nil {
+		return Info{}, err
+	}
+
+	return Info{
+		PID:     pid,
+		Name:    strings.TrimSpace(string(name)),
+		Cmdline: strings.TrimSpace(strings.ReplaceAll(string(cmdline), "\x00", " ")),
+	}, nil
+}
+
+// pidNamespace returns the pid namespace identifier of the given process
+func (f finder) pidNamespace(pid int) (string, error) {
+	return os.Readlink(filepath.Join(f.procPath, strconv.Itoa(pid), "ns", "pid"))
+}
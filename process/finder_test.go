@@ -0,0 +1,80 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package process_test
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/DataDog/chaos-controller/process"
+)
+
+var _ = Describe("Finder", func() {
+	var (
+		procPath string
+		finder   Finder
+	)
+
+	// addProcess adds a process to the fake proc filesystem
+	addProcess := func(pid int, name, cmdline, pidNamespace string) {
+		dir := filepath.Join(procPath, strconv.Itoa(pid))
+		Expect(os.MkdirAll(filepath.Join(dir, "ns"), 0o700)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(dir, "comm"), []byte(name+"\n"), 0o600)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(dir, "cmdline"), []byte(cmdline), 0o600)).To(Succeed())
+		Expect(os.Symlink(pidNamespace, filepath.Join(dir, "ns", "pid"))).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		procPath = GinkgoT().TempDir()
+		finder = NewFinder(procPath)
+
+		// the injector runs in the host pid namespace, like the kubelet
+		addProcess(1, "systemd", "/sbin/init", "pid:[1]")
+		addProcess(100, "kubelet", "/usr/bin/kubelet\x00--config\x00/etc/kubelet.yaml", "pid:[1]")
+		addProcess(os.Getpid(), "injector", "/usr/local/bin/chaos-injector\x00process-failure", "pid:[1]")
+
+		// a pod sharing its pid namespace between a sidecar and the target container
+		addProcess(200, "pause", "/pause", "pid:[2]")
+		addProcess(201, "sidecar", "/bin/sidecar", "pid:[2]")
+		addProcess(202, "worker", "/bin/worker\x00--queue\x00foo", "pid:[2]")
+	})
+
+	Describe("FindInNamespace", func() {
+		It("should return the matching processes of the namespace", func() {
+			Expect(finder.FindInNamespace(201, regexp.MustCompile("."))).To(Equal([]Info{
+				{PID: 200, Name: "pause", Cmdline: "/pause"},
+				{PID: 201, Name: "sidecar", Cmdline: "/bin/sidecar"},
+				{PID: 202, Name: "worker", Cmdline: "/bin/worker --queue foo"},
+			}))
+		})
+
+		It("should never return the calling process", func() {
+			Expect(finder.FindInNamespace(1, regexp.MustCompile("."))).To(Equal([]Info{
+				{PID: 1, Name: "systemd", Cmdline: "/sbin/init"},
+				{PID: 100, Name: "kubelet", Cmdline: "/usr/bin/kubelet --config /etc/kubelet.yaml"},
+			}))
+		})
+	})
+
+	Describe("Find", func() {
+		It("should only return the given processes matching the pattern", func() {
+			Expect(finder.Find([]int{202, 201}, regexp.MustCompile("queue"))).To(Equal([]Info{
+				{PID: 202, Name: "worker", Cmdline: "/bin/worker --queue foo"},
+			}))
+		})
+
+		It("should skip the processes which exited and the calling process", func() {
+			Expect(finder.Find([]int{os.Getpid(), 300, 202}, regexp.MustCompile("."))).To(Equal([]Info{
+				{PID: 202, Name: "worker", Cmdline: "/bin/worker --queue foo"},
+			}))
+		})
+	})
+})
@@ -46,12 +46,30 @@ func AddAllSafemodeObjects(disruption v1beta1.Disruption, k8sClient client.Clien
 		safemodeList = append(safemodeList, &safemodeDiskFailure)
 	}
 
+	if disruption.Spec.DiskFill != nil {
+		safemodeDiskFill := DiskFill{}
+		safemodeDiskFill.Init(disruption, k8sClient)
+		safemodeList = append(safemodeList, &safemodeDiskFill)
+	}
+
 	if disruption.Spec.ContainerFailure != nil {
 		safemodeContainerFailure := ContainerFailure{}
 		safemodeContainerFailure.Init(disruption, k8sClient)
 		safemodeList = append(safemodeList, &safemodeContainerFailure)
 	}
 
+	if disruption.Spec.ProcessFailure != nil {
+		safemodeProcessFailure := ProcessFailure{}
+		safemodeProcessFailure.Init(disruption, k8sClient)
+		safemodeList = append(safemodeList, &safemodeProcessFailure)
+	}
+
+	if disruption.Spec.ContainerFreeze != nil {
+		safemodeContainerFreeze := ContainerFreeze{}
+		safemodeContainerFreeze.Init(disruption, k8sClient)
+		safemodeList = append(safemodeList, &safemodeContainerFreeze)
+	}
+
 	if disruption.Spec.CPUPressure != nil {
 		safemodeCPU := CPU{}
 		safemodeCPU.Init(disruption, k8sClient)
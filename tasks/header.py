@@ -28,7 +28,11 @@ auto_generated_headers = [
 files_to_skip = [
     "api/v1beta1/zz_generated.deepcopy.go",
     "bin/injector/dns_disruption_resolver.py",
+    "chart/templates/generated/chaos.datadoghq.com_chaosworkflows.yaml",
+    "chart/templates/generated/chaos.datadoghq.com_clusterdisruptiontemplates.yaml",
+    "chart/templates/generated/chaos.datadoghq.com_disruptioncrons.yaml",
     "chart/templates/generated/chaos.datadoghq.com_disruptions.yaml",
+    "chart/templates/generated/chaos.datadoghq.com_disruptiontemplates.yaml",
     "chart/templates/generated/role.yaml",
     "cpuset/cpuset.go",
     "grpc/disruptionlistener/disruptionlistener_grpc.pb.go",
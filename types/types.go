@@ -58,17 +58,38 @@ const (
 	// InjectHandlerLabel is the expected label when a chaos handler init container must be injected
 	DisruptOnInitLabel = GroupName + "/disrupt-on-init"
 
+	// DisruptionCronNameLabel is the label used to identify the disruption cron which created a disruption
+	DisruptionCronNameLabel = GroupName + "/disruption-cron"
+
+	// ChaosWorkflowNameLabel is the label used to identify the chaos workflow which created a disruption
+	ChaosWorkflowNameLabel = GroupName + "/chaos-workflow"
+	// ChaosWorkflowStepLabel is the label used to identify the chaos workflow step which created a disruption
+	ChaosWorkflowStepLabel = GroupName + "/chaos-workflow-step"
+
 	// MultiDistruptionAllowed is the expected annotation to put on a pod to enable multi disruption
 	MultiDistruptionAllowed = GroupName + "/multi-disruption-allowed"
 
 	// DisruptionKindLabel is the label used to identify the disruption kind for a chaos pod
 	DisruptionKindLabel = GroupName + "/disruption-kind"
+	// TunablesAnnotation is the annotation holding the tunable fields values a chaos pod was created with
+	TunablesAnnotation = GroupName + "/tunables"
+	// InjectionResultAnnotation is the annotation holding the injection result reported by the injector of a chaos pod
+	InjectionResultAnnotation = GroupName + "/injection-result"
+	// InjectorControlPortName is the name of the chaos pod container port exposing the injector control API
+	InjectorControlPortName = "control"
+	// InjectorControlTokenSecretKey is the key of the injector control API token in the control token secret
+	InjectorControlTokenSecretKey = "token"
+
 	// DisruptionKindNetworkDisruption is a network failure disruption
 	DisruptionKindNetworkDisruption = "network-disruption"
 	// DisruptionKindNodeFailure is a node failure disruption
 	DisruptionKindNodeFailure = "node-failure"
 	// DisruptionKindContainerFailure is a container failure disruption
 	DisruptionKindContainerFailure = "container-failure"
+	// DisruptionKindProcessFailure is a process failure disruption
+	DisruptionKindProcessFailure = "process-failure"
+	// DisruptionKindContainerFreeze is a container freeze disruption
+	DisruptionKindContainerFreeze = "container-freeze"
 	// DisruptionKindCPUPressure is a CPU pressure disruption
 	DisruptionKindCPUPressure = "cpu-pressure"
 	// DisruptionKindCPUStress is a CPU pressure sub-disruption that stress a single container
@@ -77,6 +98,8 @@ const (
 	DisruptionKindDiskFailure = "disk-failure"
 	// DisruptionKindDiskPressure is a disk pressure disruption
 	DisruptionKindDiskPressure = "disk-pressure"
+	// DisruptionKindDiskFill is a disk fill disruption
+	DisruptionKindDiskFill = "disk-fill"
 	// DisruptionKindDNSDisruption is a dns disruption
 	DisruptionKindDNSDisruption = "dns-disruption"
 	// DisruptionKindGRPCDisruption is a grpc disruption
@@ -101,6 +124,9 @@ const (
 	// DisruptionInjectionStatusPausedInjected is the value of the injection status when the disruption was injected but is no longer due to target disappearance (and duration has not expired and disruption is not deleted)
 	// It imply the disruption could be re-injected at some point if new targets matching provided selector appears
 	DisruptionInjectionStatusPausedInjected DisruptionInjectionStatus = "PausedInjected"
+	// DisruptionInjectionStatusPaused is the value of the injection status of a disruption paused through its spec.paused field
+	// Its chaos pods are cleaned and the disruption will be re-injected once resumed
+	DisruptionInjectionStatusPaused DisruptionInjectionStatus = "Paused"
 	// DisruptionInjectionStatusPreviouslyNotInjected is the value of the injection status after the duration has expired and the disruption was not injected
 	DisruptionInjectionStatusPreviouslyNotInjected DisruptionInjectionStatus = "PreviouslyNotInjected"
 	// DisruptionInjectionStatusPreviouslyPartiallyInjected is the value of the injection status after the duration has expired and the disruption was partially injected
@@ -114,6 +140,8 @@ const (
 	DisruptionTargetInjectionStatusInjected DisruptionTargetInjectionStatus = "Injected"
 	// DisruptionInjectionStatusIsStuckOnRemoval is the value of the injection status when the injection could not be removed on the target
 	DisruptionTargetInjectionStatusStatusIsStuckOnRemoval DisruptionTargetInjectionStatus = "IsStuckOnRemoval"
+	// DisruptionTargetInjectionStatusDrifted is the value of the injection status when the injection silently disappeared from the target after being injected
+	DisruptionTargetInjectionStatusDrifted DisruptionTargetInjectionStatus = "Drifted"
 
 	// DisruptionNameLabel is the label used to identify the disruption name for a chaos pod. This is used to determine pod ownership.
 	DisruptionNameLabel = GroupName + "/disruption-name"
@@ -145,9 +173,12 @@ var DisruptionKindNames = []DisruptionKindName{
 	DisruptionKindNetworkDisruption,
 	DisruptionKindNodeFailure,
 	DisruptionKindContainerFailure,
+	DisruptionKindProcessFailure,
+	DisruptionKindContainerFreeze,
 	DisruptionKindCPUPressure,
 	DisruptionKindDiskPressure,
 	DisruptionKindDiskFailure,
+	DisruptionKindDiskFill,
 	DisruptionKindDNSDisruption,
 	DisruptionKindGRPCDisruption,
 }
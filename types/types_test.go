@@ -22,6 +22,7 @@ var _ = Describe("DisruptionInjectionStatus", func() {
 		Entry("injected returns false", types.DisruptionInjectionStatusInjected, false),
 		Entry("paused partially injected returns true", types.DisruptionInjectionStatusPausedPartiallyInjected, true),
 		Entry("paused injected returns true", types.DisruptionInjectionStatusPausedInjected, true),
+		Entry("paused returns false", types.DisruptionInjectionStatusPaused, false),
 		Entry("previously not injected returns false", types.DisruptionInjectionStatusPreviouslyNotInjected, false),
 		Entry("previously partially injected returns false", types.DisruptionInjectionStatusPreviouslyPartiallyInjected, false),
 		Entry("previously injected returns false", types.DisruptionInjectionStatusPreviouslyInjected, false),
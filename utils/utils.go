@@ -23,6 +23,7 @@ import (
 	"github.com/DataDog/chaos-controller/cloudservice"
 	"github.com/DataDog/chaos-controller/o11y/metrics"
 	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
@@ -38,6 +39,52 @@ func Contains(s []string, str string) bool {
 	return false
 }
 
+// GetPodReadyCondition returns the ready condition of the given pod, nil if the pod has none
+func GetPodReadyCondition(pod *corev1.Pod) *corev1.PodCondition {
+	for i, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady {
+			return &pod.Status.Conditions[i]
+		}
+	}
+
+	return nil
+}
+
+// IsPodReady returns true if the given pod has its ready condition set to true
+func IsPodReady(pod *corev1.Pod) bool {
+	cond := GetPodReadyCondition(pod)
+
+	return cond != nil && cond.Status == corev1.ConditionTrue
+}
+
+// GetPodRestartCount returns the sum of the restart counts of the given pod containers
+func GetPodRestartCount(pod *corev1.Pod) int32 {
+	restartCount := int32(0)
+	for _, status := range pod.Status.ContainerStatuses {
+		restartCount += status.RestartCount
+	}
+
+	return restartCount
+}
+
+// GetNodeReadyCondition returns the ready condition of the given node, nil if the node has none
+func GetNodeReadyCondition(node *corev1.Node) *corev1.NodeCondition {
+	for i, cond := range node.Status.Conditions {
+		if cond.Type == corev1.NodeReady {
+			return &node.Status.Conditions[i]
+		}
+	}
+
+	return nil
+}
+
+// IsNodeReady returns true if the given node has its ready condition set to true
+func IsNodeReady(node *corev1.Node) bool {
+	cond := GetNodeReadyCondition(node)
+
+	return cond != nil && cond.Status == corev1.ConditionTrue
+}
+
 type SetupWebhookWithManagerConfig struct {
 	Manager                       ctrl.Manager
 	Logger                        *zap.SugaredLogger
@@ -49,6 +96,7 @@ type SetupWebhookWithManagerConfig struct {
 	DeleteOnlyFlag                bool
 	HandlerEnabledFlag            bool
 	DefaultDurationFlag           time.Duration
+	AbortProbeAllowedHostsFlag    []string
 	ChaosNamespace                string
 	CloudServicesProvidersManager *cloudservice.CloudServicesProvidersManager
 	Environment                   string
@@ -9,6 +9,8 @@ import (
 	"fmt"
 
 	chaosv1beta1 "github.com/DataDog/chaos-controller/api/v1beta1"
+	chaostypes "github.com/DataDog/chaos-controller/types"
+	"github.com/DataDog/chaos-controller/utils"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/tools/record"
@@ -51,6 +53,13 @@ func (w ChaosPodHandler) OnUpdate(oldObj, newObj interface{}) {
 		return
 	}
 
+	// If a running chaos pod is not ready anymore, the injector detected its injection drifted
+	if oldPod.Status.Phase == corev1.PodRunning && newPod.Status.Phase == corev1.PodRunning && newPod.DeletionTimestamp == nil && utils.IsPodReady(oldPod) && !utils.IsPodReady(newPod) {
+		w.sendInjectionDriftedEvent(newPod)
+
+		return
+	}
+
 	// If the old and new phase are the same, do nothing
 	if oldPod.Status.Phase == newPod.Status.Phase {
 		return
@@ -95,3 +104,22 @@ func (w ChaosPodHandler) sendEvent(newPod *corev1.Pod) {
 		"chaosPodName", newPod.Name,
 	)
 }
+
+// sendInjectionDriftedEvent sends an event to the recorder notifying the injection of the given chaos pod is not in effect anymore
+func (w ChaosPodHandler) sendInjectionDriftedEvent(pod *corev1.Pod) {
+	eventReason := chaosv1beta1.EventChaosPodInjectionDrifted
+	eventType := chaosv1beta1.Events[eventReason].Type
+	eventMessage := fmt.Sprintf(chaosv1beta1.Events[eventReason].OnDisruptionTemplateMessage,
+		pod.Name,
+		pod.Labels[chaostypes.TargetLabel],
+	)
+
+	w.recorder.Event(w.disruption, eventType, string(eventReason), eventMessage)
+
+	w.log.Debugw("ChaosPodHandler UPDATE - Send injection drifted event",
+		"eventMessage", eventMessage,
+		"disruptionName", w.disruption.Name,
+		"disruptionNamespace", w.disruption.Namespace,
+		"chaosPodName", pod.Name,
+	)
+}
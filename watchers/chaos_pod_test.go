@@ -128,6 +128,38 @@ var _ = Describe("Chaos Pod watcher", func() {
 					})
 				})
 
+				When("the old pod is ready and the new pod is not ready anymore while running", func() {
+					BeforeEach(func() {
+						// Arrange
+						oldPodStatus := v1.PodStatus{
+							Phase:      v1.PodRunning,
+							Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}},
+						}
+						newPodStatus := v1.PodStatus{
+							Phase:      v1.PodRunning,
+							Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionFalse}},
+						}
+						oldPod, newPod = createPods(oldPodStatus, newPodStatus)
+					})
+
+					It("should send an injection drifted warning event", func() {
+						expectEventMessage := fmt.Sprintf(v1beta1.Events[v1beta1.EventChaosPodInjectionDrifted].OnDisruptionTemplateMessage, newPod.Name, "")
+
+						Eventually(eventRecorder.(*record.FakeRecorder).Events).Should(Receive(ContainSubstring(string(v1beta1.EventChaosPodInjectionDrifted)), ContainSubstring(expectEventMessage)))
+					})
+
+					When("the new pod is being deleted", func() {
+						BeforeEach(func() {
+							// Arrange
+							newPod.DeletionTimestamp = &metav1.Time{}
+						})
+
+						It("should not send a warning event", func() {
+							Consistently(eventRecorder.(*record.FakeRecorder).Events).ShouldNot(Receive())
+						})
+					})
+				})
+
 				When("the old pod is in a running phase  and the new pod is in a Succeed phase", func() {
 					BeforeEach(func() {
 						// Arrange
@@ -78,8 +78,8 @@ func (d disruptionsWatchersManager) CreateAllWatchers(disruption *v1beta1.Disrup
 	// Save the watcher manager for later use
 	d.watchersManagers[disruptionNamespacedName] = watcherManager
 
-	// Calculate a hash of the disruption spec (excluding the count field)
-	disSpecHash, err := disruption.Spec.HashNoCount()
+	// Calculate a hash of the disruption spec (excluding the count and tunable fields)
+	disSpecHash, err := disruption.Spec.WithoutTunables().HashNoCount()
 	if err != nil {
 		return err
 	}
@@ -30,19 +30,22 @@ type Factory interface {
 }
 
 type factory struct {
-	log         *zap.SugaredLogger
-	metricSinks metrics.Sink
-	reader      client.Reader
-	recorder    record.EventRecorder
+	log           *zap.SugaredLogger
+	metricSinks   metrics.Sink
+	reader        client.Reader
+	recorder      record.EventRecorder
+	targetsHealth *TargetsHealth
 }
 
 // NewWatcherFactory creates a new instance of the factory for creating new watcher instances.
-func NewWatcherFactory(logger *zap.SugaredLogger, metricSinks metrics.Sink, reader client.Reader, recorder record.EventRecorder) Factory {
+// The disruption target watchers store the health of the targets they see in the given targets health store if not nil.
+func NewWatcherFactory(logger *zap.SugaredLogger, metricSinks metrics.Sink, reader client.Reader, recorder record.EventRecorder, targetsHealth *TargetsHealth) Factory {
 	return factory{
-		log:         logger,
-		metricSinks: metricSinks,
-		reader:      reader,
-		recorder:    recorder,
+		log:           logger,
+		metricSinks:   metricSinks,
+		reader:        reader,
+		recorder:      recorder,
+		targetsHealth: targetsHealth,
 	}
 }
 
@@ -84,6 +87,7 @@ func (f factory) NewDisruptionTargetWatcher(name string, enableObserver bool, di
 		enableObserver: enableObserver,
 		disruption:     disruption,
 		metricsSink:    f.metricSinks,
+		targetsHealth:  f.targetsHealth,
 		log:            f.log,
 	}
 
@@ -37,7 +37,7 @@ var _ = Describe("Watcher factory", func() {
 	})
 
 	JustBeforeEach(func() {
-		watcherFactory = watchers.NewWatcherFactory(logger, &noopSink, &readerMock, eventRecorderMock)
+		watcherFactory = watchers.NewWatcherFactory(logger, &noopSink, &readerMock, eventRecorderMock, nil)
 	})
 
 	It("should not be nil", func() {
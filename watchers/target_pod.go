@@ -30,6 +30,7 @@ type DisruptionTargetHandler struct {
 	enableObserver bool
 	disruption     *v1beta1.Disruption
 	metricsSink    metrics.Sink
+	targetsHealth  *TargetsHealth
 	log            *zap.SugaredLogger
 }
 
@@ -48,6 +49,7 @@ func (d DisruptionTargetHandler) OnAdd(obj interface{}) {
 	)
 
 	d.OnChangeHandleMetricsSink(pod, node, okPod, okNode)
+	d.OnChangeHandleTargetsHealth(pod, node, okPod, okNode)
 }
 
 // OnDelete target
@@ -65,6 +67,10 @@ func (d DisruptionTargetHandler) OnDelete(obj interface{}) {
 	)
 
 	d.OnChangeHandleMetricsSink(pod, node, okPod, okNode)
+
+	if d.targetsHealth != nil && (okPod || okNode) {
+		d.targetsHealth.Delete(d.disruption.UID, targetName)
+	}
 }
 
 // OnUpdate target
@@ -87,6 +93,7 @@ func (d DisruptionTargetHandler) OnUpdate(oldObj, newObj interface{}) {
 	)
 
 	d.OnChangeHandleMetricsSink(newPod, newNode, okNewPod, okNewNode)
+	d.OnChangeHandleTargetsHealth(newPod, newNode, okNewPod, okNewNode)
 
 	if d.enableObserver {
 		d.OnChangeHandleNotifierSink(oldPod, newPod, oldNode, newNode, okOldPod, okNewPod, okOldNode, okNewNode)
@@ -105,6 +112,20 @@ func (d DisruptionTargetHandler) OnChangeHandleMetricsSink(pod *corev1.Pod, node
 	}
 }
 
+// OnChangeHandleTargetsHealth stores the health of the changed target, read by the controller to evaluate the abort conditions
+func (d DisruptionTargetHandler) OnChangeHandleTargetsHealth(pod *corev1.Pod, node *corev1.Node, okPod, okNode bool) {
+	if d.targetsHealth == nil {
+		return
+	}
+
+	switch {
+	case okPod:
+		d.targetsHealth.Set(d.disruption.UID, pod.Name, GetPodHealth(pod))
+	case okNode:
+		d.targetsHealth.Set(d.disruption.UID, node.Name, GetNodeHealth(node))
+	}
+}
+
 // OnChangeHandleNotifierSink Trigger Notifier Sink on changes in the targets
 func (d DisruptionTargetHandler) OnChangeHandleNotifierSink(oldPod, newPod *corev1.Pod, oldNode, newNode *corev1.Node, okOldPod, okNewPod, okOldNode, okNewNode bool) {
 	var objectToNotify runtime.Object
@@ -0,0 +1,108 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package watchers
+
+import (
+	"sync"
+	"time"
+
+	"github.com/DataDog/chaos-controller/utils"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// TargetHealth is the health of a disruption target
+type TargetHealth struct {
+	// Ready is true if the target ready condition is true
+	Ready bool
+
+	// Since is the last transition time of the target ready condition, its creation time if it has none
+	Since time.Time
+
+	// RestartCount is the sum of the restart counts of the target containers, always 0 for nodes
+	RestartCount int32
+}
+
+// TargetsHealth stores the health of the disruptions targets as seen by the disruption target watchers,
+// so the controller can evaluate the abort conditions without getting each target on every reconcile loop
+type TargetsHealth struct {
+	lock    sync.RWMutex
+	targets map[types.UID]map[string]TargetHealth
+}
+
+// NewTargetsHealth creates an empty targets health store
+func NewTargetsHealth() *TargetsHealth {
+	return &TargetsHealth{
+		targets: map[types.UID]map[string]TargetHealth{},
+	}
+}
+
+// Get returns the last known health of the given target of the given disruption, false if the target has not been seen yet
+func (t *TargetsHealth) Get(disruptionUID types.UID, targetName string) (TargetHealth, bool) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	health, found := t.targets[disruptionUID][targetName]
+
+	return health, found
+}
+
+// Clear removes the health of all the targets of the given disruption
+func (t *TargetsHealth) Clear(disruptionUID types.UID) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	delete(t.targets, disruptionUID)
+}
+
+// Set stores the health of the given target of the given disruption
+func (t *TargetsHealth) Set(disruptionUID types.UID, targetName string, health TargetHealth) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.targets[disruptionUID] == nil {
+		t.targets[disruptionUID] = map[string]TargetHealth{}
+	}
+
+	t.targets[disruptionUID][targetName] = health
+}
+
+// Delete removes the health of the given target of the given disruption
+func (t *TargetsHealth) Delete(disruptionUID types.UID, targetName string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	delete(t.targets[disruptionUID], targetName)
+}
+
+// GetPodHealth returns the health of the given pod
+func GetPodHealth(pod *corev1.Pod) TargetHealth {
+	health := TargetHealth{
+		Since:        pod.CreationTimestamp.Time,
+		RestartCount: utils.GetPodRestartCount(pod),
+	}
+
+	if cond := utils.GetPodReadyCondition(pod); cond != nil {
+		health.Ready = cond.Status == corev1.ConditionTrue
+		health.Since = cond.LastTransitionTime.Time
+	}
+
+	return health
+}
+
+// GetNodeHealth returns the health of the given node
+func GetNodeHealth(node *corev1.Node) TargetHealth {
+	health := TargetHealth{
+		Since: node.CreationTimestamp.Time,
+	}
+
+	if cond := utils.GetNodeReadyCondition(node); cond != nil {
+		health.Ready = cond.Status == corev1.ConditionTrue
+		health.Since = cond.LastTransitionTime.Time
+	}
+
+	return health
+}
@@ -0,0 +1,97 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/DataDog/chaos-controller/api/v1beta1"
+	"go.uber.org/zap"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// +kubebuilder:webhook:webhookVersions={v1},path=/mutate-chaos-datadoghq-com-v1beta1-disruption-template,mutating=true,failurePolicy=fail,sideEffects=None,groups=chaos.datadoghq.com,resources=disruptions,verbs=create,versions=v1beta1,name=mdisruptiontemplate.kb.io,admissionReviewVersions={v1,v1beta1}
+
+// DisruptionTemplateMutator instantiates the disruptions referencing a template, before they are defaulted and validated
+type DisruptionTemplateMutator struct {
+	Client  client.Client
+	Log     *zap.SugaredLogger
+	decoder *admission.Decoder
+}
+
+func (m *DisruptionTemplateMutator) InjectDecoder(d *admission.Decoder) error {
+	m.decoder = d
+
+	return nil
+}
+
+func (m *DisruptionTemplateMutator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	dis := &v1beta1.Disruption{}
+
+	// ensure decoder is set
+	if m.decoder == nil {
+		m.Log.Errorw("webhook decoder seems to be nil while it should not, aborting")
+
+		return admission.Errored(http.StatusInternalServerError, fmt.Errorf("webhook decoder is not set"))
+	}
+
+	// decode object
+	if err := m.decoder.Decode(req, dis); err != nil {
+		m.Log.Errorw("error decoding disruption object", "error", err, "disruptionName", req.Name, "disruptionNamespace", req.Namespace)
+
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	if dis.Spec.TemplateRef == nil {
+		return admission.Allowed("no template to instantiate")
+	}
+
+	ref := *dis.Spec.TemplateRef
+	log := m.Log.With("disruptionName", dis.Name, "disruptionNamespace", dis.Namespace, "templateKind", ref.GetKind(), "templateName", ref.Name)
+
+	template, err := v1beta1.GetDisruptionTemplateSpec(ctx, m.Client, dis.Namespace, ref)
+	if err != nil {
+		log.Errorw("error getting the disruption template", "error", err)
+
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	// work on the raw object so only the fields set by the template replace the ones of the disruption
+	raw := map[string]interface{}{}
+	if err := json.Unmarshal(req.Object.Raw, &raw); err != nil {
+		log.Errorw("error decoding raw disruption object", "error", err)
+
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	spec, _ := raw["spec"].(map[string]interface{})
+	if spec == nil {
+		spec = map[string]interface{}{}
+	}
+
+	if err := template.Apply(spec, ref.Parameters); err != nil {
+		log.Infow("error instantiating the disruption template", "error", err)
+
+		return admission.Errored(http.StatusBadRequest, fmt.Errorf("error instantiating %s %s: %w", ref.GetKind(), ref.Name, err))
+	}
+
+	raw["spec"] = spec
+
+	marshaled, err := json.Marshal(raw)
+	if err != nil {
+		log.Errorw("error encoding instantiated disruption", "error", err)
+
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	log.Infow("disruption instantiated from template", "parameters", ref.Parameters)
+
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaled)
+}
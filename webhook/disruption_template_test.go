@@ -0,0 +1,122 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023 Datadog, Inc.
+
+package webhook_test
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/DataDog/chaos-controller/api/v1beta1"
+	. "github.com/DataDog/chaos-controller/webhook"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var _ = Describe("DisruptionTemplateMutator", func() {
+	var (
+		mutator  *DisruptionTemplateMutator
+		rawSpec  string
+		response admission.Response
+	)
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(v1beta1.AddToScheme(scheme)).To(Succeed())
+
+		template := &v1beta1.DisruptionTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "network-drop", Namespace: "demo"},
+			Spec: v1beta1.DisruptionTemplateSpec{
+				Parameters: []v1beta1.DisruptionTemplateParameter{{Name: "drop", Type: v1beta1.DisruptionTemplateParameterTypeInteger}},
+				Disruption: runtime.RawExtension{Raw: []byte(`{"network": {"drop": "${drop}"}}`)},
+			},
+		}
+
+		decoder, err := admission.NewDecoder(scheme)
+		Expect(err).ToNot(HaveOccurred())
+
+		mutator = &DisruptionTemplateMutator{
+			Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(template).Build(),
+			Log:    logger,
+		}
+		Expect(mutator.InjectDecoder(decoder)).To(Succeed())
+
+		rawSpec = `{"selector": {"app": "demo"}, "count": 1, "templateRef": {"name": "network-drop", "parameters": {"drop": "30"}}}`
+	})
+
+	JustBeforeEach(func() {
+		raw := []byte(`{"apiVersion": "chaos.datadoghq.com/v1beta1", "kind": "Disruption", "metadata": {"name": "demo", "namespace": "demo"}, "spec": ` + rawSpec + `}`)
+
+		response = mutator.Handle(context.Background(), admission.Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{
+				Name:      "demo",
+				Namespace: "demo",
+				Operation: admissionv1.Create,
+				Object:    runtime.RawExtension{Raw: raw},
+			},
+		})
+	})
+
+	It("should patch the disruption with the instantiated template", func() {
+		Expect(response.Allowed).To(BeTrue())
+		Expect(response.Patches).To(ContainElement(HaveField("Path", "/spec/network")))
+
+		for _, patch := range response.Patches {
+			if patch.Path == "/spec/network" {
+				Expect(patch.Value).To(HaveKeyWithValue("drop", BeNumerically("==", 30)))
+			}
+		}
+	})
+
+	Context("without template reference", func() {
+		BeforeEach(func() {
+			rawSpec = `{"selector": {"app": "demo"}, "count": 1, "network": {"drop": 10}}`
+		})
+
+		It("should allow the disruption without patching it", func() {
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Patches).To(BeEmpty())
+		})
+	})
+
+	Context("with a missing template", func() {
+		BeforeEach(func() {
+			rawSpec = `{"selector": {"app": "demo"}, "count": 1, "templateRef": {"name": "unknown"}}`
+		})
+
+		It("should reject the disruption", func() {
+			Expect(response.Allowed).To(BeFalse())
+			Expect(response.Result.Code).To(BeEquivalentTo(http.StatusBadRequest))
+		})
+	})
+
+	Context("with a missing parameter", func() {
+		BeforeEach(func() {
+			rawSpec = `{"selector": {"app": "demo"}, "count": 1, "templateRef": {"name": "network-drop"}}`
+		})
+
+		It("should reject the disruption", func() {
+			Expect(response.Allowed).To(BeFalse())
+			Expect(response.Result.Code).To(BeEquivalentTo(http.StatusBadRequest))
+			Expect(response.Result.Message).To(ContainSubstring("drop"))
+		})
+	})
+
+	Context("without decoder", func() {
+		BeforeEach(func() {
+			Expect(mutator.InjectDecoder(nil)).To(Succeed())
+		})
+
+		It("should fail with an internal error", func() {
+			Expect(response.Allowed).To(BeFalse())
+			Expect(response.Result.Code).To(BeEquivalentTo(http.StatusInternalServerError))
+		})
+	})
+})